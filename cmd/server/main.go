@@ -5,6 +5,8 @@ import (
 	"html/template"
 	"log"
 	"net/http"
+	"strconv"
+	"strings"
 
 	"github.com/gin-contrib/sessions"
 	"github.com/gin-contrib/sessions/cookie"
@@ -15,6 +17,11 @@ import (
 	"github.com/choiexe1/hongik-academy/internal/db/sqlc"
 	"github.com/choiexe1/hongik-academy/internal/handlers"
 	"github.com/choiexe1/hongik-academy/internal/middleware"
+	"github.com/choiexe1/hongik-academy/internal/permission"
+	"github.com/choiexe1/hongik-academy/internal/report"
+	"github.com/choiexe1/hongik-academy/internal/sharelink"
+	"github.com/choiexe1/hongik-academy/internal/storage"
+	"github.com/choiexe1/hongik-academy/internal/usersession"
 )
 
 func main() {
@@ -28,16 +35,33 @@ func main() {
 
 	queries := sqlc.New(pool)
 
+	// PDF 리포트는 요청 때 글꼴을 읽으므로, 글꼴이 없으면 배포 직후 로그로 알 수 있도록 시작 시 확인.
+	// 글꼴이 없어도 리포트만 만들 수 없을 뿐 나머지 기능은 동작함
+	if err := report.CheckFont(cfg.ReportFontPath); err != nil {
+		log.Printf("Warning: PDF reports are unavailable until REPORT_FONT_PATH points to a TrueType font with Hangul glyphs such as NanumGothic.ttf (%q): %v", cfg.ReportFontPath, err)
+	}
+
+	uploadStorage, err := storage.NewLocalStorage(cfg.UploadDir)
+	if err != nil {
+		log.Fatalf("Unable to prepare upload directory: %v", err)
+	}
+
 	r := gin.Default()
+	// 로그인 시도 제한과 기록은 클라이언트 IP를 기준으로 하므로, 지정한 프록시가 아니면
+	// 클라이언트가 보낸 X-Forwarded-For를 믿지 않음
+	if err := r.SetTrustedProxies(cfg.TrustedProxies); err != nil {
+		log.Fatalf("Invalid TRUSTED_PROXIES: %v", err)
+	}
 
 	store := cookie.NewStore([]byte(cfg.SessionKey))
 	store.Options(sessions.Options{
-		MaxAge:   86400 * 3, // 3일
+		MaxAge:   int(usersession.Lifetime.Seconds()),
 		HttpOnly: true,
 		Path:     "/",
 		SameSite: http.SameSiteLaxMode,
 	})
 	r.Use(sessions.Sessions("session", store))
+	r.Use(middleware.CSRF())
 
 	// 템플릿 함수 등록
 	r.SetFuncMap(template.FuncMap{
@@ -63,6 +87,17 @@ func main() {
 		"int": func(i int64) int {
 			return int(i)
 		},
+		"won": func(amount int64) string {
+			str := strconv.FormatInt(amount, 10)
+			sign := ""
+			if amount < 0 {
+				sign, str = "-", str[1:]
+			}
+			for i := len(str) - 3; i > 0; i -= 3 {
+				str = str[:i] + "," + str[i:]
+			}
+			return sign + str
+		},
 		"slice": func(s interface{}, start, end int) string {
 			str, ok := s.(string)
 			if !ok {
@@ -82,53 +117,235 @@ func main() {
 	r.LoadHTMLGlob("templates/*.html")
 
 	authHandler := handlers.NewAuthHandler(queries)
-	dashboardHandler := handlers.NewDashboardHandler()
-	studentHandler := handlers.NewStudentHandler(queries)
-	userHandler := handlers.NewUserHandler(queries)
-	evaluationHandler := handlers.NewEvaluationHandler(queries)
+	dashboardHandler := handlers.NewDashboardHandler(queries)
+	studentHandler := handlers.NewStudentHandler(pool, queries)
+	userHandler := handlers.NewUserHandler(pool, queries)
+	evaluationHandler := handlers.NewEvaluationHandler(pool, queries, uploadStorage)
+	attendanceHandler := handlers.NewAttendanceHandler(pool, queries)
+	classHandler := handlers.NewClassHandler(pool, queries)
+	tuitionHandler := handlers.NewTuitionHandler(queries)
+	studentAPIHandler := handlers.NewStudentAPIHandler(pool, queries)
+	evaluationAPIHandler := handlers.NewEvaluationAPIHandler(pool, queries)
+	userAPIHandler := handlers.NewUserAPIHandler(pool, queries)
+	apiTokenHandler := handlers.NewAPITokenHandler(queries)
+	auditHandler := handlers.NewAuditHandler(queries)
+	trashHandler := handlers.NewTrashHandler(queries, uploadStorage)
+	studentImportHandler := handlers.NewStudentImportHandler(pool, queries)
+	reportHandler := handlers.NewReportHandler(queries, cfg.ReportFontPath)
+	// 공유 링크는 로그인 없이 원생 정보를 보여주므로 기본값이 있는 세션 키 대신 전용 키로만 서명
+	var shareSigner *sharelink.Signer
+	if len(cfg.ShareLinkKey) >= sharelink.MinKeyLength {
+		shareSigner = sharelink.NewSigner(cfg.ShareLinkKey)
+	} else {
+		log.Printf("SHARE_LINK_KEY is not set or shorter than %d characters; parent share links are disabled", sharelink.MinKeyLength)
+	}
+	shareLinkHandler := handlers.NewShareLinkHandler(queries, shareSigner, cfg.TrustedProxies)
+	parentHandler := handlers.NewParentHandler(queries)
+	evaluationImageHandler := handlers.NewEvaluationImageHandler(queries, uploadStorage)
+	rubricHandler := handlers.NewRubricHandler(queries)
+	evaluationTemplateHandler := handlers.NewEvaluationTemplateHandler(queries)
+	roleHandler := handlers.NewRoleHandler(pool, queries)
+	accountHandler := handlers.NewAccountHandler(pool, queries)
+	loginAttemptHandler := handlers.NewLoginAttemptHandler(queries)
 
 	r.GET("/", func(c *gin.Context) {
 		c.Redirect(302, "/login")
 	})
 	r.GET("/login", authHandler.ShowLoginPage)
 	r.POST("/login", authHandler.Login)
-	r.GET("/logout", authHandler.Logout)
+	r.GET("/login/2fa", authHandler.ShowTwoFactorPage)
+	r.POST("/login/2fa", authHandler.VerifyTwoFactor)
+	r.POST("/logout", authHandler.Logout)
+
+	// 평가표 단건 경로는 원생 소속과 수정 권한을 여기서 한 번에 확인
+	evaluationReader := middleware.EvaluationAccess(queries, false)
+	evaluationEditor := middleware.EvaluationAccess(queries, true)
+	apiEvaluationReader := middleware.APIEvaluationAccess(queries, false)
+	apiEvaluationEditor := middleware.APIEvaluationAccess(queries, true)
+
+	// 라우트별 필요 권한 (역할별 권한은 DB의 role_permissions에서 관리)
+	require := middleware.RequirePermission
+	apiRequire := middleware.APIRequirePermission
 
 	authorized := r.Group("/")
 	authorized.Use(middleware.AuthRequired(queries))
 	{
 		authorized.GET("/dashboard", dashboardHandler.ShowDashboard)
+		authorized.GET("/dashboard/stale-students", dashboardHandler.ListStaleStudents)
+
+		// 내 계정 (모든 역할)
+		authorized.GET("/account/sessions", accountHandler.ListSessions)
+		authorized.POST("/account/sessions/revoke-others", accountHandler.RevokeOtherSessions)
+		authorized.POST("/account/sessions/:id/revoke", accountHandler.RevokeSession)
+		authorized.GET("/account/password", accountHandler.ShowPasswordForm)
+		authorized.POST("/account/password", accountHandler.ChangePassword)
+		authorized.GET("/account/2fa", accountHandler.ShowTwoFactor)
+		authorized.POST("/account/2fa/enable", accountHandler.EnableTwoFactor)
+		authorized.POST("/account/2fa/recovery-codes", accountHandler.RegenerateRecoveryCodes)
+		authorized.POST("/account/2fa/disable", accountHandler.DisableTwoFactor)
 
 		// 원생 관리
-		authorized.GET("/students", studentHandler.ListStudents)
-		authorized.GET("/students/new", studentHandler.ShowCreateForm)
-		authorized.POST("/students", studentHandler.CreateStudent)
-		authorized.GET("/students/:id/edit", studentHandler.ShowEditForm)
-		authorized.POST("/students/:id", studentHandler.UpdateStudent)
-		authorized.POST("/students/:id/delete", studentHandler.DeleteStudent)
+		authorized.GET("/students", require(permission.StudentsRead), studentHandler.ListStudents)
+		authorized.GET("/students/new", require(permission.StudentsWrite), studentHandler.ShowCreateForm)
+		authorized.POST("/students", require(permission.StudentsWrite), studentHandler.CreateStudent)
+		authorized.GET("/students/export", require(permission.StudentsRead), studentHandler.ExportStudents)
+		authorized.GET("/students/import", require(permission.StudentsWrite), studentImportHandler.ShowImportPage)
+		authorized.POST("/students/import/preview", require(permission.StudentsWrite), studentImportHandler.PreviewImport)
+		authorized.POST("/students/import", require(permission.StudentsWrite), studentImportHandler.ConfirmImport)
+		authorized.GET("/students/:id/edit", require(permission.StudentsWrite), studentHandler.ShowEditForm)
+		authorized.POST("/students/:id", require(permission.StudentsWrite), studentHandler.UpdateStudent)
+		authorized.POST("/students/:id/delete", require(permission.StudentsDelete), studentHandler.DeleteStudent)
+		authorized.POST("/students/:id/status", require(permission.StudentsWrite), studentHandler.ChangeStatus)
+		authorized.GET("/students/:id/share", require(permission.StudentsWrite), shareLinkHandler.ShowShareForm)
+		authorized.POST("/students/:id/share", require(permission.StudentsWrite), shareLinkHandler.CreateShareLink)
+		authorized.POST("/students/:id/share/revoke", require(permission.StudentsWrite), shareLinkHandler.RevokeShareLinks)
 
 		// 평가표 관리
-		authorized.GET("/students/:id/evaluations", evaluationHandler.ListEvaluations)
-		authorized.GET("/students/:id/evaluations/new", evaluationHandler.ShowCreateForm)
-		authorized.POST("/students/:id/evaluations", evaluationHandler.CreateEvaluation)
-		authorized.GET("/students/:id/evaluations/:eval_id/edit", evaluationHandler.ShowEditForm)
-		authorized.POST("/students/:id/evaluations/:eval_id", evaluationHandler.UpdateEvaluation)
-		authorized.POST("/students/:id/evaluations/:eval_id/delete", evaluationHandler.DeleteEvaluation)
+		authorized.GET("/students/:id/evaluations", require(permission.EvaluationsRead), evaluationHandler.ListEvaluations)
+		authorized.GET("/students/:id/evaluations/new", require(permission.EvaluationsWrite), evaluationHandler.ShowCreateForm)
+		authorized.GET("/students/:id/evaluations/export", require(permission.EvaluationsRead), evaluationHandler.ExportEvaluations)
+		authorized.GET("/students/:id/evaluations/report", require(permission.EvaluationsRead), reportHandler.EvaluationReport)
+		authorized.POST("/students/:id/evaluations", require(permission.EvaluationsWrite), evaluationHandler.CreateEvaluation)
+		authorized.GET("/students/:id/evaluations/:eval_id/edit", require(permission.EvaluationsWrite), evaluationEditor, evaluationHandler.ShowEditForm)
+		authorized.POST("/students/:id/evaluations/:eval_id", require(permission.EvaluationsWrite), evaluationEditor, evaluationHandler.UpdateEvaluation)
+		authorized.POST("/students/:id/evaluations/:eval_id/delete", require(permission.EvaluationsDelete), evaluationEditor, evaluationHandler.DeleteEvaluation)
+		authorized.GET("/students/:id/evaluations/:eval_id/history", require(permission.EvaluationsRead), evaluationReader, evaluationHandler.ShowHistory)
+		authorized.POST("/students/:id/evaluations/:eval_id/revisions/:revision_id/revert", require(permission.EvaluationsWrite), evaluationEditor, evaluationHandler.RevertEvaluation)
+		authorized.GET("/students/:id/evaluations/:eval_id/images/:image_id", require(permission.EvaluationsRead), evaluationReader, evaluationImageHandler.ServeImage)
+		authorized.GET("/students/:id/evaluations/:eval_id/images/:image_id/thumbnail", require(permission.EvaluationsRead), evaluationReader, evaluationImageHandler.ServeThumbnail)
+		authorized.POST("/students/:id/evaluations/:eval_id/images/:image_id/delete", require(permission.EvaluationsWrite), evaluationEditor, evaluationImageHandler.DeleteImage)
+		authorized.GET("/students/:id/gallery", require(permission.EvaluationsRead), evaluationImageHandler.ShowGallery)
+
+		// 평가 템플릿
+		authorized.GET("/evaluation-templates", require(permission.EvaluationsWrite), evaluationTemplateHandler.ListTemplates)
+		authorized.POST("/evaluation-templates", require(permission.EvaluationsWrite), evaluationTemplateHandler.CreateTemplate)
+		authorized.GET("/evaluation-templates/:id/edit", require(permission.EvaluationsWrite), evaluationTemplateHandler.ShowEditForm)
+		authorized.POST("/evaluation-templates/:id", require(permission.EvaluationsWrite), evaluationTemplateHandler.UpdateTemplate)
+		authorized.POST("/evaluation-templates/:id/delete", require(permission.EvaluationsWrite), evaluationTemplateHandler.DeleteTemplate)
+
+		// 반 관리
+		authorized.GET("/classes", require(permission.ClassesRead), classHandler.ListClasses)
+		authorized.GET("/classes/new", require(permission.ClassesWrite), classHandler.ShowCreateForm)
+		authorized.POST("/classes", require(permission.ClassesWrite), classHandler.CreateClass)
+		authorized.GET("/classes/:id", require(permission.ClassesRead), classHandler.ShowClass)
+		authorized.GET("/classes/:id/edit", require(permission.ClassesWrite), classHandler.ShowEditForm)
+		authorized.POST("/classes/:id", require(permission.ClassesWrite), classHandler.UpdateClass)
+		authorized.POST("/classes/:id/delete", require(permission.ClassesWrite), classHandler.DeleteClass)
+		authorized.POST("/classes/:id/students", require(permission.ClassesWrite), classHandler.EnrollStudent)
+		authorized.POST("/classes/:id/students/:student_id/delete", require(permission.ClassesWrite), classHandler.WithdrawStudent)
+
+		// 출석 관리
+		authorized.GET("/attendance", require(permission.AttendanceRead), attendanceHandler.ShowRollCall)
+		authorized.POST("/attendance", require(permission.AttendanceWrite), attendanceHandler.SaveRollCall)
+		authorized.GET("/students/:id/attendance", require(permission.AttendanceRead), attendanceHandler.ListStudentAttendance)
+		authorized.POST("/students/:id/attendance", require(permission.AttendanceWrite), attendanceHandler.RecordAttendance)
+		authorized.POST("/students/:id/attendance/:attendance_id/delete", require(permission.AttendanceWrite), attendanceHandler.DeleteAttendance)
+
+		// 수강료 관리
+		authorized.GET("/tuition", require(permission.BillingRead), tuitionHandler.ListUnpaid)
+		authorized.GET("/tuition/plans", require(permission.BillingRead), tuitionHandler.ListPlans)
+		authorized.POST("/tuition/plans", require(permission.BillingWrite), tuitionHandler.CreatePlan)
+		authorized.GET("/tuition/plans/:id/edit", require(permission.BillingWrite), tuitionHandler.ShowEditPlanForm)
+		authorized.POST("/tuition/plans/:id", require(permission.BillingWrite), tuitionHandler.UpdatePlan)
+		authorized.POST("/tuition/plans/:id/delete", require(permission.BillingWrite), tuitionHandler.DeletePlan)
+		authorized.GET("/students/:id/tuition", require(permission.BillingRead), tuitionHandler.ShowStudentLedger)
+		authorized.POST("/students/:id/tuition/invoices", require(permission.BillingWrite), tuitionHandler.CreateInvoice)
+		authorized.POST("/students/:id/tuition/invoices/:invoice_id/delete", require(permission.BillingWrite), tuitionHandler.DeleteInvoice)
+		authorized.POST("/students/:id/tuition/payments", require(permission.BillingWrite), tuitionHandler.RecordPayment)
+		authorized.POST("/students/:id/tuition/payments/:payment_id/delete", require(permission.BillingWrite), tuitionHandler.DeletePayment)
 	}
 
-	// 관리자 전용 (admin role 필요)
+	// 관리자 메뉴 (경로별 권한 필요)
 	admin := r.Group("/admin")
-	admin.Use(middleware.AuthRequired(queries), middleware.AdminRequired())
+	admin.Use(middleware.AuthRequired(queries))
 	{
 		// 사용자 관리
-		admin.GET("/users", userHandler.ListUsers)
-		admin.GET("/users/new", userHandler.ShowCreateForm)
-		admin.POST("/users", userHandler.CreateUser)
-		admin.GET("/users/:id/edit", userHandler.ShowEditForm)
-		admin.POST("/users/:id", userHandler.UpdateUser)
-		admin.POST("/users/:id/delete", userHandler.DeleteUser)
+		admin.GET("/users", require(permission.UsersRead), userHandler.ListUsers)
+		admin.GET("/users/new", require(permission.UsersManage), userHandler.ShowCreateForm)
+		admin.POST("/users", require(permission.UsersManage), userHandler.CreateUser)
+		admin.GET("/users/:id/edit", require(permission.UsersRead), userHandler.ShowEditForm)
+		admin.POST("/users/:id", require(permission.UsersRead), userHandler.UpdateUser)
+		admin.POST("/users/:id/delete", require(permission.UsersManage), userHandler.DeleteUser)
+		admin.POST("/users/:id/unlock", require(permission.UsersManage), userHandler.UnlockUser)
+		admin.POST("/users/:id/2fa/reset", require(permission.UsersManage), userHandler.ResetTwoFactor)
+		admin.POST("/users/:id/password/reset", require(permission.UsersManage), userHandler.ResetPassword)
+
+		// API 토큰 관리
+		admin.GET("/users/:id/tokens", require(permission.UsersRead), apiTokenHandler.ListTokens)
+		admin.POST("/users/:id/tokens", require(permission.UsersRead), apiTokenHandler.CreateToken)
+		admin.POST("/users/:id/tokens/:token_id/revoke", require(permission.UsersRead), apiTokenHandler.RevokeToken)
+
+		// 감사 로그와 로그인 기록
+		admin.GET("/audit", require(permission.AuditRead), auditHandler.ListAuditLogs)
+		admin.GET("/login-attempts", require(permission.AuditRead), loginAttemptHandler.ListLoginAttempts)
+
+		// 휴지통
+		admin.GET("/trash", require(permission.TrashManage), trashHandler.ShowTrash)
+		admin.POST("/trash/students/:id/restore", require(permission.TrashManage), trashHandler.RestoreStudent)
+		admin.POST("/trash/students/:id/purge", require(permission.TrashManage), trashHandler.PurgeStudent)
+		admin.POST("/trash/evaluations/:id/restore", require(permission.TrashManage), trashHandler.RestoreEvaluation)
+		admin.POST("/trash/evaluations/:id/purge", require(permission.TrashManage), trashHandler.PurgeEvaluation)
+
+		// 평가 기준 (루브릭)
+		admin.GET("/rubric", require(permission.RubricManage), rubricHandler.ListCriteria)
+		admin.POST("/rubric", require(permission.RubricManage), rubricHandler.CreateCriterion)
+		admin.GET("/rubric/:id/edit", require(permission.RubricManage), rubricHandler.ShowEditForm)
+		admin.POST("/rubric/:id", require(permission.RubricManage), rubricHandler.UpdateCriterion)
+		admin.POST("/rubric/:id/delete", require(permission.RubricManage), rubricHandler.DeleteCriterion)
+
+		// 역할과 권한
+		admin.GET("/roles", require(permission.RolesManage), roleHandler.ListRoles)
+		admin.POST("/roles", require(permission.RolesManage), roleHandler.CreateRole)
+		admin.GET("/roles/:id/edit", require(permission.RolesManage), roleHandler.ShowEditForm)
+		admin.POST("/roles/:id", require(permission.RolesManage), roleHandler.UpdateRole)
+		admin.POST("/roles/:id/delete", require(permission.RolesManage), roleHandler.DeleteRole)
+	}
+
+	// 학부모 열람 (로그인 없이 서명된 공유 링크로만 접근)
+	parent := r.Group("/parent/:token")
+	parent.Use(middleware.ParentLinkRequired(shareSigner, queries))
+	{
+		parent.GET("", parentHandler.ShowEvaluations)
+		parent.GET("/attendance", parentHandler.ShowAttendance)
+	}
+
+	// JSON API
+	api := r.Group("/api/v1")
+	api.Use(middleware.APIAuthRequired(queries))
+	{
+		// 원생
+		api.GET("/students", apiRequire(permission.StudentsRead), studentAPIHandler.ListStudents)
+		api.POST("/students", apiRequire(permission.StudentsWrite), studentAPIHandler.CreateStudent)
+		api.GET("/students/:id", apiRequire(permission.StudentsRead), studentAPIHandler.GetStudent)
+		api.PUT("/students/:id", apiRequire(permission.StudentsWrite), studentAPIHandler.UpdateStudent)
+		api.DELETE("/students/:id", apiRequire(permission.StudentsDelete), studentAPIHandler.DeleteStudent)
+
+		// 평가표
+		api.GET("/students/:id/evaluations", apiRequire(permission.EvaluationsRead), evaluationAPIHandler.ListEvaluations)
+		api.POST("/students/:id/evaluations", apiRequire(permission.EvaluationsWrite), evaluationAPIHandler.CreateEvaluation)
+		api.GET("/students/:id/evaluations/:eval_id", apiRequire(permission.EvaluationsRead), apiEvaluationReader, evaluationAPIHandler.GetEvaluation)
+		api.PUT("/students/:id/evaluations/:eval_id", apiRequire(permission.EvaluationsWrite), apiEvaluationEditor, evaluationAPIHandler.UpdateEvaluation)
+		api.DELETE("/students/:id/evaluations/:eval_id", apiRequire(permission.EvaluationsDelete), apiEvaluationEditor, evaluationAPIHandler.DeleteEvaluation)
+
+		// 사용자
+		api.GET("/users", apiRequire(permission.UsersRead), userAPIHandler.ListUsers)
+		api.POST("/users", apiRequire(permission.UsersManage), userAPIHandler.CreateUser)
+		api.GET("/users/:id", apiRequire(permission.UsersRead), userAPIHandler.GetUser)
+		api.PUT("/users/:id", apiRequire(permission.UsersRead), userAPIHandler.UpdateUser)
+		api.DELETE("/users/:id", apiRequire(permission.UsersManage), userAPIHandler.DeleteUser)
 	}
 
+	// 존재하지 않는 API 경로는 JSON으로 응답
+	r.NoRoute(func(c *gin.Context) {
+		if strings.HasPrefix(c.Request.URL.Path, "/api/") {
+			c.JSON(http.StatusNotFound, gin.H{
+				"error": gin.H{"code": "not_found", "message": "요청한 API를 찾을 수 없습니다."},
+			})
+			return
+		}
+		c.String(http.StatusNotFound, "404 page not found")
+	})
+
 	log.Printf("Server starting on port %s", cfg.ServerPort)
 	if err := r.Run(":" + cfg.ServerPort); err != nil {
 		log.Fatalf("Failed to start server: %v", err)
@@ -10,6 +10,21 @@ source ./deploy.env
 
 REMOTE_DIR="/home/$SERVER_USER/academy"
 
+# PDF 리포트용 한글 글꼴. 글꼴이 없으면 서버는 시작하지만 PDF 리포트를 만들 수 없으므로 미리 알림
+REPORT_FONT_PATH="${REPORT_FONT_PATH:-fonts/NanumGothic.ttf}"
+DEPLOY_FONT=""
+case "$REPORT_FONT_PATH" in
+    /*) ;; # 서버에 따로 설치한 글꼴
+    *)
+        if [ -f "./$REPORT_FONT_PATH" ]; then
+            DEPLOY_FONT="$REPORT_FONT_PATH"
+        else
+            echo "경고: PDF 리포트용 한글 글꼴이 없습니다: $REPORT_FONT_PATH"
+            echo "NanumGothic.ttf 등 한글 TrueType 글꼴을 이 경로에 두거나 deploy.env의 REPORT_FONT_PATH를 수정하기 전까지 PDF 리포트를 만들 수 없습니다."
+        fi
+        ;;
+esac
+
 echo "=== 홍익미술학원 배포 스크립트 ==="
 
 # 1. 바이너리 빌드 (AMD64)
@@ -23,7 +38,8 @@ echo "빌드 완료: academy"
 
 # 2. 배포 파일 압축
 echo "[2/4] 파일 압축 중..."
-tar czf deploy.tar.gz academy templates migrations docker-compose.yaml
+# 상대 경로의 글꼴은 함께 배포
+tar czf deploy.tar.gz academy templates migrations docker-compose.yaml $DEPLOY_FONT
 echo "압축 완료: deploy.tar.gz"
 
 # 3. 서버로 전송
@@ -38,7 +54,7 @@ echo "전송 완료"
 # 4. 서버에서 압축 해제, 서비스 설정 및 재시작
 echo "[4/4] 서버 배포 및 재시작 중..."
 ssh -i $SSH_KEY -o StrictHostKeyChecking=no $SERVER_USER@$SERVER_IP \
-    "DB_USER='$DB_USER' DB_PASSWORD='$DB_PASSWORD' DB_NAME='$DB_NAME' SESSION_KEY='$SESSION_KEY'" \
+    "DB_USER='$DB_USER' DB_PASSWORD='$DB_PASSWORD' DB_NAME='$DB_NAME' SESSION_KEY='$SESSION_KEY' SHARE_LINK_KEY='$SHARE_LINK_KEY' REPORT_FONT_PATH='$REPORT_FONT_PATH' UPLOAD_DIR='${UPLOAD_DIR:-uploads}' TRUSTED_PROXIES='$TRUSTED_PROXIES'" \
     bash << 'EOF'
     set -e
 
@@ -66,6 +82,10 @@ Environment=DB_USER=$DB_USER
 Environment=DB_PASSWORD=$DB_PASSWORD
 Environment=DB_NAME=$DB_NAME
 Environment=SESSION_KEY=$SESSION_KEY
+Environment=SHARE_LINK_KEY=$SHARE_LINK_KEY
+Environment=REPORT_FONT_PATH=$REPORT_FONT_PATH
+Environment=UPLOAD_DIR=$UPLOAD_DIR
+Environment=TRUSTED_PROXIES=$TRUSTED_PROXIES
 ExecStart=/home/ubuntu/academy/academy
 Restart=always
 RestartSec=5
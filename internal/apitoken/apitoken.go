@@ -0,0 +1,32 @@
+// Package apitoken generates personal API tokens and the hashes stored for them.
+// Only the SHA-256 hash of a token is kept in the database; the plain token is shown
+// to the user once when it is created.
+package apitoken
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+)
+
+// Prefix marks strings issued by this package so they are easy to recognise in scripts and logs
+const Prefix = "hia_"
+
+// DisplayLength is how many leading characters of a token are stored for display
+const DisplayLength = len(Prefix) + 8
+
+// Generate returns a new random token together with its display prefix and hash
+func Generate() (token, display, hash string, err error) {
+	bytes := make([]byte, 32)
+	if _, err := rand.Read(bytes); err != nil {
+		return "", "", "", err
+	}
+	token = Prefix + hex.EncodeToString(bytes)
+	return token, token[:DisplayLength], Hash(token), nil
+}
+
+// Hash returns the hex encoded SHA-256 hash of a token
+func Hash(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -3,6 +3,7 @@ package config
 import (
 	"fmt"
 	"os"
+	"strings"
 )
 
 type Config struct {
@@ -13,17 +14,30 @@ type Config struct {
 	DBName     string
 	ServerPort string
 	SessionKey string
+	// ShareLinkKey signs parent share links. It has no default: without it no links are issued
+	ShareLinkKey string
+	// ReportFontPath is a TrueType font with Hangul glyphs embedded in PDF reports
+	ReportFontPath string
+	// UploadDir is where uploaded evaluation images are stored
+	UploadDir string
+	// TrustedProxies are the reverse proxies whose X-Forwarded-For is believed when
+	// working out the client IP; empty means the connection's address is always used
+	TrustedProxies []string
 }
 
 func Load() *Config {
 	return &Config{
-		DBHost:     getEnv("DB_HOST", "localhost"),
-		DBPort:     getEnv("DB_PORT", "5432"),
-		DBUser:     getEnv("DB_USER", "academy"),
-		DBPassword: getEnv("DB_PASSWORD", "academy123"),
-		DBName:     getEnv("DB_NAME", "academy"),
-		ServerPort: getEnv("SERVER_PORT", "8080"),
-		SessionKey: getEnv("SESSION_KEY", "super-secret-key-change-in-production"),
+		DBHost:         getEnv("DB_HOST", "localhost"),
+		DBPort:         getEnv("DB_PORT", "5432"),
+		DBUser:         getEnv("DB_USER", "academy"),
+		DBPassword:     getEnv("DB_PASSWORD", "academy123"),
+		DBName:         getEnv("DB_NAME", "academy"),
+		ServerPort:     getEnv("SERVER_PORT", "8080"),
+		SessionKey:     getEnv("SESSION_KEY", "super-secret-key-change-in-production"),
+		ShareLinkKey:   os.Getenv("SHARE_LINK_KEY"),
+		ReportFontPath: getEnv("REPORT_FONT_PATH", "fonts/NanumGothic.ttf"),
+		UploadDir:      getEnv("UPLOAD_DIR", "uploads"),
+		TrustedProxies: splitList(os.Getenv("TRUSTED_PROXIES")),
 	}
 }
 
@@ -38,3 +52,14 @@ func getEnv(key, defaultValue string) string {
 	}
 	return defaultValue
 }
+
+// splitList parses a comma separated environment value, skipping empty entries
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
@@ -0,0 +1,33 @@
+-- name: CreateApiToken :one
+INSERT INTO api_tokens (user_id, name, token_hash, token_prefix, expires_at)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING *;
+
+-- name: ListApiTokensByUser :many
+SELECT * FROM api_tokens
+WHERE user_id = $1
+ORDER BY created_at DESC;
+
+-- name: RevokeApiToken :one
+UPDATE api_tokens
+SET revoked_at = NOW()
+WHERE id = $1 AND user_id = $2 AND revoked_at IS NULL
+RETURNING *;
+
+-- name: RevokeAllUserApiTokens :exec
+UPDATE api_tokens
+SET revoked_at = NOW()
+WHERE user_id = $1 AND revoked_at IS NULL;
+
+-- name: GetActiveApiTokenByHash :one
+SELECT t.id, t.user_id, u.username, u.role
+FROM api_tokens t
+JOIN users u ON t.user_id = u.id
+WHERE t.token_hash = $1
+    AND t.revoked_at IS NULL
+    AND (t.expires_at IS NULL OR t.expires_at > NOW());
+
+-- name: TouchApiToken :exec
+UPDATE api_tokens
+SET last_used_at = NOW()
+WHERE id = $1;
@@ -0,0 +1,45 @@
+-- name: UpsertAttendance :one
+INSERT INTO attendance (student_id, attendance_date, status, memo, recorded_by)
+VALUES ($1, $2, $3, $4, $5)
+ON CONFLICT (student_id, attendance_date)
+DO UPDATE SET status = EXCLUDED.status, memo = EXCLUDED.memo, recorded_by = EXCLUDED.recorded_by, updated_at = NOW()
+RETURNING *;
+
+-- name: DeleteAttendance :exec
+DELETE FROM attendance
+WHERE id = $1 AND student_id = $2;
+
+-- name: DeleteAttendanceByDate :exec
+DELETE FROM attendance
+WHERE student_id = $1 AND attendance_date = $2;
+
+-- name: ListAttendanceByStudent :many
+SELECT a.*, u.name as recorded_by_name
+FROM attendance a
+LEFT JOIN users u ON a.recorded_by = u.id
+WHERE a.student_id = $1
+    AND (sqlc.narg('start_date')::date IS NULL OR a.attendance_date >= sqlc.narg('start_date')::date)
+    AND (sqlc.narg('end_date')::date IS NULL OR a.attendance_date <= sqlc.narg('end_date')::date)
+ORDER BY a.attendance_date DESC
+LIMIT $2 OFFSET $3;
+
+-- name: CountAttendanceByStudent :one
+SELECT COUNT(*) FROM attendance a
+WHERE a.student_id = $1
+    AND (sqlc.narg('start_date')::date IS NULL OR a.attendance_date >= sqlc.narg('start_date')::date)
+    AND (sqlc.narg('end_date')::date IS NULL OR a.attendance_date <= sqlc.narg('end_date')::date);
+
+-- name: SummarizeAttendanceByStudent :many
+SELECT a.status, COUNT(*) as count
+FROM attendance a
+WHERE a.student_id = $1
+    AND (sqlc.narg('start_date')::date IS NULL OR a.attendance_date >= sqlc.narg('start_date')::date)
+    AND (sqlc.narg('end_date')::date IS NULL OR a.attendance_date <= sqlc.narg('end_date')::date)
+GROUP BY a.status;
+
+-- name: ListRollCall :many
+SELECT s.id as student_id, s.name, s.gender, a.status, a.memo
+FROM students s
+LEFT JOIN attendance a ON a.student_id = s.id AND a.attendance_date = $1
+WHERE s.deleted_at IS NULL AND s.status = 'enrolled'
+ORDER BY s.name ASC, s.id ASC;
@@ -0,0 +1,22 @@
+-- name: CreateAuditLog :exec
+INSERT INTO audit_log (actor_id, action, entity_type, entity_id, before_data, after_data)
+VALUES ($1, $2, $3, $4, $5, $6);
+
+-- name: ListAuditLogs :many
+SELECT a.id, a.actor_id, a.action, a.entity_type, a.entity_id, a.before_data, a.after_data, a.created_at,
+    u.name as actor_name
+FROM audit_log a
+LEFT JOIN users u ON a.actor_id = u.id
+WHERE (sqlc.narg('actor_id')::int IS NULL OR a.actor_id = sqlc.narg('actor_id')::int)
+    AND (sqlc.narg('entity_type')::text IS NULL OR a.entity_type = sqlc.narg('entity_type')::text)
+    AND (sqlc.narg('start_date')::date IS NULL OR a.created_at::date >= sqlc.narg('start_date')::date)
+    AND (sqlc.narg('end_date')::date IS NULL OR a.created_at::date <= sqlc.narg('end_date')::date)
+ORDER BY a.created_at DESC, a.id DESC
+LIMIT sqlc.arg('limit')::int OFFSET sqlc.arg('offset')::int;
+
+-- name: CountAuditLogs :one
+SELECT COUNT(*) FROM audit_log a
+WHERE (sqlc.narg('actor_id')::int IS NULL OR a.actor_id = sqlc.narg('actor_id')::int)
+    AND (sqlc.narg('entity_type')::text IS NULL OR a.entity_type = sqlc.narg('entity_type')::text)
+    AND (sqlc.narg('start_date')::date IS NULL OR a.created_at::date >= sqlc.narg('start_date')::date)
+    AND (sqlc.narg('end_date')::date IS NULL OR a.created_at::date <= sqlc.narg('end_date')::date);
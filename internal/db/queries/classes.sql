@@ -0,0 +1,79 @@
+-- name: ListClasses :many
+SELECT c.*, u.name as instructor_name,
+    (SELECT COUNT(*) FROM class_enrollments ce
+        JOIN students s ON ce.student_id = s.id
+        WHERE ce.class_id = c.id AND s.deleted_at IS NULL)::bigint as student_count
+FROM classes c
+LEFT JOIN users u ON c.instructor_id = u.id
+ORDER BY c.name ASC;
+
+-- name: GetClassByID :one
+SELECT c.*, u.name as instructor_name,
+    (SELECT COUNT(*) FROM class_enrollments ce
+        JOIN students s ON ce.student_id = s.id
+        WHERE ce.class_id = c.id AND s.deleted_at IS NULL)::bigint as student_count
+FROM classes c
+LEFT JOIN users u ON c.instructor_id = u.id
+WHERE c.id = $1;
+
+-- name: CreateClass :one
+INSERT INTO classes (name, instructor_id, capacity, description)
+VALUES ($1, $2, $3, $4)
+RETURNING *;
+
+-- name: UpdateClass :one
+UPDATE classes
+SET name = $2, instructor_id = $3, capacity = $4, description = $5, updated_at = NOW()
+WHERE id = $1
+RETURNING *;
+
+-- name: DeleteClass :exec
+DELETE FROM classes
+WHERE id = $1;
+
+-- name: ListClassSchedules :many
+SELECT * FROM class_schedules
+ORDER BY class_id, weekday, start_time;
+
+-- name: ListClassSchedulesByClass :many
+SELECT * FROM class_schedules
+WHERE class_id = $1
+ORDER BY weekday, start_time;
+
+-- name: CreateClassSchedule :exec
+INSERT INTO class_schedules (class_id, weekday, start_time, end_time)
+VALUES ($1, $2, $3, $4);
+
+-- name: DeleteClassSchedulesByClass :exec
+DELETE FROM class_schedules
+WHERE class_id = $1;
+
+-- name: ListClassStudents :many
+SELECT s.id, s.name, s.gender, s.phone, s.parent_phone, ce.enrolled_at
+FROM class_enrollments ce
+JOIN students s ON ce.student_id = s.id
+WHERE ce.class_id = $1 AND s.deleted_at IS NULL
+ORDER BY s.name ASC;
+
+-- name: ListStudentsNotInClass :many
+SELECT s.id, s.name, s.gender
+FROM students s
+WHERE s.deleted_at IS NULL AND s.status IN ('enrolled', 'on_leave')
+    AND NOT EXISTS (
+    SELECT 1 FROM class_enrollments ce
+    WHERE ce.student_id = s.id AND ce.class_id = $1)
+ORDER BY s.name ASC;
+
+-- name: LockClass :one
+SELECT id FROM classes
+WHERE id = $1
+FOR UPDATE;
+
+-- name: EnrollStudent :exec
+INSERT INTO class_enrollments (class_id, student_id)
+VALUES ($1, $2)
+ON CONFLICT (class_id, student_id) DO NOTHING;
+
+-- name: WithdrawStudent :exec
+DELETE FROM class_enrollments
+WHERE class_id = $1 AND student_id = $2;
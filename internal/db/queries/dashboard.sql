@@ -0,0 +1,42 @@
+-- name: CountActiveStudents :one
+SELECT COUNT(*) FROM students
+WHERE deleted_at IS NULL AND status = 'enrolled';
+
+-- name: CountStudentsCreatedSince :one
+SELECT COUNT(*) FROM students
+WHERE deleted_at IS NULL AND created_at >= sqlc.arg('since')::timestamptz;
+
+-- name: CountStudentsByGender :many
+SELECT gender, COUNT(*) as count
+FROM students
+WHERE deleted_at IS NULL AND status = 'enrolled'
+GROUP BY gender
+ORDER BY gender ASC;
+
+-- name: CountEvaluationsByAuthorSince :many
+SELECT u.id as author_id, u.name as author_name, COUNT(e.id) as count
+FROM users u
+LEFT JOIN evaluations e ON e.author_id = u.id AND e.created_at >= sqlc.arg('since')::timestamptz
+    AND e.deleted_at IS NULL
+    AND EXISTS (SELECT 1 FROM students s WHERE s.id = e.student_id AND s.deleted_at IS NULL)
+GROUP BY u.id, u.name
+ORDER BY count DESC, u.name ASC;
+
+-- name: ListStudentsWithoutRecentEvaluation :many
+SELECT s.id, s.name, s.gender,
+    (SELECT MAX(e.created_at) FROM evaluations e
+        WHERE e.student_id = s.id AND e.deleted_at IS NULL)::timestamptz as last_evaluated_at
+FROM students s
+WHERE s.deleted_at IS NULL AND s.status = 'enrolled'
+    AND NOT EXISTS (
+        SELECT 1 FROM evaluations e
+        WHERE e.student_id = s.id AND e.deleted_at IS NULL AND e.created_at >= sqlc.arg('since')::timestamptz)
+ORDER BY last_evaluated_at ASC NULLS FIRST, s.name ASC
+LIMIT sqlc.arg('limit') OFFSET sqlc.arg('offset');
+
+-- name: CountStudentsWithoutRecentEvaluation :one
+SELECT COUNT(*) FROM students s
+WHERE s.deleted_at IS NULL AND s.status = 'enrolled'
+    AND NOT EXISTS (
+        SELECT 1 FROM evaluations e
+        WHERE e.student_id = s.id AND e.deleted_at IS NULL AND e.created_at >= sqlc.arg('since')::timestamptz);
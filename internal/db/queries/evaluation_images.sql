@@ -0,0 +1,50 @@
+-- name: CreateEvaluationImage :one
+INSERT INTO evaluation_images (
+    evaluation_id, storage_key, thumbnail_key, original_name, content_type, size_bytes, width, height, uploaded_by
+)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+RETURNING *;
+
+-- name: GetEvaluationImageByID :one
+SELECT i.*, e.student_id
+FROM evaluation_images i
+JOIN evaluations e ON i.evaluation_id = e.id
+JOIN students s ON e.student_id = s.id
+WHERE i.id = $1 AND e.deleted_at IS NULL AND s.deleted_at IS NULL;
+
+-- name: ListEvaluationImagesByEvaluation :many
+SELECT * FROM evaluation_images
+WHERE evaluation_id = $1
+ORDER BY id;
+
+-- name: ListEvaluationImagesByEvaluations :many
+SELECT * FROM evaluation_images
+WHERE evaluation_id = ANY(sqlc.arg('evaluation_ids')::int[])
+ORDER BY evaluation_id, id;
+
+-- name: CountEvaluationImagesByEvaluation :one
+SELECT COUNT(*) FROM evaluation_images
+WHERE evaluation_id = $1;
+
+-- name: ListEvaluationImagesByStudent :many
+SELECT i.*, e.created_at as evaluation_created_at
+FROM evaluation_images i
+JOIN evaluations e ON i.evaluation_id = e.id
+WHERE e.student_id = $1 AND e.deleted_at IS NULL
+ORDER BY e.created_at DESC, i.id
+LIMIT $2 OFFSET $3;
+
+-- name: CountEvaluationImagesByStudent :one
+SELECT COUNT(*)
+FROM evaluation_images i
+JOIN evaluations e ON i.evaluation_id = e.id
+WHERE e.student_id = $1 AND e.deleted_at IS NULL;
+
+-- name: ListEvaluationImageKeysByStudent :many
+SELECT i.storage_key, i.thumbnail_key
+FROM evaluation_images i
+JOIN evaluations e ON i.evaluation_id = e.id
+WHERE e.student_id = $1;
+
+-- name: DeleteEvaluationImage :exec
+DELETE FROM evaluation_images WHERE id = $1;
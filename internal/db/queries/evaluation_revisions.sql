@@ -0,0 +1,13 @@
+-- name: CreateEvaluationRevision :exec
+INSERT INTO evaluation_revisions (evaluation_id, content, edited_by)
+VALUES ($1, $2, $3);
+
+-- name: ListEvaluationRevisions :many
+SELECT r.*, u.name as editor_name
+FROM evaluation_revisions r
+LEFT JOIN users u ON r.edited_by = u.id
+WHERE r.evaluation_id = $1
+ORDER BY r.created_at DESC, r.id DESC;
+
+-- name: GetEvaluationRevisionByID :one
+SELECT * FROM evaluation_revisions WHERE id = $1;
@@ -0,0 +1,21 @@
+-- name: ListEvaluationTemplatesForUser :many
+SELECT * FROM evaluation_templates
+WHERE owner_id IS NULL OR owner_id = $1
+ORDER BY owner_id IS NOT NULL, title, id;
+
+-- name: GetEvaluationTemplateByID :one
+SELECT * FROM evaluation_templates WHERE id = $1;
+
+-- name: CreateEvaluationTemplate :one
+INSERT INTO evaluation_templates (owner_id, title, content)
+VALUES ($1, $2, $3)
+RETURNING *;
+
+-- name: UpdateEvaluationTemplate :one
+UPDATE evaluation_templates
+SET owner_id = $2, title = $3, content = $4, updated_at = NOW()
+WHERE id = $1
+RETURNING *;
+
+-- name: DeleteEvaluationTemplate :exec
+DELETE FROM evaluation_templates WHERE id = $1;
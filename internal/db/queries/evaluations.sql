@@ -8,22 +8,35 @@ SELECT e.*, u.name as author_name, s.name as student_name
 FROM evaluations e
 JOIN users u ON e.author_id = u.id
 JOIN students s ON e.student_id = s.id
-WHERE e.id = $1;
+WHERE e.id = $1 AND e.deleted_at IS NULL AND s.deleted_at IS NULL;
 
 -- name: ListEvaluationsByStudent :many
 SELECT e.*, u.name as author_name
 FROM evaluations e
 JOIN users u ON e.author_id = u.id
 WHERE e.student_id = $1
+    AND e.deleted_at IS NULL
     AND (sqlc.narg('search')::text IS NULL OR e.content ILIKE '%' || sqlc.narg('search')::text || '%')
     AND (sqlc.narg('start_date')::date IS NULL OR e.created_at::date >= sqlc.narg('start_date')::date)
     AND (sqlc.narg('end_date')::date IS NULL OR e.created_at::date <= sqlc.narg('end_date')::date)
 ORDER BY e.created_at DESC
 LIMIT $2 OFFSET $3;
 
+-- name: ExportEvaluationsByStudent :many
+SELECT e.*, u.name as author_name
+FROM evaluations e
+JOIN users u ON e.author_id = u.id
+WHERE e.student_id = $1
+    AND e.deleted_at IS NULL
+    AND (sqlc.narg('search')::text IS NULL OR e.content ILIKE '%' || sqlc.narg('search')::text || '%')
+    AND (sqlc.narg('start_date')::date IS NULL OR e.created_at::date >= sqlc.narg('start_date')::date)
+    AND (sqlc.narg('end_date')::date IS NULL OR e.created_at::date <= sqlc.narg('end_date')::date)
+ORDER BY e.created_at DESC;
+
 -- name: CountEvaluationsByStudent :one
 SELECT COUNT(*) FROM evaluations e
 WHERE e.student_id = $1
+    AND e.deleted_at IS NULL
     AND (sqlc.narg('search')::text IS NULL OR e.content ILIKE '%' || sqlc.narg('search')::text || '%')
     AND (sqlc.narg('start_date')::date IS NULL OR e.created_at::date >= sqlc.narg('start_date')::date)
     AND (sqlc.narg('end_date')::date IS NULL OR e.created_at::date <= sqlc.narg('end_date')::date);
@@ -31,8 +44,30 @@ WHERE e.student_id = $1
 -- name: UpdateEvaluation :one
 UPDATE evaluations
 SET content = $2, updated_at = NOW()
-WHERE id = $1
+WHERE id = $1 AND deleted_at IS NULL
 RETURNING *;
 
 -- name: DeleteEvaluation :exec
-DELETE FROM evaluations WHERE id = $1;
+UPDATE evaluations SET deleted_at = NOW() WHERE id = $1 AND deleted_at IS NULL;
+
+-- name: ListDeletedEvaluations :many
+SELECT e.id, e.student_id, e.content, e.created_at, e.deleted_at,
+    s.name as student_name, s.deleted_at as student_deleted_at, u.name as author_name
+FROM evaluations e
+JOIN users u ON e.author_id = u.id
+JOIN students s ON e.student_id = s.id
+WHERE e.deleted_at IS NOT NULL
+ORDER BY e.deleted_at DESC;
+
+-- name: GetDeletedEvaluationByID :one
+SELECT * FROM evaluations
+WHERE id = $1 AND deleted_at IS NOT NULL;
+
+-- name: RestoreEvaluation :one
+UPDATE evaluations
+SET deleted_at = NULL
+WHERE id = $1 AND deleted_at IS NOT NULL
+RETURNING *;
+
+-- name: PurgeEvaluation :exec
+DELETE FROM evaluations WHERE id = $1 AND deleted_at IS NOT NULL;
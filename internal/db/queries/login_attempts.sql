@@ -0,0 +1,27 @@
+-- name: CreateLoginAttempt :exec
+INSERT INTO login_attempts (username, user_id, ip_address, user_agent, success, reason)
+VALUES ($1, $2, $3, $4, $5, $6);
+
+-- name: CountRecentFailedLoginsByIP :one
+SELECT COUNT(*) FROM login_attempts
+WHERE ip_address = $1 AND NOT success AND created_at >= sqlc.arg('since')::timestamptz;
+
+-- name: ListLoginAttempts :many
+SELECT * FROM login_attempts
+WHERE (sqlc.narg('username')::text IS NULL OR username = sqlc.narg('username')::text)
+    AND (sqlc.narg('ip_address')::text IS NULL OR ip_address = sqlc.narg('ip_address')::text)
+    AND (sqlc.narg('success')::bool IS NULL OR success = sqlc.narg('success')::bool)
+ORDER BY created_at DESC, id DESC
+LIMIT sqlc.arg('limit')::int OFFSET sqlc.arg('offset')::int;
+
+-- name: CountLoginAttempts :one
+SELECT COUNT(*) FROM login_attempts
+WHERE (sqlc.narg('username')::text IS NULL OR username = sqlc.narg('username')::text)
+    AND (sqlc.narg('ip_address')::text IS NULL OR ip_address = sqlc.narg('ip_address')::text)
+    AND (sqlc.narg('success')::bool IS NULL OR success = sqlc.narg('success')::bool);
+
+-- name: ListRecentLoginAttemptsByUser :many
+SELECT * FROM login_attempts
+WHERE user_id = $1
+ORDER BY created_at DESC, id DESC
+LIMIT sqlc.arg('limit')::int;
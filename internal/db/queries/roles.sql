@@ -0,0 +1,63 @@
+-- name: ListPermissions :many
+SELECT * FROM permissions
+ORDER BY sort_order, key;
+
+-- name: ListRoles :many
+SELECT r.id, r.name, r.display_name, r.description, r.is_system, r.created_at, r.updated_at, r.max_sessions, r.require_two_factor,
+       (SELECT COUNT(*) FROM users u WHERE u.role = r.name) AS user_count
+FROM roles r
+ORDER BY r.is_system DESC, r.id;
+
+-- name: GetRoleByID :one
+SELECT * FROM roles WHERE id = $1;
+
+-- name: GetRoleByName :one
+SELECT * FROM roles WHERE name = $1;
+
+-- name: CreateRole :one
+INSERT INTO roles (name, display_name, description, max_sessions, require_two_factor)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING *;
+
+-- name: UpdateRole :one
+UPDATE roles
+SET display_name = $2, description = $3, max_sessions = $4, require_two_factor = $5, updated_at = NOW()
+WHERE id = $1
+RETURNING *;
+
+-- name: DeleteRole :exec
+DELETE FROM roles WHERE id = $1 AND NOT is_system;
+
+-- name: CountUsersByRole :one
+SELECT COUNT(*) FROM users WHERE role = $1;
+
+-- name: ListRolePermissions :many
+SELECT rp.permission
+FROM role_permissions rp
+JOIN roles r ON r.id = rp.role_id
+WHERE r.name = $1
+ORDER BY rp.permission;
+
+-- name: ListAllRolePermissions :many
+SELECT r.name AS role, rp.permission
+FROM role_permissions rp
+JOIN roles r ON r.id = rp.role_id
+ORDER BY r.name, rp.permission;
+
+-- name: AddRolePermission :exec
+INSERT INTO role_permissions (role_id, permission)
+VALUES ($1, $2)
+ON CONFLICT DO NOTHING;
+
+-- name: DeleteRolePermissions :exec
+DELETE FROM role_permissions WHERE role_id = $1;
+
+-- name: GetUserAuthorization :one
+SELECT u.role, r.display_name AS role_name, r.require_two_factor,
+       (u.totp_enabled_at IS NOT NULL)::boolean AS two_factor_enabled, u.must_change_password,
+       COALESCE(array_agg(rp.permission ORDER BY rp.permission) FILTER (WHERE rp.permission IS NOT NULL), '{}')::text[] AS permissions
+FROM users u
+JOIN roles r ON r.name = u.role
+LEFT JOIN role_permissions rp ON rp.role_id = r.id
+WHERE u.id = $1
+GROUP BY u.role, r.display_name, r.require_two_factor, u.totp_enabled_at, u.must_change_password;
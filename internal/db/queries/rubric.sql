@@ -0,0 +1,55 @@
+-- name: ListRubricCriteria :many
+SELECT * FROM rubric_criteria
+ORDER BY sort_order, id;
+
+-- name: ListActiveRubricCriteria :many
+SELECT * FROM rubric_criteria
+WHERE active = TRUE
+ORDER BY sort_order, id;
+
+-- name: GetRubricCriterionByID :one
+SELECT * FROM rubric_criteria WHERE id = $1;
+
+-- name: CreateRubricCriterion :one
+INSERT INTO rubric_criteria (name, description, max_score, sort_order)
+VALUES ($1, $2, $3, $4)
+RETURNING *;
+
+-- name: UpdateRubricCriterion :one
+UPDATE rubric_criteria
+SET name = $2, description = $3, max_score = $4, sort_order = $5, active = $6, updated_at = NOW()
+WHERE id = $1
+RETURNING *;
+
+-- name: DeleteRubricCriterion :execrows
+DELETE FROM rubric_criteria
+WHERE id = $1 AND NOT EXISTS (SELECT 1 FROM evaluation_scores WHERE criterion_id = $1);
+
+-- name: GetHighestScoreForCriterion :one
+SELECT COALESCE(MAX(score), 0)::int AS highest_score FROM evaluation_scores WHERE criterion_id = $1;
+
+-- name: UpsertEvaluationScore :exec
+INSERT INTO evaluation_scores (evaluation_id, criterion_id, score)
+VALUES ($1, $2, $3)
+ON CONFLICT (evaluation_id, criterion_id) DO UPDATE SET score = EXCLUDED.score;
+
+-- name: DeleteEvaluationScore :exec
+DELETE FROM evaluation_scores WHERE evaluation_id = $1 AND criterion_id = $2;
+
+-- name: ListEvaluationScores :many
+SELECT criterion_id, score FROM evaluation_scores
+WHERE evaluation_id = $1;
+
+-- name: ListEvaluationScoresByEvaluations :many
+SELECT es.evaluation_id, es.criterion_id, es.score
+FROM evaluation_scores es
+JOIN rubric_criteria rc ON es.criterion_id = rc.id
+WHERE es.evaluation_id = ANY(sqlc.arg('evaluation_ids')::int[])
+ORDER BY es.evaluation_id, rc.sort_order, rc.id;
+
+-- name: ListScoreProgressByStudent :many
+SELECT e.id as evaluation_id, e.created_at, es.criterion_id, es.score
+FROM evaluation_scores es
+JOIN evaluations e ON es.evaluation_id = e.id
+WHERE e.student_id = $1 AND e.deleted_at IS NULL
+ORDER BY e.created_at, e.id;
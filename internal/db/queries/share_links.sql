@@ -0,0 +1,10 @@
+-- name: GetStudentShareLink :one
+SELECT student_id, version, revoked_at FROM student_share_links
+WHERE student_id = $1;
+
+-- name: RevokeStudentShareLinks :one
+INSERT INTO student_share_links (student_id, version, revoked_at)
+VALUES ($1, 1, NOW())
+ON CONFLICT (student_id) DO UPDATE
+SET version = student_share_links.version + 1, revoked_at = NOW()
+RETURNING student_id, version, revoked_at;
@@ -1,42 +1,122 @@
 -- name: CountStudents :one
 SELECT COUNT(*) FROM students
-WHERE
-    (sqlc.narg('search')::text IS NULL OR
+WHERE deleted_at IS NULL
+    AND (sqlc.narg('search')::text IS NULL OR
         name ILIKE '%' || sqlc.narg('search')::text || '%' OR
         phone ILIKE '%' || sqlc.narg('search')::text || '%' OR
         parent_phone ILIKE '%' || sqlc.narg('search')::text || '%' OR
         remarks ILIKE '%' || sqlc.narg('search')::text || '%')
-    AND (sqlc.narg('gender')::text IS NULL OR gender = sqlc.narg('gender')::text);
+    AND (sqlc.narg('gender')::text IS NULL OR gender = sqlc.narg('gender')::text)
+    AND (sqlc.narg('class_id')::int IS NULL OR EXISTS (
+        SELECT 1 FROM class_enrollments ce
+        WHERE ce.student_id = students.id AND ce.class_id = sqlc.narg('class_id')::int))
+    AND (sqlc.narg('statuses')::text[] IS NULL OR status = ANY(sqlc.narg('statuses')::text[]));
 
 -- name: ListStudents :many
-SELECT id, name, gender, phone, parent_phone, remarks, created_at, updated_at
+SELECT id, name, gender, phone, parent_phone, remarks, created_at, updated_at, deleted_at,
+    status, enrolled_on, left_on, status_reason
 FROM students
-WHERE
-    (sqlc.narg('search')::text IS NULL OR
+WHERE deleted_at IS NULL
+    AND (sqlc.narg('search')::text IS NULL OR
         name ILIKE '%' || sqlc.narg('search')::text || '%' OR
         phone ILIKE '%' || sqlc.narg('search')::text || '%' OR
         parent_phone ILIKE '%' || sqlc.narg('search')::text || '%' OR
         remarks ILIKE '%' || sqlc.narg('search')::text || '%')
     AND (sqlc.narg('gender')::text IS NULL OR gender = sqlc.narg('gender')::text)
+    AND (sqlc.narg('class_id')::int IS NULL OR EXISTS (
+        SELECT 1 FROM class_enrollments ce
+        WHERE ce.student_id = students.id AND ce.class_id = sqlc.narg('class_id')::int))
+    AND (sqlc.narg('statuses')::text[] IS NULL OR status = ANY(sqlc.narg('statuses')::text[]))
 ORDER BY created_at DESC
 LIMIT $1 OFFSET $2;
 
+-- name: ExportStudents :many
+SELECT id, name, gender, phone, parent_phone, remarks, created_at, updated_at, deleted_at,
+    status, enrolled_on, left_on, status_reason
+FROM students
+WHERE deleted_at IS NULL
+    AND (sqlc.narg('search')::text IS NULL OR
+        name ILIKE '%' || sqlc.narg('search')::text || '%' OR
+        phone ILIKE '%' || sqlc.narg('search')::text || '%' OR
+        parent_phone ILIKE '%' || sqlc.narg('search')::text || '%' OR
+        remarks ILIKE '%' || sqlc.narg('search')::text || '%')
+    AND (sqlc.narg('gender')::text IS NULL OR gender = sqlc.narg('gender')::text)
+    AND (sqlc.narg('class_id')::int IS NULL OR EXISTS (
+        SELECT 1 FROM class_enrollments ce
+        WHERE ce.student_id = students.id AND ce.class_id = sqlc.narg('class_id')::int))
+    AND (sqlc.narg('statuses')::text[] IS NULL OR status = ANY(sqlc.narg('statuses')::text[]))
+ORDER BY created_at DESC;
+
 -- name: GetStudentByID :one
-SELECT id, name, gender, phone, parent_phone, remarks, created_at, updated_at
+SELECT id, name, gender, phone, parent_phone, remarks, created_at, updated_at, deleted_at,
+    status, enrolled_on, left_on, status_reason
 FROM students
-WHERE id = $1;
+WHERE id = $1 AND deleted_at IS NULL;
+
+-- name: ListStudentsByNames :many
+SELECT id, name, parent_phone
+FROM students
+WHERE deleted_at IS NULL AND name = ANY(sqlc.arg('names')::text[])
+ORDER BY id;
 
 -- name: CreateStudent :one
-INSERT INTO students (name, gender, phone, parent_phone, remarks)
-VALUES ($1, $2, $3, $4, $5)
-RETURNING id, name, gender, phone, parent_phone, remarks, created_at, updated_at;
+INSERT INTO students (name, gender, phone, parent_phone, remarks, enrolled_on)
+VALUES ($1, $2, $3, $4, $5, COALESCE(sqlc.narg('enrolled_on')::date, CURRENT_DATE))
+RETURNING id, name, gender, phone, parent_phone, remarks, created_at, updated_at, deleted_at,
+    status, enrolled_on, left_on, status_reason;
 
 -- name: UpdateStudent :one
 UPDATE students
-SET name = $2, gender = $3, phone = $4, parent_phone = $5, remarks = $6, updated_at = NOW()
-WHERE id = $1
-RETURNING id, name, gender, phone, parent_phone, remarks, created_at, updated_at;
+SET name = $2, gender = $3, phone = $4, parent_phone = $5, remarks = $6,
+    enrolled_on = COALESCE(sqlc.narg('enrolled_on')::date, enrolled_on), updated_at = NOW()
+WHERE id = $1 AND deleted_at IS NULL
+RETURNING id, name, gender, phone, parent_phone, remarks, created_at, updated_at, deleted_at,
+    status, enrolled_on, left_on, status_reason;
+
+-- name: UpdateStudentStatus :one
+UPDATE students
+SET status = $2, left_on = $3, status_reason = $4, updated_at = NOW()
+WHERE id = $1 AND deleted_at IS NULL
+RETURNING id, name, gender, phone, parent_phone, remarks, created_at, updated_at, deleted_at,
+    status, enrolled_on, left_on, status_reason;
+
+-- name: CreateStudentStatusHistory :exec
+INSERT INTO student_status_history (student_id, from_status, to_status, changed_on, reason, changed_by)
+VALUES ($1, $2, $3, $4, $5, $6);
+
+-- name: ListStudentStatusHistory :many
+SELECT h.id, h.from_status, h.to_status, h.changed_on, h.reason, h.created_at,
+    u.name as changed_by_name
+FROM student_status_history h
+LEFT JOIN users u ON h.changed_by = u.id
+WHERE h.student_id = $1
+ORDER BY h.changed_on DESC, h.id DESC;
 
 -- name: DeleteStudent :exec
+UPDATE students
+SET deleted_at = NOW()
+WHERE id = $1 AND deleted_at IS NULL;
+
+-- name: ListDeletedStudents :many
+SELECT s.id, s.name, s.gender, s.parent_phone, s.deleted_at,
+    (SELECT COUNT(*) FROM evaluations e WHERE e.student_id = s.id)::bigint as evaluation_count
+FROM students s
+WHERE s.deleted_at IS NOT NULL
+ORDER BY s.deleted_at DESC;
+
+-- name: GetDeletedStudentByID :one
+SELECT id, name, gender, phone, parent_phone, remarks, created_at, updated_at, deleted_at,
+    status, enrolled_on, left_on, status_reason
+FROM students
+WHERE id = $1 AND deleted_at IS NOT NULL;
+
+-- name: RestoreStudent :one
+UPDATE students
+SET deleted_at = NULL
+WHERE id = $1 AND deleted_at IS NOT NULL
+RETURNING id, name, gender, phone, parent_phone, remarks, created_at, updated_at, deleted_at,
+    status, enrolled_on, left_on, status_reason;
+
+-- name: PurgeStudent :exec
 DELETE FROM students
-WHERE id = $1;
+WHERE id = $1 AND deleted_at IS NOT NULL;
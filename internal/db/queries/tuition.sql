@@ -0,0 +1,81 @@
+-- name: ListTuitionPlans :many
+SELECT * FROM tuition_plans
+ORDER BY name ASC, id ASC;
+
+-- name: GetTuitionPlanByID :one
+SELECT * FROM tuition_plans
+WHERE id = $1;
+
+-- name: CreateTuitionPlan :one
+INSERT INTO tuition_plans (name, monthly_fee, description)
+VALUES ($1, $2, $3)
+RETURNING *;
+
+-- name: UpdateTuitionPlan :one
+UPDATE tuition_plans
+SET name = $2, monthly_fee = $3, description = $4, updated_at = NOW()
+WHERE id = $1
+RETURNING *;
+
+-- name: DeleteTuitionPlan :exec
+DELETE FROM tuition_plans
+WHERE id = $1;
+
+-- name: CreateTuitionInvoice :one
+INSERT INTO tuition_invoices (student_id, plan_id, billing_month, amount, memo)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING *;
+
+-- name: GetTuitionInvoice :one
+SELECT * FROM tuition_invoices
+WHERE id = $1 AND student_id = $2;
+
+-- name: DeleteTuitionInvoice :exec
+DELETE FROM tuition_invoices
+WHERE id = $1 AND student_id = $2;
+
+-- name: ListTuitionInvoicesByStudent :many
+SELECT i.*, p.name as plan_name,
+    (SELECT COALESCE(SUM(tp.amount), 0) FROM tuition_payments tp WHERE tp.invoice_id = i.id)::bigint as paid_amount
+FROM tuition_invoices i
+LEFT JOIN tuition_plans p ON i.plan_id = p.id
+WHERE i.student_id = $1
+ORDER BY i.billing_month DESC, i.id DESC;
+
+-- name: CreateTuitionPayment :one
+INSERT INTO tuition_payments (invoice_id, amount, method, paid_on, memo, recorded_by)
+VALUES ($1, $2, $3, $4, $5, $6)
+RETURNING *;
+
+-- name: DeleteTuitionPayment :exec
+DELETE FROM tuition_payments tp
+USING tuition_invoices i
+WHERE tp.id = $1 AND tp.invoice_id = i.id AND i.student_id = $2;
+
+-- name: ListTuitionPaymentsByStudent :many
+SELECT tp.*, i.billing_month, u.name as recorded_by_name
+FROM tuition_payments tp
+JOIN tuition_invoices i ON tp.invoice_id = i.id
+LEFT JOIN users u ON tp.recorded_by = u.id
+WHERE i.student_id = $1
+ORDER BY tp.paid_on DESC, tp.id DESC;
+
+-- name: GetStudentTuitionBalance :one
+SELECT
+    (SELECT COALESCE(SUM(i.amount), 0) FROM tuition_invoices i WHERE i.student_id = $1)::bigint as total_billed,
+    (SELECT COALESCE(SUM(tp.amount), 0) FROM tuition_payments tp
+        JOIN tuition_invoices i ON tp.invoice_id = i.id
+        WHERE i.student_id = $1)::bigint as total_paid;
+
+-- name: ListUnpaidInvoices :many
+SELECT i.id as invoice_id, i.student_id, s.name as student_name, s.parent_phone,
+    p.name as plan_name, i.amount,
+    (SELECT COALESCE(SUM(tp.amount), 0) FROM tuition_payments tp WHERE tp.invoice_id = i.id)::bigint as paid_amount,
+    (i.amount - (SELECT COALESCE(SUM(tp.amount), 0) FROM tuition_payments tp WHERE tp.invoice_id = i.id))::bigint as outstanding_amount
+FROM tuition_invoices i
+JOIN students s ON i.student_id = s.id
+LEFT JOIN tuition_plans p ON i.plan_id = p.id
+WHERE i.billing_month = $1
+    AND s.deleted_at IS NULL
+    AND i.amount > (SELECT COALESCE(SUM(tp.amount), 0) FROM tuition_payments tp WHERE tp.invoice_id = i.id)
+ORDER BY s.name ASC, i.id ASC;
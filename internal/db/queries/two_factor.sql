@@ -0,0 +1,43 @@
+-- name: GetUserTwoFactor :one
+SELECT totp_secret, totp_enabled_at, totp_last_step
+FROM users
+WHERE id = $1;
+
+-- name: SetUserTOTPSecret :exec
+-- 등록 진행 중인 비밀키 저장. 이미 활성화된 경우에는 바꾸지 않음
+UPDATE users
+SET totp_secret = $2, totp_last_step = 0
+WHERE id = $1 AND totp_enabled_at IS NULL;
+
+-- name: EnableUserTOTP :execrows
+UPDATE users
+SET totp_enabled_at = NOW(), totp_last_step = $2
+WHERE id = $1 AND totp_secret IS NOT NULL AND totp_enabled_at IS NULL;
+
+-- name: UpdateUserTOTPStep :execrows
+-- 이미 사용된 시간 단계 이하이면 0행을 반환하므로 동시에 들어온 같은 코드도 한 번만 통과
+UPDATE users
+SET totp_last_step = $2
+WHERE id = $1 AND totp_last_step < $2;
+
+-- name: DisableUserTOTP :exec
+UPDATE users
+SET totp_secret = NULL, totp_enabled_at = NULL, totp_last_step = 0
+WHERE id = $1;
+
+-- name: CreateRecoveryCode :exec
+INSERT INTO user_recovery_codes (user_id, code_hash)
+VALUES ($1, $2);
+
+-- name: DeleteRecoveryCodes :exec
+DELETE FROM user_recovery_codes
+WHERE user_id = $1;
+
+-- name: UseRecoveryCode :execrows
+UPDATE user_recovery_codes
+SET used_at = NOW()
+WHERE user_id = $1 AND code_hash = $2 AND used_at IS NULL;
+
+-- name: CountUnusedRecoveryCodes :one
+SELECT COUNT(*) FROM user_recovery_codes
+WHERE user_id = $1 AND used_at IS NULL;
@@ -0,0 +1,54 @@
+-- name: CreateUserSession :one
+INSERT INTO user_sessions (user_id, token_hash, user_agent, ip_address, expires_at)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING *;
+
+-- name: GetActiveUserSession :one
+SELECT * FROM user_sessions
+WHERE user_id = $1 AND token_hash = $2 AND expires_at > NOW();
+
+-- name: TouchUserSession :exec
+UPDATE user_sessions
+SET last_seen_at = NOW()
+WHERE id = $1 AND last_seen_at < NOW() - INTERVAL '1 minute';
+
+-- name: ListActiveUserSessions :many
+SELECT * FROM user_sessions
+WHERE user_id = $1 AND expires_at > NOW()
+ORDER BY last_seen_at DESC;
+
+-- name: DeleteUserSession :execrows
+DELETE FROM user_sessions
+WHERE id = $1 AND user_id = $2;
+
+-- name: DeleteUserSessionByHash :exec
+DELETE FROM user_sessions
+WHERE token_hash = $1;
+
+-- name: DeleteOtherUserSessions :exec
+DELETE FROM user_sessions
+WHERE user_id = $1 AND id <> $2;
+
+-- name: DeleteAllUserSessions :exec
+DELETE FROM user_sessions
+WHERE user_id = $1;
+
+-- name: DeleteExpiredUserSessions :exec
+DELETE FROM user_sessions
+WHERE user_id = $1 AND expires_at <= NOW();
+
+-- name: TrimUserSessions :exec
+-- 최근에 사용한 세션 limit개만 남기고 종료
+DELETE FROM user_sessions
+WHERE id IN (
+    SELECT s.id FROM user_sessions s
+    WHERE s.user_id = $1
+    ORDER BY s.last_seen_at DESC, s.id DESC
+    OFFSET sqlc.arg('limit')::int
+);
+
+-- name: GetUserMaxSessions :one
+SELECT r.max_sessions
+FROM users u
+JOIN roles r ON r.name = u.role
+WHERE u.id = $1;
@@ -1,16 +1,16 @@
 -- name: GetUserByUsername :one
-SELECT id, username, name, password_hash, role, created_at
+SELECT id, username, name, password_hash, role, created_at, failed_login_count, locked_until, must_change_password
 FROM users
 WHERE username = $1;
 
 -- name: GetUserByID :one
-SELECT id, username, name, password_hash, role, created_at
+SELECT id, username, name, password_hash, role, created_at, failed_login_count, locked_until, must_change_password
 FROM users
 WHERE id = $1;
 
 -- name: CreateUser :one
-INSERT INTO users (username, name, password_hash, role)
-VALUES ($1, $2, $3, $4)
+INSERT INTO users (username, name, password_hash, role, must_change_password)
+VALUES ($1, $2, $3, $4, TRUE)
 RETURNING id, username, name, password_hash, role, created_at;
 
 -- name: UpdateUser :one
@@ -21,7 +21,7 @@ RETURNING id, username, name, password_hash, role, created_at;
 
 -- name: UpdateUserPassword :exec
 UPDATE users
-SET password_hash = $2
+SET password_hash = $2, must_change_password = $3, password_changed_at = NOW()
 WHERE id = $1;
 
 -- name: DeleteUser :exec
@@ -36,17 +36,27 @@ ORDER BY created_at DESC;
 -- name: CountUsers :one
 SELECT COUNT(*) FROM users;
 
--- name: UpdateSessionToken :exec
+-- name: ReserveLoginAttempt :one
+-- Counts a login attempt as failed before its password or code is checked, and locks the
+-- account in the same statement once it reaches the limit. Returns no row when it is locked.
 UPDATE users
-SET session_token = $2
-WHERE id = $1;
-
--- name: GetSessionToken :one
-SELECT session_token
-FROM users
-WHERE id = $1;
+SET failed_login_count = failed_login_count + 1,
+    locked_until = CASE WHEN failed_login_count + 1 >= sqlc.arg(max_failures)::int
+        THEN sqlc.arg(lock_until)::timestamptz ELSE locked_until END
+WHERE id = sqlc.arg(id) AND (locked_until IS NULL OR locked_until <= NOW())
+RETURNING failed_login_count, locked_until;
+
+-- name: ReleaseLoginAttempt :exec
+-- Undoes ReserveLoginAttempt for a correct password, unless another attempt came in between
+UPDATE users
+SET failed_login_count = failed_login_count - 1, locked_until = $3
+WHERE id = $1 AND failed_login_count = $2;
 
--- name: ClearSessionToken :exec
+-- name: ResetLoginFailures :exec
 UPDATE users
-SET session_token = NULL
+SET failed_login_count = 0, locked_until = NULL
 WHERE id = $1;
+
+-- name: ListLockedUserIDs :many
+SELECT id FROM users
+WHERE locked_until > NOW();
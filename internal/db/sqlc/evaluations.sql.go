@@ -14,6 +14,7 @@ import (
 const countEvaluationsByStudent = `-- name: CountEvaluationsByStudent :one
 SELECT COUNT(*) FROM evaluations e
 WHERE e.student_id = $1
+    AND e.deleted_at IS NULL
     AND ($2::text IS NULL OR e.content ILIKE '%' || $2::text || '%')
     AND ($3::date IS NULL OR e.created_at::date >= $3::date)
     AND ($4::date IS NULL OR e.created_at::date <= $4::date)
@@ -41,7 +42,7 @@ func (q *Queries) CountEvaluationsByStudent(ctx context.Context, arg CountEvalua
 const createEvaluation = `-- name: CreateEvaluation :one
 INSERT INTO evaluations (student_id, author_id, content)
 VALUES ($1, $2, $3)
-RETURNING id, student_id, author_id, content, created_at, updated_at
+RETURNING id, student_id, author_id, content, created_at, updated_at, deleted_at
 `
 
 type CreateEvaluationParams struct {
@@ -60,12 +61,13 @@ func (q *Queries) CreateEvaluation(ctx context.Context, arg CreateEvaluationPara
 		&i.Content,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.DeletedAt,
 	)
 	return i, err
 }
 
 const deleteEvaluation = `-- name: DeleteEvaluation :exec
-DELETE FROM evaluations WHERE id = $1
+UPDATE evaluations SET deleted_at = NOW() WHERE id = $1 AND deleted_at IS NULL
 `
 
 func (q *Queries) DeleteEvaluation(ctx context.Context, id int32) error {
@@ -73,12 +75,96 @@ func (q *Queries) DeleteEvaluation(ctx context.Context, id int32) error {
 	return err
 }
 
+const exportEvaluationsByStudent = `-- name: ExportEvaluationsByStudent :many
+SELECT e.id, e.student_id, e.author_id, e.content, e.created_at, e.updated_at, e.deleted_at, u.name as author_name
+FROM evaluations e
+JOIN users u ON e.author_id = u.id
+WHERE e.student_id = $1
+    AND e.deleted_at IS NULL
+    AND ($2::text IS NULL OR e.content ILIKE '%' || $2::text || '%')
+    AND ($3::date IS NULL OR e.created_at::date >= $3::date)
+    AND ($4::date IS NULL OR e.created_at::date <= $4::date)
+ORDER BY e.created_at DESC
+`
+
+type ExportEvaluationsByStudentParams struct {
+	StudentID int32       `json:"student_id"`
+	Search    pgtype.Text `json:"search"`
+	StartDate pgtype.Date `json:"start_date"`
+	EndDate   pgtype.Date `json:"end_date"`
+}
+
+type ExportEvaluationsByStudentRow struct {
+	ID         int32              `json:"id"`
+	StudentID  int32              `json:"student_id"`
+	AuthorID   int32              `json:"author_id"`
+	Content    string             `json:"content"`
+	CreatedAt  pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt  pgtype.Timestamptz `json:"updated_at"`
+	DeletedAt  pgtype.Timestamptz `json:"deleted_at"`
+	AuthorName string             `json:"author_name"`
+}
+
+func (q *Queries) ExportEvaluationsByStudent(ctx context.Context, arg ExportEvaluationsByStudentParams) ([]ExportEvaluationsByStudentRow, error) {
+	rows, err := q.db.Query(ctx, exportEvaluationsByStudent,
+		arg.StudentID,
+		arg.Search,
+		arg.StartDate,
+		arg.EndDate,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ExportEvaluationsByStudentRow
+	for rows.Next() {
+		var i ExportEvaluationsByStudentRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.StudentID,
+			&i.AuthorID,
+			&i.Content,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.DeletedAt,
+			&i.AuthorName,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getDeletedEvaluationByID = `-- name: GetDeletedEvaluationByID :one
+SELECT id, student_id, author_id, content, created_at, updated_at, deleted_at FROM evaluations
+WHERE id = $1 AND deleted_at IS NOT NULL
+`
+
+func (q *Queries) GetDeletedEvaluationByID(ctx context.Context, id int32) (Evaluation, error) {
+	row := q.db.QueryRow(ctx, getDeletedEvaluationByID, id)
+	var i Evaluation
+	err := row.Scan(
+		&i.ID,
+		&i.StudentID,
+		&i.AuthorID,
+		&i.Content,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+	)
+	return i, err
+}
+
 const getEvaluationByID = `-- name: GetEvaluationByID :one
-SELECT e.id, e.student_id, e.author_id, e.content, e.created_at, e.updated_at, u.name as author_name, s.name as student_name
+SELECT e.id, e.student_id, e.author_id, e.content, e.created_at, e.updated_at, e.deleted_at, u.name as author_name, s.name as student_name
 FROM evaluations e
 JOIN users u ON e.author_id = u.id
 JOIN students s ON e.student_id = s.id
-WHERE e.id = $1
+WHERE e.id = $1 AND e.deleted_at IS NULL AND s.deleted_at IS NULL
 `
 
 type GetEvaluationByIDRow struct {
@@ -88,6 +174,7 @@ type GetEvaluationByIDRow struct {
 	Content     string             `json:"content"`
 	CreatedAt   pgtype.Timestamptz `json:"created_at"`
 	UpdatedAt   pgtype.Timestamptz `json:"updated_at"`
+	DeletedAt   pgtype.Timestamptz `json:"deleted_at"`
 	AuthorName  string             `json:"author_name"`
 	StudentName string             `json:"student_name"`
 }
@@ -102,17 +189,69 @@ func (q *Queries) GetEvaluationByID(ctx context.Context, id int32) (GetEvaluatio
 		&i.Content,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.DeletedAt,
 		&i.AuthorName,
 		&i.StudentName,
 	)
 	return i, err
 }
 
+const listDeletedEvaluations = `-- name: ListDeletedEvaluations :many
+SELECT e.id, e.student_id, e.content, e.created_at, e.deleted_at,
+    s.name as student_name, s.deleted_at as student_deleted_at, u.name as author_name
+FROM evaluations e
+JOIN users u ON e.author_id = u.id
+JOIN students s ON e.student_id = s.id
+WHERE e.deleted_at IS NOT NULL
+ORDER BY e.deleted_at DESC
+`
+
+type ListDeletedEvaluationsRow struct {
+	ID               int32              `json:"id"`
+	StudentID        int32              `json:"student_id"`
+	Content          string             `json:"content"`
+	CreatedAt        pgtype.Timestamptz `json:"created_at"`
+	DeletedAt        pgtype.Timestamptz `json:"deleted_at"`
+	StudentName      string             `json:"student_name"`
+	StudentDeletedAt pgtype.Timestamptz `json:"student_deleted_at"`
+	AuthorName       string             `json:"author_name"`
+}
+
+func (q *Queries) ListDeletedEvaluations(ctx context.Context) ([]ListDeletedEvaluationsRow, error) {
+	rows, err := q.db.Query(ctx, listDeletedEvaluations)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListDeletedEvaluationsRow
+	for rows.Next() {
+		var i ListDeletedEvaluationsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.StudentID,
+			&i.Content,
+			&i.CreatedAt,
+			&i.DeletedAt,
+			&i.StudentName,
+			&i.StudentDeletedAt,
+			&i.AuthorName,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listEvaluationsByStudent = `-- name: ListEvaluationsByStudent :many
-SELECT e.id, e.student_id, e.author_id, e.content, e.created_at, e.updated_at, u.name as author_name
+SELECT e.id, e.student_id, e.author_id, e.content, e.created_at, e.updated_at, e.deleted_at, u.name as author_name
 FROM evaluations e
 JOIN users u ON e.author_id = u.id
 WHERE e.student_id = $1
+    AND e.deleted_at IS NULL
     AND ($4::text IS NULL OR e.content ILIKE '%' || $4::text || '%')
     AND ($5::date IS NULL OR e.created_at::date >= $5::date)
     AND ($6::date IS NULL OR e.created_at::date <= $6::date)
@@ -136,6 +275,7 @@ type ListEvaluationsByStudentRow struct {
 	Content    string             `json:"content"`
 	CreatedAt  pgtype.Timestamptz `json:"created_at"`
 	UpdatedAt  pgtype.Timestamptz `json:"updated_at"`
+	DeletedAt  pgtype.Timestamptz `json:"deleted_at"`
 	AuthorName string             `json:"author_name"`
 }
 
@@ -162,6 +302,7 @@ func (q *Queries) ListEvaluationsByStudent(ctx context.Context, arg ListEvaluati
 			&i.Content,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.DeletedAt,
 			&i.AuthorName,
 		); err != nil {
 			return nil, err
@@ -174,11 +315,42 @@ func (q *Queries) ListEvaluationsByStudent(ctx context.Context, arg ListEvaluati
 	return items, nil
 }
 
+const purgeEvaluation = `-- name: PurgeEvaluation :exec
+DELETE FROM evaluations WHERE id = $1 AND deleted_at IS NOT NULL
+`
+
+func (q *Queries) PurgeEvaluation(ctx context.Context, id int32) error {
+	_, err := q.db.Exec(ctx, purgeEvaluation, id)
+	return err
+}
+
+const restoreEvaluation = `-- name: RestoreEvaluation :one
+UPDATE evaluations
+SET deleted_at = NULL
+WHERE id = $1 AND deleted_at IS NOT NULL
+RETURNING id, student_id, author_id, content, created_at, updated_at, deleted_at
+`
+
+func (q *Queries) RestoreEvaluation(ctx context.Context, id int32) (Evaluation, error) {
+	row := q.db.QueryRow(ctx, restoreEvaluation, id)
+	var i Evaluation
+	err := row.Scan(
+		&i.ID,
+		&i.StudentID,
+		&i.AuthorID,
+		&i.Content,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+	)
+	return i, err
+}
+
 const updateEvaluation = `-- name: UpdateEvaluation :one
 UPDATE evaluations
 SET content = $2, updated_at = NOW()
-WHERE id = $1
-RETURNING id, student_id, author_id, content, created_at, updated_at
+WHERE id = $1 AND deleted_at IS NULL
+RETURNING id, student_id, author_id, content, created_at, updated_at, deleted_at
 `
 
 type UpdateEvaluationParams struct {
@@ -196,6 +368,7 @@ func (q *Queries) UpdateEvaluation(ctx context.Context, arg UpdateEvaluationPara
 		&i.Content,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.DeletedAt,
 	)
 	return i, err
 }
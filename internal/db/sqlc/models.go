@@ -8,6 +8,64 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+type ApiToken struct {
+	ID          int32              `json:"id"`
+	UserID      int32              `json:"user_id"`
+	Name        string             `json:"name"`
+	TokenHash   string             `json:"token_hash"`
+	TokenPrefix string             `json:"token_prefix"`
+	ExpiresAt   pgtype.Timestamptz `json:"expires_at"`
+	LastUsedAt  pgtype.Timestamptz `json:"last_used_at"`
+	RevokedAt   pgtype.Timestamptz `json:"revoked_at"`
+	CreatedAt   pgtype.Timestamptz `json:"created_at"`
+}
+
+type Attendance struct {
+	ID             int32              `json:"id"`
+	StudentID      int32              `json:"student_id"`
+	AttendanceDate pgtype.Date        `json:"attendance_date"`
+	Status         string             `json:"status"`
+	Memo           pgtype.Text        `json:"memo"`
+	RecordedBy     pgtype.Int4        `json:"recorded_by"`
+	CreatedAt      pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt      pgtype.Timestamptz `json:"updated_at"`
+}
+
+type AuditLog struct {
+	ID         int32              `json:"id"`
+	ActorID    pgtype.Int4        `json:"actor_id"`
+	Action     string             `json:"action"`
+	EntityType string             `json:"entity_type"`
+	EntityID   int32              `json:"entity_id"`
+	BeforeData []byte             `json:"before_data"`
+	AfterData  []byte             `json:"after_data"`
+	CreatedAt  pgtype.Timestamptz `json:"created_at"`
+}
+
+type Class struct {
+	ID           int32              `json:"id"`
+	Name         string             `json:"name"`
+	InstructorID pgtype.Int4        `json:"instructor_id"`
+	Capacity     pgtype.Int4        `json:"capacity"`
+	Description  pgtype.Text        `json:"description"`
+	CreatedAt    pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt    pgtype.Timestamptz `json:"updated_at"`
+}
+
+type ClassEnrollment struct {
+	ClassID    int32              `json:"class_id"`
+	StudentID  int32              `json:"student_id"`
+	EnrolledAt pgtype.Timestamptz `json:"enrolled_at"`
+}
+
+type ClassSchedule struct {
+	ID        int32       `json:"id"`
+	ClassID   int32       `json:"class_id"`
+	Weekday   int16       `json:"weekday"`
+	StartTime pgtype.Time `json:"start_time"`
+	EndTime   pgtype.Time `json:"end_time"`
+}
+
 type Evaluation struct {
 	ID        int32              `json:"id"`
 	StudentID int32              `json:"student_id"`
@@ -15,25 +73,186 @@ type Evaluation struct {
 	Content   string             `json:"content"`
 	CreatedAt pgtype.Timestamptz `json:"created_at"`
 	UpdatedAt pgtype.Timestamptz `json:"updated_at"`
+	DeletedAt pgtype.Timestamptz `json:"deleted_at"`
 }
 
-type Student struct {
+type EvaluationImage struct {
+	ID           int32              `json:"id"`
+	EvaluationID int32              `json:"evaluation_id"`
+	StorageKey   string             `json:"storage_key"`
+	ThumbnailKey string             `json:"thumbnail_key"`
+	OriginalName string             `json:"original_name"`
+	ContentType  string             `json:"content_type"`
+	SizeBytes    int64              `json:"size_bytes"`
+	Width        int32              `json:"width"`
+	Height       int32              `json:"height"`
+	UploadedBy   pgtype.Int4        `json:"uploaded_by"`
+	CreatedAt    pgtype.Timestamptz `json:"created_at"`
+}
+
+type EvaluationRevision struct {
+	ID           int32              `json:"id"`
+	EvaluationID int32              `json:"evaluation_id"`
+	Content      string             `json:"content"`
+	EditedBy     pgtype.Int4        `json:"edited_by"`
+	CreatedAt    pgtype.Timestamptz `json:"created_at"`
+}
+
+type EvaluationScore struct {
+	EvaluationID int32 `json:"evaluation_id"`
+	CriterionID  int32 `json:"criterion_id"`
+	Score        int32 `json:"score"`
+}
+
+type EvaluationTemplate struct {
+	ID        int32              `json:"id"`
+	OwnerID   pgtype.Int4        `json:"owner_id"`
+	Title     string             `json:"title"`
+	Content   string             `json:"content"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt pgtype.Timestamptz `json:"updated_at"`
+}
+
+type LoginAttempt struct {
+	ID        int32              `json:"id"`
+	Username  string             `json:"username"`
+	UserID    pgtype.Int4        `json:"user_id"`
+	IpAddress string             `json:"ip_address"`
+	UserAgent string             `json:"user_agent"`
+	Success   bool               `json:"success"`
+	Reason    string             `json:"reason"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+}
+
+type Permission struct {
+	Key         string `json:"key"`
+	Category    string `json:"category"`
+	Description string `json:"description"`
+	SortOrder   int32  `json:"sort_order"`
+}
+
+type Role struct {
+	ID               int32              `json:"id"`
+	Name             string             `json:"name"`
+	DisplayName      string             `json:"display_name"`
+	Description      pgtype.Text        `json:"description"`
+	IsSystem         bool               `json:"is_system"`
+	CreatedAt        pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt        pgtype.Timestamptz `json:"updated_at"`
+	MaxSessions      pgtype.Int4        `json:"max_sessions"`
+	RequireTwoFactor bool               `json:"require_two_factor"`
+}
+
+type RolePermission struct {
+	RoleID     int32  `json:"role_id"`
+	Permission string `json:"permission"`
+}
+
+type RubricCriterion struct {
 	ID          int32              `json:"id"`
 	Name        string             `json:"name"`
-	Gender      string             `json:"gender"`
-	Phone       pgtype.Text        `json:"phone"`
-	ParentPhone pgtype.Text        `json:"parent_phone"`
-	Remarks     pgtype.Text        `json:"remarks"`
+	Description pgtype.Text        `json:"description"`
+	MaxScore    int32              `json:"max_score"`
+	SortOrder   int32              `json:"sort_order"`
+	Active      bool               `json:"active"`
 	CreatedAt   pgtype.Timestamptz `json:"created_at"`
 	UpdatedAt   pgtype.Timestamptz `json:"updated_at"`
 }
 
-type User struct {
+type Student struct {
 	ID           int32              `json:"id"`
-	Username     string             `json:"username"`
 	Name         string             `json:"name"`
-	PasswordHash string             `json:"password_hash"`
-	Role         string             `json:"role"`
+	Gender       string             `json:"gender"`
+	Phone        pgtype.Text        `json:"phone"`
+	ParentPhone  pgtype.Text        `json:"parent_phone"`
+	Remarks      pgtype.Text        `json:"remarks"`
+	CreatedAt    pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt    pgtype.Timestamptz `json:"updated_at"`
+	DeletedAt    pgtype.Timestamptz `json:"deleted_at"`
+	Status       string             `json:"status"`
+	EnrolledOn   pgtype.Date        `json:"enrolled_on"`
+	LeftOn       pgtype.Date        `json:"left_on"`
+	StatusReason pgtype.Text        `json:"status_reason"`
+}
+
+type StudentShareLink struct {
+	StudentID int32              `json:"student_id"`
+	Version   int32              `json:"version"`
+	RevokedAt pgtype.Timestamptz `json:"revoked_at"`
+}
+
+type StudentStatusHistory struct {
+	ID         int32              `json:"id"`
+	StudentID  int32              `json:"student_id"`
+	FromStatus pgtype.Text        `json:"from_status"`
+	ToStatus   string             `json:"to_status"`
+	ChangedOn  pgtype.Date        `json:"changed_on"`
+	Reason     pgtype.Text        `json:"reason"`
+	ChangedBy  pgtype.Int4        `json:"changed_by"`
+	CreatedAt  pgtype.Timestamptz `json:"created_at"`
+}
+
+type TuitionInvoice struct {
+	ID           int32              `json:"id"`
+	StudentID    int32              `json:"student_id"`
+	PlanID       pgtype.Int4        `json:"plan_id"`
+	BillingMonth pgtype.Date        `json:"billing_month"`
+	Amount       int64              `json:"amount"`
+	Memo         pgtype.Text        `json:"memo"`
 	CreatedAt    pgtype.Timestamptz `json:"created_at"`
-	SessionToken pgtype.Text        `json:"session_token"`
+}
+
+type TuitionPayment struct {
+	ID         int32              `json:"id"`
+	InvoiceID  int32              `json:"invoice_id"`
+	Amount     int64              `json:"amount"`
+	Method     string             `json:"method"`
+	PaidOn     pgtype.Date        `json:"paid_on"`
+	Memo       pgtype.Text        `json:"memo"`
+	RecordedBy pgtype.Int4        `json:"recorded_by"`
+	CreatedAt  pgtype.Timestamptz `json:"created_at"`
+}
+
+type TuitionPlan struct {
+	ID          int32              `json:"id"`
+	Name        string             `json:"name"`
+	MonthlyFee  int64              `json:"monthly_fee"`
+	Description pgtype.Text        `json:"description"`
+	CreatedAt   pgtype.Timestamptz `json:"created_at"`
+	UpdatedAt   pgtype.Timestamptz `json:"updated_at"`
+}
+
+type User struct {
+	ID                 int32              `json:"id"`
+	Username           string             `json:"username"`
+	Name               string             `json:"name"`
+	PasswordHash       string             `json:"password_hash"`
+	Role               string             `json:"role"`
+	CreatedAt          pgtype.Timestamptz `json:"created_at"`
+	FailedLoginCount   int32              `json:"failed_login_count"`
+	LockedUntil        pgtype.Timestamptz `json:"locked_until"`
+	TotpSecret         pgtype.Text        `json:"totp_secret"`
+	TotpEnabledAt      pgtype.Timestamptz `json:"totp_enabled_at"`
+	TotpLastStep       int64              `json:"totp_last_step"`
+	MustChangePassword bool               `json:"must_change_password"`
+	PasswordChangedAt  pgtype.Timestamptz `json:"password_changed_at"`
+}
+
+type UserRecoveryCode struct {
+	ID        int32              `json:"id"`
+	UserID    int32              `json:"user_id"`
+	CodeHash  string             `json:"code_hash"`
+	UsedAt    pgtype.Timestamptz `json:"used_at"`
+	CreatedAt pgtype.Timestamptz `json:"created_at"`
+}
+
+type UserSession struct {
+	ID         int32              `json:"id"`
+	UserID     int32              `json:"user_id"`
+	TokenHash  string             `json:"token_hash"`
+	UserAgent  string             `json:"user_agent"`
+	IpAddress  string             `json:"ip_address"`
+	CreatedAt  pgtype.Timestamptz `json:"created_at"`
+	LastSeenAt pgtype.Timestamptz `json:"last_seen_at"`
+	ExpiresAt  pgtype.Timestamptz `json:"expires_at"`
 }
@@ -11,29 +11,169 @@ import (
 )
 
 type Querier interface {
-	ClearSessionToken(ctx context.Context, id int32) error
+	AddRolePermission(ctx context.Context, arg AddRolePermissionParams) error
+	CountActiveStudents(ctx context.Context) (int64, error)
+	CountAttendanceByStudent(ctx context.Context, arg CountAttendanceByStudentParams) (int64, error)
+	CountAuditLogs(ctx context.Context, arg CountAuditLogsParams) (int64, error)
+	CountEvaluationImagesByEvaluation(ctx context.Context, evaluationID int32) (int64, error)
+	CountEvaluationImagesByStudent(ctx context.Context, studentID int32) (int64, error)
+	CountEvaluationsByAuthorSince(ctx context.Context, since pgtype.Timestamptz) ([]CountEvaluationsByAuthorSinceRow, error)
 	CountEvaluationsByStudent(ctx context.Context, arg CountEvaluationsByStudentParams) (int64, error)
+	CountLoginAttempts(ctx context.Context, arg CountLoginAttemptsParams) (int64, error)
+	CountRecentFailedLoginsByIP(ctx context.Context, arg CountRecentFailedLoginsByIPParams) (int64, error)
 	CountStudents(ctx context.Context, arg CountStudentsParams) (int64, error)
+	CountStudentsByGender(ctx context.Context) ([]CountStudentsByGenderRow, error)
+	CountStudentsCreatedSince(ctx context.Context, since pgtype.Timestamptz) (int64, error)
+	CountStudentsWithoutRecentEvaluation(ctx context.Context, since pgtype.Timestamptz) (int64, error)
+	CountUnusedRecoveryCodes(ctx context.Context, userID int32) (int64, error)
 	CountUsers(ctx context.Context) (int64, error)
+	CountUsersByRole(ctx context.Context, role string) (int64, error)
+	CreateApiToken(ctx context.Context, arg CreateApiTokenParams) (ApiToken, error)
+	CreateAuditLog(ctx context.Context, arg CreateAuditLogParams) error
+	CreateClass(ctx context.Context, arg CreateClassParams) (Class, error)
+	CreateClassSchedule(ctx context.Context, arg CreateClassScheduleParams) error
 	CreateEvaluation(ctx context.Context, arg CreateEvaluationParams) (Evaluation, error)
+	CreateEvaluationImage(ctx context.Context, arg CreateEvaluationImageParams) (EvaluationImage, error)
+	CreateEvaluationRevision(ctx context.Context, arg CreateEvaluationRevisionParams) error
+	CreateEvaluationTemplate(ctx context.Context, arg CreateEvaluationTemplateParams) (EvaluationTemplate, error)
+	CreateLoginAttempt(ctx context.Context, arg CreateLoginAttemptParams) error
+	CreateRecoveryCode(ctx context.Context, arg CreateRecoveryCodeParams) error
+	CreateRole(ctx context.Context, arg CreateRoleParams) (Role, error)
+	CreateRubricCriterion(ctx context.Context, arg CreateRubricCriterionParams) (RubricCriterion, error)
 	CreateStudent(ctx context.Context, arg CreateStudentParams) (Student, error)
+	CreateStudentStatusHistory(ctx context.Context, arg CreateStudentStatusHistoryParams) error
+	CreateTuitionInvoice(ctx context.Context, arg CreateTuitionInvoiceParams) (TuitionInvoice, error)
+	CreateTuitionPayment(ctx context.Context, arg CreateTuitionPaymentParams) (TuitionPayment, error)
+	CreateTuitionPlan(ctx context.Context, arg CreateTuitionPlanParams) (TuitionPlan, error)
 	CreateUser(ctx context.Context, arg CreateUserParams) (CreateUserRow, error)
+	CreateUserSession(ctx context.Context, arg CreateUserSessionParams) (UserSession, error)
+	DeleteAllUserSessions(ctx context.Context, userID int32) error
+	DeleteAttendance(ctx context.Context, arg DeleteAttendanceParams) error
+	DeleteAttendanceByDate(ctx context.Context, arg DeleteAttendanceByDateParams) error
+	DeleteClass(ctx context.Context, id int32) error
+	DeleteClassSchedulesByClass(ctx context.Context, classID int32) error
 	DeleteEvaluation(ctx context.Context, id int32) error
+	DeleteEvaluationImage(ctx context.Context, id int32) error
+	DeleteEvaluationScore(ctx context.Context, arg DeleteEvaluationScoreParams) error
+	DeleteEvaluationTemplate(ctx context.Context, id int32) error
+	DeleteExpiredUserSessions(ctx context.Context, userID int32) error
+	DeleteOtherUserSessions(ctx context.Context, arg DeleteOtherUserSessionsParams) error
+	DeleteRecoveryCodes(ctx context.Context, userID int32) error
+	DeleteRole(ctx context.Context, id int32) error
+	DeleteRolePermissions(ctx context.Context, roleID int32) error
+	DeleteRubricCriterion(ctx context.Context, id int32) (int64, error)
 	DeleteStudent(ctx context.Context, id int32) error
+	DeleteTuitionInvoice(ctx context.Context, arg DeleteTuitionInvoiceParams) error
+	DeleteTuitionPayment(ctx context.Context, arg DeleteTuitionPaymentParams) error
+	DeleteTuitionPlan(ctx context.Context, id int32) error
 	DeleteUser(ctx context.Context, id int32) error
+	DeleteUserSession(ctx context.Context, arg DeleteUserSessionParams) (int64, error)
+	DeleteUserSessionByHash(ctx context.Context, tokenHash string) error
+	DisableUserTOTP(ctx context.Context, id int32) error
+	EnableUserTOTP(ctx context.Context, arg EnableUserTOTPParams) (int64, error)
+	EnrollStudent(ctx context.Context, arg EnrollStudentParams) error
+	ExportEvaluationsByStudent(ctx context.Context, arg ExportEvaluationsByStudentParams) ([]ExportEvaluationsByStudentRow, error)
+	ExportStudents(ctx context.Context, arg ExportStudentsParams) ([]Student, error)
+	GetActiveApiTokenByHash(ctx context.Context, tokenHash string) (GetActiveApiTokenByHashRow, error)
+	GetActiveUserSession(ctx context.Context, arg GetActiveUserSessionParams) (UserSession, error)
+	GetClassByID(ctx context.Context, id int32) (GetClassByIDRow, error)
+	GetDeletedEvaluationByID(ctx context.Context, id int32) (Evaluation, error)
+	GetDeletedStudentByID(ctx context.Context, id int32) (Student, error)
 	GetEvaluationByID(ctx context.Context, id int32) (GetEvaluationByIDRow, error)
-	GetSessionToken(ctx context.Context, id int32) (pgtype.Text, error)
+	GetEvaluationImageByID(ctx context.Context, id int32) (GetEvaluationImageByIDRow, error)
+	GetEvaluationRevisionByID(ctx context.Context, id int32) (EvaluationRevision, error)
+	GetEvaluationTemplateByID(ctx context.Context, id int32) (EvaluationTemplate, error)
+	GetHighestScoreForCriterion(ctx context.Context, criterionID int32) (int32, error)
+	GetRoleByID(ctx context.Context, id int32) (Role, error)
+	GetRoleByName(ctx context.Context, name string) (Role, error)
+	GetRubricCriterionByID(ctx context.Context, id int32) (RubricCriterion, error)
 	GetStudentByID(ctx context.Context, id int32) (Student, error)
+	GetStudentShareLink(ctx context.Context, studentID int32) (StudentShareLink, error)
+	GetStudentTuitionBalance(ctx context.Context, studentID int32) (GetStudentTuitionBalanceRow, error)
+	GetTuitionInvoice(ctx context.Context, arg GetTuitionInvoiceParams) (TuitionInvoice, error)
+	GetTuitionPlanByID(ctx context.Context, id int32) (TuitionPlan, error)
+	GetUserAuthorization(ctx context.Context, id int32) (GetUserAuthorizationRow, error)
 	GetUserByID(ctx context.Context, id int32) (GetUserByIDRow, error)
 	GetUserByUsername(ctx context.Context, username string) (GetUserByUsernameRow, error)
+	GetUserMaxSessions(ctx context.Context, id int32) (pgtype.Int4, error)
+	GetUserTwoFactor(ctx context.Context, id int32) (GetUserTwoFactorRow, error)
+	ListActiveRubricCriteria(ctx context.Context) ([]RubricCriterion, error)
+	ListActiveUserSessions(ctx context.Context, userID int32) ([]UserSession, error)
+	ListAllRolePermissions(ctx context.Context) ([]ListAllRolePermissionsRow, error)
+	ListApiTokensByUser(ctx context.Context, userID int32) ([]ApiToken, error)
+	ListAttendanceByStudent(ctx context.Context, arg ListAttendanceByStudentParams) ([]ListAttendanceByStudentRow, error)
+	ListAuditLogs(ctx context.Context, arg ListAuditLogsParams) ([]ListAuditLogsRow, error)
+	ListClassSchedules(ctx context.Context) ([]ClassSchedule, error)
+	ListClassSchedulesByClass(ctx context.Context, classID int32) ([]ClassSchedule, error)
+	ListClassStudents(ctx context.Context, classID int32) ([]ListClassStudentsRow, error)
+	ListClasses(ctx context.Context) ([]ListClassesRow, error)
+	ListDeletedEvaluations(ctx context.Context) ([]ListDeletedEvaluationsRow, error)
+	ListDeletedStudents(ctx context.Context) ([]ListDeletedStudentsRow, error)
+	ListEvaluationImageKeysByStudent(ctx context.Context, studentID int32) ([]ListEvaluationImageKeysByStudentRow, error)
+	ListEvaluationImagesByEvaluation(ctx context.Context, evaluationID int32) ([]EvaluationImage, error)
+	ListEvaluationImagesByEvaluations(ctx context.Context, evaluationIds []int32) ([]EvaluationImage, error)
+	ListEvaluationImagesByStudent(ctx context.Context, arg ListEvaluationImagesByStudentParams) ([]ListEvaluationImagesByStudentRow, error)
+	ListEvaluationRevisions(ctx context.Context, evaluationID int32) ([]ListEvaluationRevisionsRow, error)
+	ListEvaluationScores(ctx context.Context, evaluationID int32) ([]ListEvaluationScoresRow, error)
+	ListEvaluationScoresByEvaluations(ctx context.Context, evaluationIds []int32) ([]EvaluationScore, error)
+	ListEvaluationTemplatesForUser(ctx context.Context, ownerID pgtype.Int4) ([]EvaluationTemplate, error)
 	ListEvaluationsByStudent(ctx context.Context, arg ListEvaluationsByStudentParams) ([]ListEvaluationsByStudentRow, error)
+	ListLockedUserIDs(ctx context.Context) ([]int32, error)
+	ListLoginAttempts(ctx context.Context, arg ListLoginAttemptsParams) ([]LoginAttempt, error)
+	ListPermissions(ctx context.Context) ([]Permission, error)
+	ListRecentLoginAttemptsByUser(ctx context.Context, arg ListRecentLoginAttemptsByUserParams) ([]LoginAttempt, error)
+	ListRolePermissions(ctx context.Context, name string) ([]string, error)
+	ListRoles(ctx context.Context) ([]ListRolesRow, error)
+	ListRollCall(ctx context.Context, attendanceDate pgtype.Date) ([]ListRollCallRow, error)
+	ListRubricCriteria(ctx context.Context) ([]RubricCriterion, error)
+	ListScoreProgressByStudent(ctx context.Context, studentID int32) ([]ListScoreProgressByStudentRow, error)
+	ListStudentStatusHistory(ctx context.Context, studentID int32) ([]ListStudentStatusHistoryRow, error)
 	ListStudents(ctx context.Context, arg ListStudentsParams) ([]Student, error)
+	ListStudentsByNames(ctx context.Context, names []string) ([]ListStudentsByNamesRow, error)
+	ListStudentsNotInClass(ctx context.Context, classID int32) ([]ListStudentsNotInClassRow, error)
+	ListStudentsWithoutRecentEvaluation(ctx context.Context, arg ListStudentsWithoutRecentEvaluationParams) ([]ListStudentsWithoutRecentEvaluationRow, error)
+	ListTuitionInvoicesByStudent(ctx context.Context, studentID int32) ([]ListTuitionInvoicesByStudentRow, error)
+	ListTuitionPaymentsByStudent(ctx context.Context, studentID int32) ([]ListTuitionPaymentsByStudentRow, error)
+	ListTuitionPlans(ctx context.Context) ([]TuitionPlan, error)
+	ListUnpaidInvoices(ctx context.Context, billingMonth pgtype.Date) ([]ListUnpaidInvoicesRow, error)
 	ListUsers(ctx context.Context) ([]ListUsersRow, error)
+	LockClass(ctx context.Context, id int32) (int32, error)
+	PurgeEvaluation(ctx context.Context, id int32) error
+	PurgeStudent(ctx context.Context, id int32) error
+	// Undoes ReserveLoginAttempt for a correct password, unless another attempt came in between
+	ReleaseLoginAttempt(ctx context.Context, arg ReleaseLoginAttemptParams) error
+	// Counts a login attempt as failed before its password or code is checked, and locks the
+	// account in the same statement once it reaches the limit. Returns no row when it is locked.
+	ReserveLoginAttempt(ctx context.Context, arg ReserveLoginAttemptParams) (ReserveLoginAttemptRow, error)
+	ResetLoginFailures(ctx context.Context, id int32) error
+	RestoreEvaluation(ctx context.Context, id int32) (Evaluation, error)
+	RestoreStudent(ctx context.Context, id int32) (Student, error)
+	RevokeAllUserApiTokens(ctx context.Context, userID int32) error
+	RevokeApiToken(ctx context.Context, arg RevokeApiTokenParams) (ApiToken, error)
+	RevokeStudentShareLinks(ctx context.Context, studentID int32) (StudentShareLink, error)
+	// 등록 진행 중인 비밀키 저장. 이미 활성화된 경우에는 바꾸지 않음
+	SetUserTOTPSecret(ctx context.Context, arg SetUserTOTPSecretParams) error
+	SummarizeAttendanceByStudent(ctx context.Context, arg SummarizeAttendanceByStudentParams) ([]SummarizeAttendanceByStudentRow, error)
+	TouchApiToken(ctx context.Context, id int32) error
+	TouchUserSession(ctx context.Context, id int32) error
+	// 최근에 사용한 세션 limit개만 남기고 종료
+	TrimUserSessions(ctx context.Context, arg TrimUserSessionsParams) error
+	UpdateClass(ctx context.Context, arg UpdateClassParams) (Class, error)
 	UpdateEvaluation(ctx context.Context, arg UpdateEvaluationParams) (Evaluation, error)
-	UpdateSessionToken(ctx context.Context, arg UpdateSessionTokenParams) error
+	UpdateEvaluationTemplate(ctx context.Context, arg UpdateEvaluationTemplateParams) (EvaluationTemplate, error)
+	UpdateRole(ctx context.Context, arg UpdateRoleParams) (Role, error)
+	UpdateRubricCriterion(ctx context.Context, arg UpdateRubricCriterionParams) (RubricCriterion, error)
 	UpdateStudent(ctx context.Context, arg UpdateStudentParams) (Student, error)
+	UpdateStudentStatus(ctx context.Context, arg UpdateStudentStatusParams) (Student, error)
+	UpdateTuitionPlan(ctx context.Context, arg UpdateTuitionPlanParams) (TuitionPlan, error)
 	UpdateUser(ctx context.Context, arg UpdateUserParams) (UpdateUserRow, error)
 	UpdateUserPassword(ctx context.Context, arg UpdateUserPasswordParams) error
+	// 이미 사용된 시간 단계 이하이면 0행을 반환하므로 동시에 들어온 같은 코드도 한 번만 통과
+	UpdateUserTOTPStep(ctx context.Context, arg UpdateUserTOTPStepParams) (int64, error)
+	UpsertAttendance(ctx context.Context, arg UpsertAttendanceParams) (Attendance, error)
+	UpsertEvaluationScore(ctx context.Context, arg UpsertEvaluationScoreParams) error
+	UseRecoveryCode(ctx context.Context, arg UseRecoveryCodeParams) (int64, error)
+	WithdrawStudent(ctx context.Context, arg WithdrawStudentParams) error
 }
 
 var _ Querier = (*Queries)(nil)
@@ -13,31 +13,43 @@ import (
 
 const countStudents = `-- name: CountStudents :one
 SELECT COUNT(*) FROM students
-WHERE
-    ($1::text IS NULL OR
+WHERE deleted_at IS NULL
+    AND ($1::text IS NULL OR
         name ILIKE '%' || $1::text || '%' OR
         phone ILIKE '%' || $1::text || '%' OR
         parent_phone ILIKE '%' || $1::text || '%' OR
         remarks ILIKE '%' || $1::text || '%')
     AND ($2::text IS NULL OR gender = $2::text)
+    AND ($3::int IS NULL OR EXISTS (
+        SELECT 1 FROM class_enrollments ce
+        WHERE ce.student_id = students.id AND ce.class_id = $3::int))
+    AND ($4::text[] IS NULL OR status = ANY($4::text[]))
 `
 
 type CountStudentsParams struct {
-	Search pgtype.Text `json:"search"`
-	Gender pgtype.Text `json:"gender"`
+	Search   pgtype.Text `json:"search"`
+	Gender   pgtype.Text `json:"gender"`
+	ClassID  pgtype.Int4 `json:"class_id"`
+	Statuses []string    `json:"statuses"`
 }
 
 func (q *Queries) CountStudents(ctx context.Context, arg CountStudentsParams) (int64, error) {
-	row := q.db.QueryRow(ctx, countStudents, arg.Search, arg.Gender)
+	row := q.db.QueryRow(ctx, countStudents,
+		arg.Search,
+		arg.Gender,
+		arg.ClassID,
+		arg.Statuses,
+	)
 	var count int64
 	err := row.Scan(&count)
 	return count, err
 }
 
 const createStudent = `-- name: CreateStudent :one
-INSERT INTO students (name, gender, phone, parent_phone, remarks)
-VALUES ($1, $2, $3, $4, $5)
-RETURNING id, name, gender, phone, parent_phone, remarks, created_at, updated_at
+INSERT INTO students (name, gender, phone, parent_phone, remarks, enrolled_on)
+VALUES ($1, $2, $3, $4, $5, COALESCE($6::date, CURRENT_DATE))
+RETURNING id, name, gender, phone, parent_phone, remarks, created_at, updated_at, deleted_at,
+    status, enrolled_on, left_on, status_reason
 `
 
 type CreateStudentParams struct {
@@ -46,6 +58,7 @@ type CreateStudentParams struct {
 	Phone       pgtype.Text `json:"phone"`
 	ParentPhone pgtype.Text `json:"parent_phone"`
 	Remarks     pgtype.Text `json:"remarks"`
+	EnrolledOn  pgtype.Date `json:"enrolled_on"`
 }
 
 func (q *Queries) CreateStudent(ctx context.Context, arg CreateStudentParams) (Student, error) {
@@ -55,6 +68,7 @@ func (q *Queries) CreateStudent(ctx context.Context, arg CreateStudentParams) (S
 		arg.Phone,
 		arg.ParentPhone,
 		arg.Remarks,
+		arg.EnrolledOn,
 	)
 	var i Student
 	err := row.Scan(
@@ -66,13 +80,45 @@ func (q *Queries) CreateStudent(ctx context.Context, arg CreateStudentParams) (S
 		&i.Remarks,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.DeletedAt,
+		&i.Status,
+		&i.EnrolledOn,
+		&i.LeftOn,
+		&i.StatusReason,
 	)
 	return i, err
 }
 
+const createStudentStatusHistory = `-- name: CreateStudentStatusHistory :exec
+INSERT INTO student_status_history (student_id, from_status, to_status, changed_on, reason, changed_by)
+VALUES ($1, $2, $3, $4, $5, $6)
+`
+
+type CreateStudentStatusHistoryParams struct {
+	StudentID  int32       `json:"student_id"`
+	FromStatus pgtype.Text `json:"from_status"`
+	ToStatus   string      `json:"to_status"`
+	ChangedOn  pgtype.Date `json:"changed_on"`
+	Reason     pgtype.Text `json:"reason"`
+	ChangedBy  pgtype.Int4 `json:"changed_by"`
+}
+
+func (q *Queries) CreateStudentStatusHistory(ctx context.Context, arg CreateStudentStatusHistoryParams) error {
+	_, err := q.db.Exec(ctx, createStudentStatusHistory,
+		arg.StudentID,
+		arg.FromStatus,
+		arg.ToStatus,
+		arg.ChangedOn,
+		arg.Reason,
+		arg.ChangedBy,
+	)
+	return err
+}
+
 const deleteStudent = `-- name: DeleteStudent :exec
-DELETE FROM students
-WHERE id = $1
+UPDATE students
+SET deleted_at = NOW()
+WHERE id = $1 AND deleted_at IS NULL
 `
 
 func (q *Queries) DeleteStudent(ctx context.Context, id int32) error {
@@ -80,10 +126,103 @@ func (q *Queries) DeleteStudent(ctx context.Context, id int32) error {
 	return err
 }
 
+const exportStudents = `-- name: ExportStudents :many
+SELECT id, name, gender, phone, parent_phone, remarks, created_at, updated_at, deleted_at,
+    status, enrolled_on, left_on, status_reason
+FROM students
+WHERE deleted_at IS NULL
+    AND ($1::text IS NULL OR
+        name ILIKE '%' || $1::text || '%' OR
+        phone ILIKE '%' || $1::text || '%' OR
+        parent_phone ILIKE '%' || $1::text || '%' OR
+        remarks ILIKE '%' || $1::text || '%')
+    AND ($2::text IS NULL OR gender = $2::text)
+    AND ($3::int IS NULL OR EXISTS (
+        SELECT 1 FROM class_enrollments ce
+        WHERE ce.student_id = students.id AND ce.class_id = $3::int))
+    AND ($4::text[] IS NULL OR status = ANY($4::text[]))
+ORDER BY created_at DESC
+`
+
+type ExportStudentsParams struct {
+	Search   pgtype.Text `json:"search"`
+	Gender   pgtype.Text `json:"gender"`
+	ClassID  pgtype.Int4 `json:"class_id"`
+	Statuses []string    `json:"statuses"`
+}
+
+func (q *Queries) ExportStudents(ctx context.Context, arg ExportStudentsParams) ([]Student, error) {
+	rows, err := q.db.Query(ctx, exportStudents,
+		arg.Search,
+		arg.Gender,
+		arg.ClassID,
+		arg.Statuses,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Student
+	for rows.Next() {
+		var i Student
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Gender,
+			&i.Phone,
+			&i.ParentPhone,
+			&i.Remarks,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.DeletedAt,
+			&i.Status,
+			&i.EnrolledOn,
+			&i.LeftOn,
+			&i.StatusReason,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getDeletedStudentByID = `-- name: GetDeletedStudentByID :one
+SELECT id, name, gender, phone, parent_phone, remarks, created_at, updated_at, deleted_at,
+    status, enrolled_on, left_on, status_reason
+FROM students
+WHERE id = $1 AND deleted_at IS NOT NULL
+`
+
+func (q *Queries) GetDeletedStudentByID(ctx context.Context, id int32) (Student, error) {
+	row := q.db.QueryRow(ctx, getDeletedStudentByID, id)
+	var i Student
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Gender,
+		&i.Phone,
+		&i.ParentPhone,
+		&i.Remarks,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+		&i.Status,
+		&i.EnrolledOn,
+		&i.LeftOn,
+		&i.StatusReason,
+	)
+	return i, err
+}
+
 const getStudentByID = `-- name: GetStudentByID :one
-SELECT id, name, gender, phone, parent_phone, remarks, created_at, updated_at
+SELECT id, name, gender, phone, parent_phone, remarks, created_at, updated_at, deleted_at,
+    status, enrolled_on, left_on, status_reason
 FROM students
-WHERE id = $1
+WHERE id = $1 AND deleted_at IS NULL
 `
 
 func (q *Queries) GetStudentByID(ctx context.Context, id int32) (Student, error) {
@@ -98,29 +237,132 @@ func (q *Queries) GetStudentByID(ctx context.Context, id int32) (Student, error)
 		&i.Remarks,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.DeletedAt,
+		&i.Status,
+		&i.EnrolledOn,
+		&i.LeftOn,
+		&i.StatusReason,
 	)
 	return i, err
 }
 
+const listDeletedStudents = `-- name: ListDeletedStudents :many
+SELECT s.id, s.name, s.gender, s.parent_phone, s.deleted_at,
+    (SELECT COUNT(*) FROM evaluations e WHERE e.student_id = s.id)::bigint as evaluation_count
+FROM students s
+WHERE s.deleted_at IS NOT NULL
+ORDER BY s.deleted_at DESC
+`
+
+type ListDeletedStudentsRow struct {
+	ID              int32              `json:"id"`
+	Name            string             `json:"name"`
+	Gender          string             `json:"gender"`
+	ParentPhone     pgtype.Text        `json:"parent_phone"`
+	DeletedAt       pgtype.Timestamptz `json:"deleted_at"`
+	EvaluationCount int64              `json:"evaluation_count"`
+}
+
+func (q *Queries) ListDeletedStudents(ctx context.Context) ([]ListDeletedStudentsRow, error) {
+	rows, err := q.db.Query(ctx, listDeletedStudents)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListDeletedStudentsRow
+	for rows.Next() {
+		var i ListDeletedStudentsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Gender,
+			&i.ParentPhone,
+			&i.DeletedAt,
+			&i.EvaluationCount,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listStudentStatusHistory = `-- name: ListStudentStatusHistory :many
+SELECT h.id, h.from_status, h.to_status, h.changed_on, h.reason, h.created_at,
+    u.name as changed_by_name
+FROM student_status_history h
+LEFT JOIN users u ON h.changed_by = u.id
+WHERE h.student_id = $1
+ORDER BY h.changed_on DESC, h.id DESC
+`
+
+type ListStudentStatusHistoryRow struct {
+	ID            int32              `json:"id"`
+	FromStatus    pgtype.Text        `json:"from_status"`
+	ToStatus      string             `json:"to_status"`
+	ChangedOn     pgtype.Date        `json:"changed_on"`
+	Reason        pgtype.Text        `json:"reason"`
+	CreatedAt     pgtype.Timestamptz `json:"created_at"`
+	ChangedByName pgtype.Text        `json:"changed_by_name"`
+}
+
+func (q *Queries) ListStudentStatusHistory(ctx context.Context, studentID int32) ([]ListStudentStatusHistoryRow, error) {
+	rows, err := q.db.Query(ctx, listStudentStatusHistory, studentID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListStudentStatusHistoryRow
+	for rows.Next() {
+		var i ListStudentStatusHistoryRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.FromStatus,
+			&i.ToStatus,
+			&i.ChangedOn,
+			&i.Reason,
+			&i.CreatedAt,
+			&i.ChangedByName,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listStudents = `-- name: ListStudents :many
-SELECT id, name, gender, phone, parent_phone, remarks, created_at, updated_at
+SELECT id, name, gender, phone, parent_phone, remarks, created_at, updated_at, deleted_at,
+    status, enrolled_on, left_on, status_reason
 FROM students
-WHERE
-    ($3::text IS NULL OR
+WHERE deleted_at IS NULL
+    AND ($3::text IS NULL OR
         name ILIKE '%' || $3::text || '%' OR
         phone ILIKE '%' || $3::text || '%' OR
         parent_phone ILIKE '%' || $3::text || '%' OR
         remarks ILIKE '%' || $3::text || '%')
     AND ($4::text IS NULL OR gender = $4::text)
+    AND ($5::int IS NULL OR EXISTS (
+        SELECT 1 FROM class_enrollments ce
+        WHERE ce.student_id = students.id AND ce.class_id = $5::int))
+    AND ($6::text[] IS NULL OR status = ANY($6::text[]))
 ORDER BY created_at DESC
 LIMIT $1 OFFSET $2
 `
 
 type ListStudentsParams struct {
-	Limit  int32       `json:"limit"`
-	Offset int32       `json:"offset"`
-	Search pgtype.Text `json:"search"`
-	Gender pgtype.Text `json:"gender"`
+	Limit    int32       `json:"limit"`
+	Offset   int32       `json:"offset"`
+	Search   pgtype.Text `json:"search"`
+	Gender   pgtype.Text `json:"gender"`
+	ClassID  pgtype.Int4 `json:"class_id"`
+	Statuses []string    `json:"statuses"`
 }
 
 func (q *Queries) ListStudents(ctx context.Context, arg ListStudentsParams) ([]Student, error) {
@@ -129,6 +371,8 @@ func (q *Queries) ListStudents(ctx context.Context, arg ListStudentsParams) ([]S
 		arg.Offset,
 		arg.Search,
 		arg.Gender,
+		arg.ClassID,
+		arg.Statuses,
 	)
 	if err != nil {
 		return nil, err
@@ -146,6 +390,11 @@ func (q *Queries) ListStudents(ctx context.Context, arg ListStudentsParams) ([]S
 			&i.Remarks,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.DeletedAt,
+			&i.Status,
+			&i.EnrolledOn,
+			&i.LeftOn,
+			&i.StatusReason,
 		); err != nil {
 			return nil, err
 		}
@@ -157,11 +406,85 @@ func (q *Queries) ListStudents(ctx context.Context, arg ListStudentsParams) ([]S
 	return items, nil
 }
 
+const listStudentsByNames = `-- name: ListStudentsByNames :many
+SELECT id, name, parent_phone
+FROM students
+WHERE deleted_at IS NULL AND name = ANY($1::text[])
+ORDER BY id
+`
+
+type ListStudentsByNamesRow struct {
+	ID          int32       `json:"id"`
+	Name        string      `json:"name"`
+	ParentPhone pgtype.Text `json:"parent_phone"`
+}
+
+func (q *Queries) ListStudentsByNames(ctx context.Context, names []string) ([]ListStudentsByNamesRow, error) {
+	rows, err := q.db.Query(ctx, listStudentsByNames, names)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListStudentsByNamesRow
+	for rows.Next() {
+		var i ListStudentsByNamesRow
+		if err := rows.Scan(&i.ID, &i.Name, &i.ParentPhone); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const purgeStudent = `-- name: PurgeStudent :exec
+DELETE FROM students
+WHERE id = $1 AND deleted_at IS NOT NULL
+`
+
+func (q *Queries) PurgeStudent(ctx context.Context, id int32) error {
+	_, err := q.db.Exec(ctx, purgeStudent, id)
+	return err
+}
+
+const restoreStudent = `-- name: RestoreStudent :one
+UPDATE students
+SET deleted_at = NULL
+WHERE id = $1 AND deleted_at IS NOT NULL
+RETURNING id, name, gender, phone, parent_phone, remarks, created_at, updated_at, deleted_at,
+    status, enrolled_on, left_on, status_reason
+`
+
+func (q *Queries) RestoreStudent(ctx context.Context, id int32) (Student, error) {
+	row := q.db.QueryRow(ctx, restoreStudent, id)
+	var i Student
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Gender,
+		&i.Phone,
+		&i.ParentPhone,
+		&i.Remarks,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+		&i.Status,
+		&i.EnrolledOn,
+		&i.LeftOn,
+		&i.StatusReason,
+	)
+	return i, err
+}
+
 const updateStudent = `-- name: UpdateStudent :one
 UPDATE students
-SET name = $2, gender = $3, phone = $4, parent_phone = $5, remarks = $6, updated_at = NOW()
-WHERE id = $1
-RETURNING id, name, gender, phone, parent_phone, remarks, created_at, updated_at
+SET name = $2, gender = $3, phone = $4, parent_phone = $5, remarks = $6,
+    enrolled_on = COALESCE($7::date, enrolled_on), updated_at = NOW()
+WHERE id = $1 AND deleted_at IS NULL
+RETURNING id, name, gender, phone, parent_phone, remarks, created_at, updated_at, deleted_at,
+    status, enrolled_on, left_on, status_reason
 `
 
 type UpdateStudentParams struct {
@@ -171,6 +494,7 @@ type UpdateStudentParams struct {
 	Phone       pgtype.Text `json:"phone"`
 	ParentPhone pgtype.Text `json:"parent_phone"`
 	Remarks     pgtype.Text `json:"remarks"`
+	EnrolledOn  pgtype.Date `json:"enrolled_on"`
 }
 
 func (q *Queries) UpdateStudent(ctx context.Context, arg UpdateStudentParams) (Student, error) {
@@ -181,6 +505,48 @@ func (q *Queries) UpdateStudent(ctx context.Context, arg UpdateStudentParams) (S
 		arg.Phone,
 		arg.ParentPhone,
 		arg.Remarks,
+		arg.EnrolledOn,
+	)
+	var i Student
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Gender,
+		&i.Phone,
+		&i.ParentPhone,
+		&i.Remarks,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+		&i.Status,
+		&i.EnrolledOn,
+		&i.LeftOn,
+		&i.StatusReason,
+	)
+	return i, err
+}
+
+const updateStudentStatus = `-- name: UpdateStudentStatus :one
+UPDATE students
+SET status = $2, left_on = $3, status_reason = $4, updated_at = NOW()
+WHERE id = $1 AND deleted_at IS NULL
+RETURNING id, name, gender, phone, parent_phone, remarks, created_at, updated_at, deleted_at,
+    status, enrolled_on, left_on, status_reason
+`
+
+type UpdateStudentStatusParams struct {
+	ID           int32       `json:"id"`
+	Status       string      `json:"status"`
+	LeftOn       pgtype.Date `json:"left_on"`
+	StatusReason pgtype.Text `json:"status_reason"`
+}
+
+func (q *Queries) UpdateStudentStatus(ctx context.Context, arg UpdateStudentStatusParams) (Student, error) {
+	row := q.db.QueryRow(ctx, updateStudentStatus,
+		arg.ID,
+		arg.Status,
+		arg.LeftOn,
+		arg.StatusReason,
 	)
 	var i Student
 	err := row.Scan(
@@ -192,6 +558,11 @@ func (q *Queries) UpdateStudent(ctx context.Context, arg UpdateStudentParams) (S
 		&i.Remarks,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.DeletedAt,
+		&i.Status,
+		&i.EnrolledOn,
+		&i.LeftOn,
+		&i.StatusReason,
 	)
 	return i, err
 }
@@ -11,17 +11,6 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
-const clearSessionToken = `-- name: ClearSessionToken :exec
-UPDATE users
-SET session_token = NULL
-WHERE id = $1
-`
-
-func (q *Queries) ClearSessionToken(ctx context.Context, id int32) error {
-	_, err := q.db.Exec(ctx, clearSessionToken, id)
-	return err
-}
-
 const countUsers = `-- name: CountUsers :one
 SELECT COUNT(*) FROM users
 `
@@ -34,8 +23,8 @@ func (q *Queries) CountUsers(ctx context.Context) (int64, error) {
 }
 
 const createUser = `-- name: CreateUser :one
-INSERT INTO users (username, name, password_hash, role)
-VALUES ($1, $2, $3, $4)
+INSERT INTO users (username, name, password_hash, role, must_change_password)
+VALUES ($1, $2, $3, $4, TRUE)
 RETURNING id, username, name, password_hash, role, created_at
 `
 
@@ -84,32 +73,22 @@ func (q *Queries) DeleteUser(ctx context.Context, id int32) error {
 	return err
 }
 
-const getSessionToken = `-- name: GetSessionToken :one
-SELECT session_token
-FROM users
-WHERE id = $1
-`
-
-func (q *Queries) GetSessionToken(ctx context.Context, id int32) (pgtype.Text, error) {
-	row := q.db.QueryRow(ctx, getSessionToken, id)
-	var session_token pgtype.Text
-	err := row.Scan(&session_token)
-	return session_token, err
-}
-
 const getUserByID = `-- name: GetUserByID :one
-SELECT id, username, name, password_hash, role, created_at
+SELECT id, username, name, password_hash, role, created_at, failed_login_count, locked_until, must_change_password
 FROM users
 WHERE id = $1
 `
 
 type GetUserByIDRow struct {
-	ID           int32              `json:"id"`
-	Username     string             `json:"username"`
-	Name         string             `json:"name"`
-	PasswordHash string             `json:"password_hash"`
-	Role         string             `json:"role"`
-	CreatedAt    pgtype.Timestamptz `json:"created_at"`
+	ID                 int32              `json:"id"`
+	Username           string             `json:"username"`
+	Name               string             `json:"name"`
+	PasswordHash       string             `json:"password_hash"`
+	Role               string             `json:"role"`
+	CreatedAt          pgtype.Timestamptz `json:"created_at"`
+	FailedLoginCount   int32              `json:"failed_login_count"`
+	LockedUntil        pgtype.Timestamptz `json:"locked_until"`
+	MustChangePassword bool               `json:"must_change_password"`
 }
 
 func (q *Queries) GetUserByID(ctx context.Context, id int32) (GetUserByIDRow, error) {
@@ -122,23 +101,29 @@ func (q *Queries) GetUserByID(ctx context.Context, id int32) (GetUserByIDRow, er
 		&i.PasswordHash,
 		&i.Role,
 		&i.CreatedAt,
+		&i.FailedLoginCount,
+		&i.LockedUntil,
+		&i.MustChangePassword,
 	)
 	return i, err
 }
 
 const getUserByUsername = `-- name: GetUserByUsername :one
-SELECT id, username, name, password_hash, role, created_at
+SELECT id, username, name, password_hash, role, created_at, failed_login_count, locked_until, must_change_password
 FROM users
 WHERE username = $1
 `
 
 type GetUserByUsernameRow struct {
-	ID           int32              `json:"id"`
-	Username     string             `json:"username"`
-	Name         string             `json:"name"`
-	PasswordHash string             `json:"password_hash"`
-	Role         string             `json:"role"`
-	CreatedAt    pgtype.Timestamptz `json:"created_at"`
+	ID                 int32              `json:"id"`
+	Username           string             `json:"username"`
+	Name               string             `json:"name"`
+	PasswordHash       string             `json:"password_hash"`
+	Role               string             `json:"role"`
+	CreatedAt          pgtype.Timestamptz `json:"created_at"`
+	FailedLoginCount   int32              `json:"failed_login_count"`
+	LockedUntil        pgtype.Timestamptz `json:"locked_until"`
+	MustChangePassword bool               `json:"must_change_password"`
 }
 
 func (q *Queries) GetUserByUsername(ctx context.Context, username string) (GetUserByUsernameRow, error) {
@@ -151,10 +136,38 @@ func (q *Queries) GetUserByUsername(ctx context.Context, username string) (GetUs
 		&i.PasswordHash,
 		&i.Role,
 		&i.CreatedAt,
+		&i.FailedLoginCount,
+		&i.LockedUntil,
+		&i.MustChangePassword,
 	)
 	return i, err
 }
 
+const listLockedUserIDs = `-- name: ListLockedUserIDs :many
+SELECT id FROM users
+WHERE locked_until > NOW()
+`
+
+func (q *Queries) ListLockedUserIDs(ctx context.Context) ([]int32, error) {
+	rows, err := q.db.Query(ctx, listLockedUserIDs)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []int32
+	for rows.Next() {
+		var id int32
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		items = append(items, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listUsers = `-- name: ListUsers :many
 SELECT id, username, name, role, created_at
 FROM users
@@ -195,19 +208,61 @@ func (q *Queries) ListUsers(ctx context.Context) ([]ListUsersRow, error) {
 	return items, nil
 }
 
-const updateSessionToken = `-- name: UpdateSessionToken :exec
+const releaseLoginAttempt = `-- name: ReleaseLoginAttempt :exec
 UPDATE users
-SET session_token = $2
-WHERE id = $1
+SET failed_login_count = failed_login_count - 1, locked_until = $3
+WHERE id = $1 AND failed_login_count = $2
+`
+
+type ReleaseLoginAttemptParams struct {
+	ID               int32              `json:"id"`
+	FailedLoginCount int32              `json:"failed_login_count"`
+	LockedUntil      pgtype.Timestamptz `json:"locked_until"`
+}
+
+// Undoes ReserveLoginAttempt for a correct password, unless another attempt came in between
+func (q *Queries) ReleaseLoginAttempt(ctx context.Context, arg ReleaseLoginAttemptParams) error {
+	_, err := q.db.Exec(ctx, releaseLoginAttempt, arg.ID, arg.FailedLoginCount, arg.LockedUntil)
+	return err
+}
+
+const reserveLoginAttempt = `-- name: ReserveLoginAttempt :one
+UPDATE users
+SET failed_login_count = failed_login_count + 1,
+    locked_until = CASE WHEN failed_login_count + 1 >= $1::int
+        THEN $2::timestamptz ELSE locked_until END
+WHERE id = $3 AND (locked_until IS NULL OR locked_until <= NOW())
+RETURNING failed_login_count, locked_until
 `
 
-type UpdateSessionTokenParams struct {
-	ID           int32       `json:"id"`
-	SessionToken pgtype.Text `json:"session_token"`
+type ReserveLoginAttemptParams struct {
+	MaxFailures int32              `json:"max_failures"`
+	LockUntil   pgtype.Timestamptz `json:"lock_until"`
+	ID          int32              `json:"id"`
+}
+
+type ReserveLoginAttemptRow struct {
+	FailedLoginCount int32              `json:"failed_login_count"`
+	LockedUntil      pgtype.Timestamptz `json:"locked_until"`
 }
 
-func (q *Queries) UpdateSessionToken(ctx context.Context, arg UpdateSessionTokenParams) error {
-	_, err := q.db.Exec(ctx, updateSessionToken, arg.ID, arg.SessionToken)
+// Counts a login attempt as failed before its password or code is checked, and locks the
+// account in the same statement once it reaches the limit. Returns no row when it is locked.
+func (q *Queries) ReserveLoginAttempt(ctx context.Context, arg ReserveLoginAttemptParams) (ReserveLoginAttemptRow, error) {
+	row := q.db.QueryRow(ctx, reserveLoginAttempt, arg.MaxFailures, arg.LockUntil, arg.ID)
+	var i ReserveLoginAttemptRow
+	err := row.Scan(&i.FailedLoginCount, &i.LockedUntil)
+	return i, err
+}
+
+const resetLoginFailures = `-- name: ResetLoginFailures :exec
+UPDATE users
+SET failed_login_count = 0, locked_until = NULL
+WHERE id = $1
+`
+
+func (q *Queries) ResetLoginFailures(ctx context.Context, id int32) error {
+	_, err := q.db.Exec(ctx, resetLoginFailures, id)
 	return err
 }
 
@@ -249,16 +304,17 @@ func (q *Queries) UpdateUser(ctx context.Context, arg UpdateUserParams) (UpdateU
 
 const updateUserPassword = `-- name: UpdateUserPassword :exec
 UPDATE users
-SET password_hash = $2
+SET password_hash = $2, must_change_password = $3, password_changed_at = NOW()
 WHERE id = $1
 `
 
 type UpdateUserPasswordParams struct {
-	ID           int32  `json:"id"`
-	PasswordHash string `json:"password_hash"`
+	ID                 int32  `json:"id"`
+	PasswordHash       string `json:"password_hash"`
+	MustChangePassword bool   `json:"must_change_password"`
 }
 
 func (q *Queries) UpdateUserPassword(ctx context.Context, arg UpdateUserPasswordParams) error {
-	_, err := q.db.Exec(ctx, updateUserPassword, arg.ID, arg.PasswordHash)
+	_, err := q.db.Exec(ctx, updateUserPassword, arg.ID, arg.PasswordHash, arg.MustChangePassword)
 	return err
 }
@@ -0,0 +1,240 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"golang.org/x/crypto/bcrypt"
+
+	"github.com/choiexe1/hongik-academy/internal/db/sqlc"
+	"github.com/choiexe1/hongik-academy/internal/loginguard"
+	"github.com/choiexe1/hongik-academy/internal/middleware"
+	"github.com/choiexe1/hongik-academy/internal/passwordpolicy"
+	"github.com/choiexe1/hongik-academy/internal/usersession"
+)
+
+// AccountHandler serves the pages where a user manages their own account
+type AccountHandler struct {
+	pool    *pgxpool.Pool
+	queries *sqlc.Queries
+}
+
+func NewAccountHandler(pool *pgxpool.Pool, queries *sqlc.Queries) *AccountHandler {
+	return &AccountHandler{pool: pool, queries: queries}
+}
+
+// sessionView is one row of the "my sessions" list
+type sessionView struct {
+	ID         int32
+	Device     string
+	UserAgent  string
+	IPAddress  string
+	CreatedAt  pgtype.Timestamptz
+	LastSeenAt pgtype.Timestamptz
+	Current    bool
+}
+
+// ListSessions shows the devices the user is logged in on
+func (h *AccountHandler) ListSessions(c *gin.Context) {
+	h.renderSessions(c, http.StatusOK, "")
+}
+
+// RevokeSession logs out one of the user's other devices
+func (h *AccountHandler) RevokeSession(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 32)
+	if err != nil {
+		c.Redirect(http.StatusFound, "/account/sessions")
+		return
+	}
+
+	// 현재 세션은 로그아웃으로 종료
+	if int32(id) == c.GetInt32(middleware.SessionIDKey) {
+		h.renderSessions(c, http.StatusBadRequest, "현재 사용 중인 세션은 로그아웃으로 종료해주세요.")
+		return
+	}
+
+	h.queries.DeleteUserSession(c.Request.Context(), sqlc.DeleteUserSessionParams{
+		ID:     int32(id),
+		UserID: sessionUserID(c),
+	})
+
+	c.Redirect(http.StatusFound, "/account/sessions")
+}
+
+// RevokeOtherSessions logs out every device except the current one
+func (h *AccountHandler) RevokeOtherSessions(c *gin.Context) {
+	err := h.queries.DeleteOtherUserSessions(c.Request.Context(), sqlc.DeleteOtherUserSessionsParams{
+		UserID: sessionUserID(c),
+		ID:     c.GetInt32(middleware.SessionIDKey),
+	})
+	if err != nil {
+		h.renderSessions(c, http.StatusInternalServerError, "세션 종료에 실패했습니다.")
+		return
+	}
+
+	c.Redirect(http.StatusFound, "/account/sessions")
+}
+
+func (h *AccountHandler) renderSessions(c *gin.Context, status int, errMsg string) {
+	ctx := c.Request.Context()
+	userID := sessionUserID(c)
+
+	rows, err := h.queries.ListActiveUserSessions(ctx, userID)
+	if err != nil {
+		c.HTML(http.StatusInternalServerError, "error.html", gin.H{
+			"error": "세션 목록을 불러오는데 실패했습니다.",
+		})
+		return
+	}
+	maxSessions, _ := h.queries.GetUserMaxSessions(ctx, userID)
+
+	currentID := c.GetInt32(middleware.SessionIDKey)
+	views := make([]sessionView, 0, len(rows))
+	for _, s := range rows {
+		views = append(views, sessionView{
+			ID:         s.ID,
+			Device:     usersession.Describe(s.UserAgent),
+			UserAgent:  s.UserAgent,
+			IPAddress:  s.IpAddress,
+			CreatedAt:  s.CreatedAt,
+			LastSeenAt: s.LastSeenAt,
+			Current:    s.ID == currentID,
+		})
+	}
+
+	renderPage(c, status, "account_sessions.html", gin.H{
+		"sessions":    views,
+		"maxSessions": maxSessions,
+		"error":       errMsg,
+		"currentPage": "account",
+	})
+}
+
+// ShowPasswordForm shows the "change my password" form
+func (h *AccountHandler) ShowPasswordForm(c *gin.Context) {
+	h.renderPassword(c, http.StatusOK, "", c.Query("changed") == "1")
+}
+
+// ChangePassword lets a user replace their own password after confirming the current one,
+// which is limited like the login form. Other devices are logged out, and a forced change is
+// cleared.
+func (h *AccountHandler) ChangePassword(c *gin.Context) {
+	ctx := c.Request.Context()
+	currentPassword := c.PostForm("current_password")
+	newPassword := c.PostForm("new_password")
+	confirmPassword := c.PostForm("confirm_password")
+
+	user, err := h.queries.GetUserByID(ctx, sessionUserID(c))
+	if err != nil {
+		c.HTML(http.StatusInternalServerError, "error.html", gin.H{
+			"error": "사용자 정보를 불러오는데 실패했습니다.",
+		})
+		return
+	}
+
+	ok, lockedMsg, err := h.limitedCheck(ctx, user, func() (bool, error) {
+		return bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(currentPassword)) == nil, nil
+	})
+	if err != nil {
+		h.renderPassword(c, http.StatusInternalServerError, "비밀번호 처리 중 오류가 발생했습니다.", false)
+		return
+	}
+	if lockedMsg != "" {
+		h.renderPassword(c, http.StatusForbidden, lockedMsg, false)
+		return
+	}
+	if !ok {
+		h.renderPassword(c, http.StatusBadRequest, "현재 비밀번호가 올바르지 않습니다.", false)
+		return
+	}
+	if newPassword != confirmPassword {
+		h.renderPassword(c, http.StatusBadRequest, "새 비밀번호가 서로 일치하지 않습니다.", false)
+		return
+	}
+	if errMsg := passwordpolicy.Check(newPassword, user.Username); errMsg != "" {
+		h.renderPassword(c, http.StatusBadRequest, errMsg, false)
+		return
+	}
+	if newPassword == currentPassword {
+		h.renderPassword(c, http.StatusBadRequest, "현재 비밀번호와 다른 비밀번호를 입력해주세요.", false)
+		return
+	}
+
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(newPassword), bcrypt.DefaultCost)
+	if err != nil {
+		h.renderPassword(c, http.StatusInternalServerError, "비밀번호 처리 중 오류가 발생했습니다.", false)
+		return
+	}
+	if err := h.queries.UpdateUserPassword(ctx, sqlc.UpdateUserPasswordParams{
+		ID:           user.ID,
+		PasswordHash: string(hashedPassword),
+	}); err != nil {
+		h.renderPassword(c, http.StatusInternalServerError, "비밀번호 변경에 실패했습니다.", false)
+		return
+	}
+
+	// 비밀번호가 새어 바꾸는 경우를 위해 다른 기기의 로그인은 종료
+	h.queries.DeleteOtherUserSessions(ctx, sqlc.DeleteOtherUserSessionsParams{
+		UserID: user.ID,
+		ID:     c.GetInt32(middleware.SessionIDKey),
+	})
+
+	snapshot := sqlc.ListUsersRow{ID: user.ID, Username: user.Username, Name: user.Name, Role: user.Role, CreatedAt: user.CreatedAt}
+	recordAudit(ctx, h.queries, user.ID, auditActionUpdate, auditEntityUser, user.ID,
+		auditUser{ListUsersRow: snapshot}, auditUser{ListUsersRow: snapshot, PasswordChanged: true})
+
+	// 변경을 요구받아 온 경우에는 원래 하려던 일로 돌아감
+	if user.MustChangePassword {
+		c.Redirect(http.StatusFound, "/dashboard")
+		return
+	}
+	c.Redirect(http.StatusFound, middleware.PasswordChangePath+"?changed=1")
+}
+
+func (h *AccountHandler) renderPassword(c *gin.Context, status int, errMsg string, changed bool) {
+	renderPage(c, status, "account_password.html", gin.H{
+		"mustChange":  c.GetBool(middleware.MustChangePasswordKey),
+		"policy":      passwordpolicy.Summary,
+		"minLength":   passwordpolicy.MinLength,
+		"changed":     changed,
+		"error":       errMsg,
+		"currentPage": "account",
+	})
+}
+
+// limitedCheck runs check, which compares a current password or 2FA code, under the same
+// failure counter and lock as the login form, so a stolen session cannot be used to guess
+// either one freely. lockedMsg is set when the account is or becomes locked. A passing check
+// only takes back its own count; resetting the count is left to a full login.
+func (h *AccountHandler) limitedCheck(ctx context.Context, user sqlc.GetUserByIDRow, check func() (bool, error)) (ok bool, lockedMsg string, err error) {
+	attempt, reserved, err := reserveAttempt(ctx, h.queries, user.ID, user.FailedLoginCount)
+	if err != nil {
+		return false, "", err
+	}
+	if !reserved {
+		return false, lockedMessage(lockedUntil(ctx, h.queries, user.ID)), nil
+	}
+
+	ok, err = check()
+	if err != nil {
+		return false, "", err
+	}
+	if ok {
+		err = h.queries.ReleaseLoginAttempt(ctx, sqlc.ReleaseLoginAttemptParams{
+			ID:               user.ID,
+			FailedLoginCount: attempt.FailedLoginCount,
+			LockedUntil:      user.LockedUntil,
+		})
+		return err == nil, "", err
+	}
+
+	if attempt.FailedLoginCount >= loginguard.MaxFailures {
+		return false, lockedMessage(attempt.LockedUntil.Time), nil
+	}
+	loginguard.Wait(ctx, loginguard.Delay(int64(attempt.FailedLoginCount)))
+	return false, "", nil
+}
@@ -0,0 +1,93 @@
+package handlers
+
+import (
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// apiError writes the JSON error envelope used by every /api/v1 endpoint:
+//
+//	{"error": {"code": "not_found", "message": "원생을 찾을 수 없습니다."}}
+func apiError(c *gin.Context, status int, code, message string) {
+	c.AbortWithStatusJSON(status, gin.H{
+		"error": gin.H{
+			"code":    code,
+			"message": message,
+		},
+	})
+}
+
+// apiData writes a single resource as {"data": ...}
+func apiData(c *gin.Context, status int, data interface{}) {
+	c.JSON(status, gin.H{"data": data})
+}
+
+// apiList writes a page of resources as {"data": [...], "meta": {...}}
+func apiList(c *gin.Context, data interface{}, p apiPage, totalCount int64) {
+	totalPages := int(math.Ceil(float64(totalCount) / float64(p.PerPage)))
+	c.JSON(http.StatusOK, gin.H{
+		"data": data,
+		"meta": gin.H{
+			"page":        p.Page,
+			"per_page":    p.PerPage,
+			"total_count": totalCount,
+			"total_pages": totalPages,
+		},
+	})
+}
+
+type apiPage struct {
+	Page    int
+	PerPage int
+}
+
+func (p apiPage) Limit() int32  { return int32(p.PerPage) }
+func (p apiPage) Offset() int32 { return int32((p.Page - 1) * p.PerPage) }
+
+// parseAPIPage reads page and the page size param (per_page for students, limit for
+// evaluations) with the same defaults and allowed sizes as the HTML lists
+func parseAPIPage(c *gin.Context, sizeParam string) apiPage {
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	if page < 1 {
+		page = 1
+	}
+	perPage, _ := strconv.Atoi(c.DefaultQuery(sizeParam, "10"))
+	if perPage != 10 && perPage != 20 && perPage != 50 {
+		perPage = 10
+	}
+	return apiPage{Page: page, PerPage: perPage}
+}
+
+// parseAPIID parses an int32 path parameter, writing a 400 error when it is malformed
+func parseAPIID(c *gin.Context, name string) (int32, bool) {
+	id, err := strconv.ParseInt(c.Param(name), 10, 32)
+	if err != nil {
+		apiError(c, http.StatusBadRequest, "invalid_id", "잘못된 ID입니다.")
+		return 0, false
+	}
+	return int32(id), true
+}
+
+// parseAPIDate parses an optional YYYY-MM-DD query parameter, writing a 400 error when it is malformed
+func parseAPIDate(c *gin.Context, name string) (pgtype.Date, bool) {
+	value := c.Query(name)
+	if value == "" {
+		return pgtype.Date{}, true
+	}
+	t, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		apiError(c, http.StatusBadRequest, "invalid_date", name+" 값은 YYYY-MM-DD 형식이어야 합니다.")
+		return pgtype.Date{}, false
+	}
+	return pgtype.Date{Time: t, Valid: true}, true
+}
+
+// apiUser returns the authenticated user set by middleware.APIAuthRequired
+func apiUser(c *gin.Context) (int32, string) {
+	return c.GetInt32("user_id"), c.GetString("role")
+}
@@ -0,0 +1,201 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/choiexe1/hongik-academy/internal/db/sqlc"
+)
+
+// EvaluationAPIHandler's single-evaluation routes run behind middleware.APIEvaluationAccess,
+// which puts the checked evaluation in the context
+type EvaluationAPIHandler struct {
+	pool    *pgxpool.Pool
+	queries *sqlc.Queries
+}
+
+func NewEvaluationAPIHandler(pool *pgxpool.Pool, queries *sqlc.Queries) *EvaluationAPIHandler {
+	return &EvaluationAPIHandler{pool: pool, queries: queries}
+}
+
+// ListEvaluations supports the same search, start_date, end_date, page and limit params as
+// /students/:id/evaluations
+func (h *EvaluationAPIHandler) ListEvaluations(c *gin.Context) {
+	studentID, ok := parseAPIID(c, "id")
+	if !ok {
+		return
+	}
+
+	if _, err := h.queries.GetStudentByID(c.Request.Context(), studentID); err != nil {
+		respondStudentLookupError(c, err)
+		return
+	}
+
+	p := parseAPIPage(c, "limit")
+
+	searchParam := pgtype.Text{Valid: false}
+	if search := c.Query("search"); search != "" {
+		searchParam = pgtype.Text{String: search, Valid: true}
+	}
+	startDateParam, ok := parseAPIDate(c, "start_date")
+	if !ok {
+		return
+	}
+	endDateParam, ok := parseAPIDate(c, "end_date")
+	if !ok {
+		return
+	}
+
+	totalCount, err := h.queries.CountEvaluationsByStudent(c.Request.Context(), sqlc.CountEvaluationsByStudentParams{
+		StudentID: studentID,
+		Search:    searchParam,
+		StartDate: startDateParam,
+		EndDate:   endDateParam,
+	})
+	if err != nil {
+		apiError(c, http.StatusInternalServerError, "internal_error", "평가표 개수를 불러오는데 실패했습니다.")
+		return
+	}
+
+	evaluations, err := h.queries.ListEvaluationsByStudent(c.Request.Context(), sqlc.ListEvaluationsByStudentParams{
+		StudentID: studentID,
+		Limit:     p.Limit(),
+		Offset:    p.Offset(),
+		Search:    searchParam,
+		StartDate: startDateParam,
+		EndDate:   endDateParam,
+	})
+	if err != nil {
+		apiError(c, http.StatusInternalServerError, "internal_error", "평가표 목록을 불러오는데 실패했습니다.")
+		return
+	}
+	if evaluations == nil {
+		evaluations = []sqlc.ListEvaluationsByStudentRow{}
+	}
+
+	apiList(c, evaluations, p, totalCount)
+}
+
+func (h *EvaluationAPIHandler) GetEvaluation(c *gin.Context) {
+	evaluation := c.MustGet("evaluation").(sqlc.GetEvaluationByIDRow)
+
+	apiData(c, http.StatusOK, evaluation)
+}
+
+// CreateEvaluation takes a CreateEvaluationParams body; student_id and author_id come from
+// the path and the authenticated user, so only content is read from the body
+func (h *EvaluationAPIHandler) CreateEvaluation(c *gin.Context) {
+	studentID, ok := parseAPIID(c, "id")
+	if !ok {
+		return
+	}
+	userID, _ := apiUser(c)
+
+	if _, err := h.queries.GetStudentByID(c.Request.Context(), studentID); err != nil {
+		respondStudentLookupError(c, err)
+		return
+	}
+
+	var params sqlc.CreateEvaluationParams
+	if err := c.ShouldBindJSON(&params); err != nil {
+		apiError(c, http.StatusBadRequest, "invalid_body", "요청 본문이 올바른 JSON이 아닙니다.")
+		return
+	}
+	params.StudentID = studentID
+	params.AuthorID = userID
+
+	if params.Content == "" {
+		apiError(c, http.StatusUnprocessableEntity, "invalid_content", "평가 내용을 입력해주세요.")
+		return
+	}
+
+	ctx := c.Request.Context()
+	evaluation, err := func() (sqlc.Evaluation, error) {
+		tx, err := h.pool.Begin(ctx)
+		if err != nil {
+			return sqlc.Evaluation{}, err
+		}
+		defer tx.Rollback(ctx)
+		qtx := h.queries.WithTx(tx)
+
+		evaluation, err := qtx.CreateEvaluation(ctx, params)
+		if err != nil {
+			return sqlc.Evaluation{}, err
+		}
+		if err := saveEvaluationRevision(ctx, qtx, evaluation, userID); err != nil {
+			return sqlc.Evaluation{}, err
+		}
+		return evaluation, tx.Commit(ctx)
+	}()
+	if err != nil {
+		apiError(c, http.StatusInternalServerError, "internal_error", "평가표 저장에 실패했습니다.")
+		return
+	}
+
+	recordAudit(ctx, h.queries, userID, auditActionCreate, auditEntityEvaluation, evaluation.ID, nil, evaluation)
+
+	apiData(c, http.StatusCreated, evaluation)
+}
+
+func (h *EvaluationAPIHandler) UpdateEvaluation(c *gin.Context) {
+	evaluation := c.MustGet("evaluation").(sqlc.GetEvaluationByIDRow)
+
+	var params sqlc.UpdateEvaluationParams
+	if err := c.ShouldBindJSON(&params); err != nil {
+		apiError(c, http.StatusBadRequest, "invalid_body", "요청 본문이 올바른 JSON이 아닙니다.")
+		return
+	}
+	params.ID = evaluation.ID
+
+	if params.Content == "" {
+		apiError(c, http.StatusUnprocessableEntity, "invalid_content", "평가 내용을 입력해주세요.")
+		return
+	}
+
+	userID, _ := apiUser(c)
+	ctx := c.Request.Context()
+	updated, err := func() (sqlc.Evaluation, error) {
+		tx, err := h.pool.Begin(ctx)
+		if err != nil {
+			return sqlc.Evaluation{}, err
+		}
+		defer tx.Rollback(ctx)
+		qtx := h.queries.WithTx(tx)
+
+		updated, err := qtx.UpdateEvaluation(ctx, params)
+		if err != nil {
+			return sqlc.Evaluation{}, err
+		}
+		if updated.Content != evaluation.Content {
+			if err := saveEvaluationRevision(ctx, qtx, updated, userID); err != nil {
+				return sqlc.Evaluation{}, err
+			}
+		}
+		return updated, tx.Commit(ctx)
+	}()
+	if err != nil {
+		apiError(c, http.StatusInternalServerError, "internal_error", "평가표 수정에 실패했습니다.")
+		return
+	}
+
+	recordAudit(ctx, h.queries, userID, auditActionUpdate, auditEntityEvaluation, updated.ID, auditEvaluation(evaluation), updated)
+
+	apiData(c, http.StatusOK, updated)
+}
+
+func (h *EvaluationAPIHandler) DeleteEvaluation(c *gin.Context) {
+	evaluation := c.MustGet("evaluation").(sqlc.GetEvaluationByIDRow)
+
+	if err := h.queries.DeleteEvaluation(c.Request.Context(), evaluation.ID); err != nil {
+		apiError(c, http.StatusInternalServerError, "internal_error", "평가표 삭제에 실패했습니다.")
+		return
+	}
+
+	userID, _ := apiUser(c)
+	recordAudit(c.Request.Context(), h.queries, userID, auditActionDelete, auditEntityEvaluation, evaluation.ID, auditEvaluation(evaluation), nil)
+
+	c.Status(http.StatusNoContent)
+}
@@ -0,0 +1,210 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/choiexe1/hongik-academy/internal/db/sqlc"
+)
+
+type StudentAPIHandler struct {
+	pool    *pgxpool.Pool
+	queries *sqlc.Queries
+}
+
+func NewStudentAPIHandler(pool *pgxpool.Pool, queries *sqlc.Queries) *StudentAPIHandler {
+	return &StudentAPIHandler{pool: pool, queries: queries}
+}
+
+// ListStudents supports the same search, gender, class_id, status, page and per_page params as /students
+func (h *StudentAPIHandler) ListStudents(c *gin.Context) {
+	p := parseAPIPage(c, "per_page")
+
+	searchParam := pgtype.Text{Valid: false}
+	if search := c.Query("search"); search != "" {
+		searchParam = pgtype.Text{String: search, Valid: true}
+	}
+	genderParam := pgtype.Text{Valid: false}
+	if gender := c.Query("gender"); gender != "" {
+		if gender != "M" && gender != "F" {
+			apiError(c, http.StatusBadRequest, "invalid_gender", "gender 값은 M 또는 F여야 합니다.")
+			return
+		}
+		genderParam = pgtype.Text{String: gender, Valid: true}
+	}
+	classParam := pgtype.Int4{Valid: false}
+	if classID := c.Query("class_id"); classID != "" {
+		id, err := strconv.ParseInt(classID, 10, 32)
+		if err != nil {
+			apiError(c, http.StatusBadRequest, "invalid_class_id", "class_id 값이 올바르지 않습니다.")
+			return
+		}
+		classParam = pgtype.Int4{Int32: int32(id), Valid: true}
+	}
+	status := c.Query("status")
+	if status != "" && status != "all" && !isValidStudentStatus(status) {
+		apiError(c, http.StatusBadRequest, "invalid_status", "status 값은 enrolled, on_leave, graduated, withdrawn, all 중 하나여야 합니다.")
+		return
+	}
+	_, statuses := studentStatusFilter(status)
+
+	totalCount, err := h.queries.CountStudents(c.Request.Context(), sqlc.CountStudentsParams{
+		Search:   searchParam,
+		Gender:   genderParam,
+		ClassID:  classParam,
+		Statuses: statuses,
+	})
+	if err != nil {
+		apiError(c, http.StatusInternalServerError, "internal_error", "원생 수를 조회하는데 실패했습니다.")
+		return
+	}
+
+	students, err := h.queries.ListStudents(c.Request.Context(), sqlc.ListStudentsParams{
+		Limit:    p.Limit(),
+		Offset:   p.Offset(),
+		Search:   searchParam,
+		Gender:   genderParam,
+		ClassID:  classParam,
+		Statuses: statuses,
+	})
+	if err != nil {
+		apiError(c, http.StatusInternalServerError, "internal_error", "원생 목록을 불러오는데 실패했습니다.")
+		return
+	}
+	if students == nil {
+		students = []sqlc.Student{}
+	}
+
+	apiList(c, students, p, totalCount)
+}
+
+func (h *StudentAPIHandler) GetStudent(c *gin.Context) {
+	id, ok := parseAPIID(c, "id")
+	if !ok {
+		return
+	}
+
+	student, err := h.queries.GetStudentByID(c.Request.Context(), id)
+	if err != nil {
+		respondStudentLookupError(c, err)
+		return
+	}
+
+	apiData(c, http.StatusOK, student)
+}
+
+func (h *StudentAPIHandler) CreateStudent(c *gin.Context) {
+	var params sqlc.CreateStudentParams
+	if err := c.ShouldBindJSON(&params); err != nil {
+		apiError(c, http.StatusBadRequest, "invalid_body", "요청 본문이 올바른 JSON이 아닙니다.")
+		return
+	}
+
+	params.Phone, params.ParentPhone = sanitizePhoneText(params.Phone), sanitizePhoneText(params.ParentPhone)
+	if code, message := validateStudentInput(params.Name, params.Gender); code != "" {
+		apiError(c, http.StatusUnprocessableEntity, code, message)
+		return
+	}
+
+	userID, _ := apiUser(c)
+	student, err := createStudent(c.Request.Context(), h.pool, h.queries, params, userID)
+	if err != nil {
+		apiError(c, http.StatusInternalServerError, "internal_error", "원생 등록에 실패했습니다.")
+		return
+	}
+
+	recordAudit(c.Request.Context(), h.queries, userID, auditActionCreate, auditEntityStudent, student.ID, nil, student)
+
+	apiData(c, http.StatusCreated, student)
+}
+
+func (h *StudentAPIHandler) UpdateStudent(c *gin.Context) {
+	id, ok := parseAPIID(c, "id")
+	if !ok {
+		return
+	}
+
+	var params sqlc.UpdateStudentParams
+	if err := c.ShouldBindJSON(&params); err != nil {
+		apiError(c, http.StatusBadRequest, "invalid_body", "요청 본문이 올바른 JSON이 아닙니다.")
+		return
+	}
+	// 경로의 ID가 본문보다 우선
+	params.ID = id
+
+	params.Phone, params.ParentPhone = sanitizePhoneText(params.Phone), sanitizePhoneText(params.ParentPhone)
+	if code, message := validateStudentInput(params.Name, params.Gender); code != "" {
+		apiError(c, http.StatusUnprocessableEntity, code, message)
+		return
+	}
+
+	before, err := h.queries.GetStudentByID(c.Request.Context(), id)
+	if err != nil {
+		respondStudentLookupError(c, err)
+		return
+	}
+
+	student, err := h.queries.UpdateStudent(c.Request.Context(), params)
+	if err != nil {
+		respondStudentLookupError(c, err)
+		return
+	}
+
+	userID, _ := apiUser(c)
+	recordAudit(c.Request.Context(), h.queries, userID, auditActionUpdate, auditEntityStudent, student.ID, before, student)
+
+	apiData(c, http.StatusOK, student)
+}
+
+func (h *StudentAPIHandler) DeleteStudent(c *gin.Context) {
+	id, ok := parseAPIID(c, "id")
+	if !ok {
+		return
+	}
+
+	before, err := h.queries.GetStudentByID(c.Request.Context(), id)
+	if err != nil {
+		respondStudentLookupError(c, err)
+		return
+	}
+
+	if err := h.queries.DeleteStudent(c.Request.Context(), id); err != nil {
+		apiError(c, http.StatusInternalServerError, "internal_error", "원생 삭제에 실패했습니다.")
+		return
+	}
+
+	userID, _ := apiUser(c)
+	recordAudit(c.Request.Context(), h.queries, userID, auditActionDelete, auditEntityStudent, before.ID, before, nil)
+
+	c.Status(http.StatusNoContent)
+}
+
+// validateStudentInput applies the same rules as the student form
+func validateStudentInput(name, gender string) (string, string) {
+	if name == "" {
+		return "invalid_name", "이름을 입력해주세요."
+	}
+	if gender != "M" && gender != "F" {
+		return "invalid_gender", "성별을 선택해주세요."
+	}
+	return "", ""
+}
+
+func sanitizePhoneText(phone pgtype.Text) pgtype.Text {
+	digits := sanitizePhone(phone.String)
+	return pgtype.Text{String: digits, Valid: digits != ""}
+}
+
+func respondStudentLookupError(c *gin.Context, err error) {
+	if errors.Is(err, pgx.ErrNoRows) {
+		apiError(c, http.StatusNotFound, "not_found", "원생을 찾을 수 없습니다.")
+		return
+	}
+	apiError(c, http.StatusInternalServerError, "internal_error", "원생 정보를 처리하는데 실패했습니다.")
+}
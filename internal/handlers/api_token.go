@@ -0,0 +1,173 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/choiexe1/hongik-academy/internal/apitoken"
+	"github.com/choiexe1/hongik-academy/internal/db/sqlc"
+	"github.com/choiexe1/hongik-academy/internal/permission"
+)
+
+// apiTokenExpiryDays lists the selectable token lifetimes; 0 means the token never expires
+var apiTokenExpiryDays = []int{30, 90, 365, 0}
+
+type APITokenHandler struct {
+	queries *sqlc.Queries
+}
+
+func NewAPITokenHandler(queries *sqlc.Queries) *APITokenHandler {
+	return &APITokenHandler{queries: queries}
+}
+
+// ListTokens renders the API tokens of a user
+func (h *APITokenHandler) ListTokens(c *gin.Context) {
+	user, ok := h.loadTargetUser(c)
+	if !ok {
+		return
+	}
+
+	h.renderTokens(c, http.StatusOK, user, "", "")
+}
+
+// CreateToken issues a new token and shows its plain value once; only the hash is stored
+func (h *APITokenHandler) CreateToken(c *gin.Context) {
+	user, ok := h.loadTargetUser(c)
+	if !ok {
+		return
+	}
+
+	name := c.PostForm("name")
+	if name == "" {
+		h.renderTokens(c, http.StatusBadRequest, user, "", "토큰 이름을 입력해주세요.")
+		return
+	}
+	if len([]rune(name)) > 100 {
+		h.renderTokens(c, http.StatusBadRequest, user, "", "토큰 이름은 100자 이하로 입력해주세요.")
+		return
+	}
+
+	expiresAt := pgtype.Timestamptz{Valid: false}
+	days, err := strconv.Atoi(c.PostForm("expires_in_days"))
+	if err != nil || !isValidTokenExpiry(days) {
+		h.renderTokens(c, http.StatusBadRequest, user, "", "만료 기간을 선택해주세요.")
+		return
+	}
+	if days > 0 {
+		expiresAt = pgtype.Timestamptz{Time: time.Now().AddDate(0, 0, days), Valid: true}
+	}
+
+	token, display, hash, err := apitoken.Generate()
+	if err != nil {
+		h.renderTokens(c, http.StatusInternalServerError, user, "", "토큰 생성 중 오류가 발생했습니다.")
+		return
+	}
+
+	created, err := h.queries.CreateApiToken(c.Request.Context(), sqlc.CreateApiTokenParams{
+		UserID:      user.ID,
+		Name:        name,
+		TokenHash:   hash,
+		TokenPrefix: display,
+		ExpiresAt:   expiresAt,
+	})
+	if err != nil {
+		h.renderTokens(c, http.StatusInternalServerError, user, "", "토큰 저장에 실패했습니다.")
+		return
+	}
+
+	recordAudit(c.Request.Context(), h.queries, sessionUserID(c), auditActionCreate, auditEntityAPIToken, created.ID, nil, auditAPIToken(created))
+
+	h.renderTokens(c, http.StatusOK, user, token, "")
+}
+
+func (h *APITokenHandler) RevokeToken(c *gin.Context) {
+	user, ok := h.loadTargetUser(c)
+	if !ok {
+		return
+	}
+
+	tokenID, err := strconv.ParseInt(c.Param("token_id"), 10, 32)
+	if err != nil {
+		c.Redirect(http.StatusFound, "/admin/users/"+c.Param("id")+"/tokens")
+		return
+	}
+
+	revoked, err := h.queries.RevokeApiToken(c.Request.Context(), sqlc.RevokeApiTokenParams{
+		ID:     int32(tokenID),
+		UserID: user.ID,
+	})
+	// 이미 폐기된 토큰이면 행이 없으므로 기록하지 않음
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		c.HTML(http.StatusInternalServerError, "error.html", gin.H{
+			"error": "토큰 폐기에 실패했습니다.",
+		})
+		return
+	}
+	if err == nil {
+		before := auditAPIToken(revoked)
+		before.RevokedAt = pgtype.Timestamptz{}
+		recordAudit(c.Request.Context(), h.queries, sessionUserID(c), auditActionUpdate, auditEntityAPIToken, revoked.ID, before, auditAPIToken(revoked))
+	}
+
+	c.Redirect(http.StatusFound, "/admin/users/"+c.Param("id")+"/tokens")
+}
+
+// loadTargetUser resolves :id with the same rule as editing a user: without users.manage
+// only one's own tokens may be managed
+func (h *APITokenHandler) loadTargetUser(c *gin.Context) (sqlc.GetUserByIDRow, bool) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 32)
+	if err != nil {
+		c.Redirect(http.StatusFound, "/admin/users")
+		return sqlc.GetUserByIDRow{}, false
+	}
+
+	user, err := h.queries.GetUserByID(c.Request.Context(), int32(id))
+	if err != nil {
+		c.Redirect(http.StatusFound, "/admin/users")
+		return sqlc.GetUserByIDRow{}, false
+	}
+
+	if !canManageUser(sessionUserID(c), c.GetString("role"), permission.FromContext(c), user.ID, user.Role) {
+		c.HTML(http.StatusForbidden, "error.html", gin.H{
+			"error": "다른 관리자의 API 토큰을 관리할 권한이 없습니다.",
+		})
+		return sqlc.GetUserByIDRow{}, false
+	}
+
+	return user, true
+}
+
+func (h *APITokenHandler) renderTokens(c *gin.Context, status int, user sqlc.GetUserByIDRow, newToken, errMsg string) {
+	tokens, err := h.queries.ListApiTokensByUser(c.Request.Context(), user.ID)
+	if err != nil {
+		c.HTML(http.StatusInternalServerError, "error.html", gin.H{
+			"error": "API 토큰 목록을 불러오는데 실패했습니다.",
+		})
+		return
+	}
+
+	renderPage(c, status, "user_tokens.html", gin.H{
+		"user":        user,
+		"tokens":      tokens,
+		"newToken":    newToken,
+		"expiryDays":  apiTokenExpiryDays,
+		"now":         time.Now(),
+		"error":       errMsg,
+		"currentPage": "users",
+	})
+}
+
+func isValidTokenExpiry(days int) bool {
+	for _, d := range apiTokenExpiryDays {
+		if d == days {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,254 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"golang.org/x/crypto/bcrypt"
+
+	"github.com/choiexe1/hongik-academy/internal/db/sqlc"
+	"github.com/choiexe1/hongik-academy/internal/passwordpolicy"
+	"github.com/choiexe1/hongik-academy/internal/permission"
+)
+
+// userAPIRequest is the body for creating and updating users. The sqlc params carry a
+// password hash, so the plain password is accepted here and hashed before storing.
+type userAPIRequest struct {
+	Username string `json:"username"`
+	Name     string `json:"name"`
+	Password string `json:"password"`
+	Role     string `json:"role"`
+}
+
+type UserAPIHandler struct {
+	pool    *pgxpool.Pool
+	queries *sqlc.Queries
+}
+
+func NewUserAPIHandler(pool *pgxpool.Pool, queries *sqlc.Queries) *UserAPIHandler {
+	return &UserAPIHandler{pool: pool, queries: queries}
+}
+
+// ListUsers returns every user; password hashes are never included in API responses
+func (h *UserAPIHandler) ListUsers(c *gin.Context) {
+	users, err := h.queries.ListUsers(c.Request.Context())
+	if err != nil {
+		apiError(c, http.StatusInternalServerError, "internal_error", "사용자 목록을 불러오는데 실패했습니다.")
+		return
+	}
+	if users == nil {
+		users = []sqlc.ListUsersRow{}
+	}
+
+	apiData(c, http.StatusOK, users)
+}
+
+func (h *UserAPIHandler) GetUser(c *gin.Context) {
+	id, ok := parseAPIID(c, "id")
+	if !ok {
+		return
+	}
+
+	user, err := h.queries.GetUserByID(c.Request.Context(), id)
+	if err != nil {
+		respondUserLookupError(c, err)
+		return
+	}
+
+	apiData(c, http.StatusOK, sqlc.ListUsersRow{
+		ID:        user.ID,
+		Username:  user.Username,
+		Name:      user.Name,
+		Role:      user.Role,
+		CreatedAt: user.CreatedAt,
+	})
+}
+
+func (h *UserAPIHandler) CreateUser(c *gin.Context) {
+	currentUserID, currentRole := apiUser(c)
+
+	var req userAPIRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apiError(c, http.StatusBadRequest, "invalid_body", "요청 본문이 올바른 JSON이 아닙니다.")
+		return
+	}
+
+	if req.Username == "" || req.Name == "" || req.Password == "" {
+		apiError(c, http.StatusUnprocessableEntity, "invalid_user", "아이디, 이름, 비밀번호는 필수입니다.")
+		return
+	}
+
+	if errMsg := passwordpolicy.Check(req.Password, req.Username); errMsg != "" {
+		apiError(c, http.StatusUnprocessableEntity, "invalid_password", errMsg)
+		return
+	}
+
+	// 역할을 생략하면 기존과 같이 일반관리자로 등록
+	if req.Role == "" {
+		req.Role = "admin"
+	}
+	if errMsg := checkAssignableRole(c.Request.Context(), h.queries, req.Role, currentRole, permission.FromContext(c)); errMsg != "" {
+		apiError(c, http.StatusForbidden, "forbidden", errMsg)
+		return
+	}
+
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(req.Password), bcrypt.DefaultCost)
+	if err != nil {
+		apiError(c, http.StatusInternalServerError, "internal_error", "비밀번호 처리 중 오류가 발생했습니다.")
+		return
+	}
+
+	user, err := h.queries.CreateUser(c.Request.Context(), sqlc.CreateUserParams{
+		Username:     req.Username,
+		Name:         req.Name,
+		PasswordHash: string(hashedPassword),
+		Role:         req.Role,
+	})
+	if err != nil {
+		apiError(c, http.StatusConflict, "conflict", "사용자 등록에 실패했습니다. 아이디가 중복되었을 수 있습니다.")
+		return
+	}
+
+	created := sqlc.ListUsersRow{
+		ID:        user.ID,
+		Username:  user.Username,
+		Name:      user.Name,
+		Role:      user.Role,
+		CreatedAt: user.CreatedAt,
+	}
+	recordAudit(c.Request.Context(), h.queries, currentUserID, auditActionCreate, auditEntityUser, user.ID, nil, auditUser{ListUsersRow: created})
+
+	apiData(c, http.StatusCreated, created)
+}
+
+// UpdateUser follows the same rules as the admin form: editing others needs users.manage,
+// nobody can change their own role, and a super_admin keeps that role
+func (h *UserAPIHandler) UpdateUser(c *gin.Context) {
+	currentUserID, currentRole := apiUser(c)
+
+	id, ok := parseAPIID(c, "id")
+	if !ok {
+		return
+	}
+
+	targetUser, err := h.queries.GetUserByID(c.Request.Context(), id)
+	if err != nil {
+		respondUserLookupError(c, err)
+		return
+	}
+
+	if !canManageUser(currentUserID, currentRole, permission.FromContext(c), targetUser.ID, targetUser.Role) {
+		apiError(c, http.StatusForbidden, "forbidden", "다른 관리자를 수정할 권한이 없습니다.")
+		return
+	}
+
+	var req userAPIRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apiError(c, http.StatusBadRequest, "invalid_body", "요청 본문이 올바른 JSON이 아닙니다.")
+		return
+	}
+
+	if req.Name == "" {
+		apiError(c, http.StatusUnprocessableEntity, "invalid_user", "이름은 필수입니다.")
+		return
+	}
+
+	// 본인 비밀번호는 현재 비밀번호를 확인하는 /account/password에서만 변경
+	if req.Password != "" && id == currentUserID {
+		apiError(c, http.StatusForbidden, "forbidden", "본인 비밀번호는 /account/password에서 변경해주세요.")
+		return
+	}
+
+	if req.Password != "" {
+		if errMsg := passwordpolicy.Check(req.Password, targetUser.Username); errMsg != "" {
+			apiError(c, http.StatusUnprocessableEntity, "invalid_password", errMsg)
+			return
+		}
+	}
+
+	role := targetUser.Role
+	if canChangeUserRole(currentUserID, targetUser) && req.Role != "" && req.Role != targetUser.Role {
+		if errMsg := checkAssignableRole(c.Request.Context(), h.queries, req.Role, currentRole, permission.FromContext(c)); errMsg != "" {
+			apiError(c, http.StatusForbidden, "forbidden", errMsg)
+			return
+		}
+		role = req.Role
+	}
+
+	user, err := h.queries.UpdateUser(c.Request.Context(), sqlc.UpdateUserParams{
+		ID:   id,
+		Name: req.Name,
+		Role: role,
+	})
+	if err != nil {
+		apiError(c, http.StatusInternalServerError, "internal_error", "사용자 수정에 실패했습니다.")
+		return
+	}
+
+	if req.Password != "" {
+		if err := setUserPassword(c.Request.Context(), h.pool, h.queries, id, req.Password); err != nil {
+			apiError(c, http.StatusInternalServerError, "internal_error", "비밀번호 변경에 실패했습니다.")
+			return
+		}
+	}
+
+	updated := sqlc.ListUsersRow{
+		ID:        user.ID,
+		Username:  user.Username,
+		Name:      user.Name,
+		Role:      user.Role,
+		CreatedAt: user.CreatedAt,
+	}
+	recordAudit(c.Request.Context(), h.queries, currentUserID, auditActionUpdate, auditEntityUser, user.ID, auditUser{
+		ListUsersRow: sqlc.ListUsersRow{ID: targetUser.ID, Username: targetUser.Username, Name: targetUser.Name, Role: targetUser.Role, CreatedAt: targetUser.CreatedAt},
+	}, auditUser{ListUsersRow: updated, PasswordChanged: req.Password != ""})
+
+	apiData(c, http.StatusOK, updated)
+}
+
+func (h *UserAPIHandler) DeleteUser(c *gin.Context) {
+	currentUserID, currentRole := apiUser(c)
+
+	id, ok := parseAPIID(c, "id")
+	if !ok {
+		return
+	}
+
+	if currentUserID == id {
+		apiError(c, http.StatusForbidden, "forbidden", "자기 자신은 삭제할 수 없습니다.")
+		return
+	}
+
+	targetUser, err := h.queries.GetUserByID(c.Request.Context(), id)
+	if err != nil {
+		respondUserLookupError(c, err)
+		return
+	}
+
+	if !canDeleteUser(currentUserID, currentRole, permission.FromContext(c), targetUser.ID, targetUser.Role) {
+		apiError(c, http.StatusForbidden, "forbidden", "최고관리자 계정은 최고관리자만 삭제할 수 있습니다.")
+		return
+	}
+
+	if err := h.queries.DeleteUser(c.Request.Context(), id); err != nil {
+		apiError(c, http.StatusInternalServerError, "internal_error", "사용자 삭제에 실패했습니다.")
+		return
+	}
+
+	recordAudit(c.Request.Context(), h.queries, currentUserID, auditActionDelete, auditEntityUser, targetUser.ID, auditUser{
+		ListUsersRow: sqlc.ListUsersRow{ID: targetUser.ID, Username: targetUser.Username, Name: targetUser.Name, Role: targetUser.Role, CreatedAt: targetUser.CreatedAt},
+	}, nil)
+
+	c.Status(http.StatusNoContent)
+}
+
+func respondUserLookupError(c *gin.Context, err error) {
+	if errors.Is(err, pgx.ErrNoRows) {
+		apiError(c, http.StatusNotFound, "not_found", "사용자를 찾을 수 없습니다.")
+		return
+	}
+	apiError(c, http.StatusInternalServerError, "internal_error", "사용자 정보를 처리하는데 실패했습니다.")
+}
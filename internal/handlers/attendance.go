@@ -0,0 +1,310 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-contrib/sessions"
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/choiexe1/hongik-academy/internal/db/sqlc"
+)
+
+// attendanceStatuses lists the valid attendance statuses in display order
+var attendanceStatuses = []string{"present", "late", "absent", "excused"}
+
+func isValidAttendanceStatus(status string) bool {
+	for _, s := range attendanceStatuses {
+		if s == status {
+			return true
+		}
+	}
+	return false
+}
+
+type AttendanceHandler struct {
+	pool    *pgxpool.Pool
+	queries *sqlc.Queries
+}
+
+func NewAttendanceHandler(pool *pgxpool.Pool, queries *sqlc.Queries) *AttendanceHandler {
+	return &AttendanceHandler{pool: pool, queries: queries}
+}
+
+// ShowRollCall renders the daily roll-call page for every student
+func (h *AttendanceHandler) ShowRollCall(c *gin.Context) {
+	dateStr := c.DefaultQuery("date", time.Now().Format("2006-01-02"))
+	date, err := time.Parse("2006-01-02", dateStr)
+	if err != nil {
+		date, _ = time.Parse("2006-01-02", time.Now().Format("2006-01-02"))
+		dateStr = date.Format("2006-01-02")
+	}
+
+	students, err := h.queries.ListRollCall(c.Request.Context(), pgtype.Date{Time: date, Valid: true})
+	if err != nil {
+		c.HTML(http.StatusInternalServerError, "error.html", gin.H{
+			"error": "출석부를 불러오는데 실패했습니다.",
+		})
+		return
+	}
+
+	renderPage(c, http.StatusOK, "attendance.html", gin.H{
+		"students":    students,
+		"date":        dateStr,
+		"prevDate":    date.AddDate(0, 0, -1).Format("2006-01-02"),
+		"nextDate":    date.AddDate(0, 0, 1).Format("2006-01-02"),
+		"saved":       c.Query("saved") == "1",
+		"currentPage": "attendance",
+	})
+}
+
+// SaveRollCall records the attendance of every student for one day in a single POST.
+// Form fields are status[<student_id>] and memo[<student_id>]; an empty status clears the record.
+func (h *AttendanceHandler) SaveRollCall(c *gin.Context) {
+	session := sessions.Default(c)
+	userID := session.Get("user_id").(int32)
+
+	dateStr := c.PostForm("date")
+	date, err := time.Parse("2006-01-02", dateStr)
+	if err != nil {
+		c.HTML(http.StatusBadRequest, "error.html", gin.H{
+			"error": "출석 날짜가 올바르지 않습니다.",
+		})
+		return
+	}
+	attendanceDate := pgtype.Date{Time: date, Valid: true}
+
+	statuses := c.PostFormMap("status")
+	memos := c.PostFormMap("memo")
+
+	// 하나라도 잘못된 값이 있으면 아무것도 저장하지 않음
+	studentIDs := make(map[string]int32, len(statuses))
+	for key, status := range statuses {
+		studentID, err := strconv.ParseInt(key, 10, 32)
+		if err != nil || (status != "" && !isValidAttendanceStatus(status)) {
+			c.HTML(http.StatusBadRequest, "error.html", gin.H{
+				"error": "출석 상태가 올바르지 않습니다.",
+			})
+			return
+		}
+		studentIDs[key] = int32(studentID)
+	}
+
+	// 출석부 한 장은 전부 저장되거나 전부 취소됨
+	ctx := c.Request.Context()
+	err = func() error {
+		tx, err := h.pool.Begin(ctx)
+		if err != nil {
+			return err
+		}
+		defer tx.Rollback(ctx)
+		qtx := h.queries.WithTx(tx)
+
+		for key, status := range statuses {
+			if status == "" {
+				err = qtx.DeleteAttendanceByDate(ctx, sqlc.DeleteAttendanceByDateParams{
+					StudentID:      studentIDs[key],
+					AttendanceDate: attendanceDate,
+				})
+			} else {
+				memo := memos[key]
+				_, err = qtx.UpsertAttendance(ctx, sqlc.UpsertAttendanceParams{
+					StudentID:      studentIDs[key],
+					AttendanceDate: attendanceDate,
+					Status:         status,
+					Memo:           pgtype.Text{String: memo, Valid: memo != ""},
+					RecordedBy:     pgtype.Int4{Int32: userID, Valid: true},
+				})
+			}
+			if err != nil {
+				return err
+			}
+		}
+		return tx.Commit(ctx)
+	}()
+	if err != nil {
+		c.HTML(http.StatusInternalServerError, "error.html", gin.H{
+			"error": "출석 저장에 실패했습니다.",
+		})
+		return
+	}
+
+	c.Redirect(http.StatusFound, "/attendance?date="+dateStr+"&saved=1")
+}
+
+// ListStudentAttendance renders the attendance history of a single student
+func (h *AttendanceHandler) ListStudentAttendance(c *gin.Context) {
+	studentID, err := strconv.ParseInt(c.Param("id"), 10, 32)
+	if err != nil {
+		c.Redirect(http.StatusFound, "/students")
+		return
+	}
+
+	student, err := h.queries.GetStudentByID(c.Request.Context(), int32(studentID))
+	if err != nil {
+		c.HTML(http.StatusNotFound, "error.html", gin.H{
+			"error": "원생을 찾을 수 없습니다.",
+		})
+		return
+	}
+
+	// 페이지네이션 파라미터
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	if page < 1 {
+		page = 1
+	}
+	limit := 20
+	offset := (page - 1) * limit
+
+	// 기간 필터
+	startDateStr := c.Query("start_date")
+	endDateStr := c.Query("end_date")
+
+	var startDateParam pgtype.Date
+	if startDateStr != "" {
+		if t, err := time.Parse("2006-01-02", startDateStr); err == nil {
+			startDateParam = pgtype.Date{Time: t, Valid: true}
+		}
+	}
+
+	var endDateParam pgtype.Date
+	if endDateStr != "" {
+		if t, err := time.Parse("2006-01-02", endDateStr); err == nil {
+			endDateParam = pgtype.Date{Time: t, Valid: true}
+		}
+	}
+
+	totalCount, err := h.queries.CountAttendanceByStudent(c.Request.Context(), sqlc.CountAttendanceByStudentParams{
+		StudentID: int32(studentID),
+		StartDate: startDateParam,
+		EndDate:   endDateParam,
+	})
+	if err != nil {
+		c.HTML(http.StatusInternalServerError, "error.html", gin.H{
+			"error": "출석 기록 수를 조회하는데 실패했습니다.",
+		})
+		return
+	}
+
+	totalPages := int(totalCount) / limit
+	if int(totalCount)%limit > 0 {
+		totalPages++
+	}
+	if totalPages < 1 {
+		totalPages = 1
+	}
+
+	records, err := h.queries.ListAttendanceByStudent(c.Request.Context(), sqlc.ListAttendanceByStudentParams{
+		StudentID: int32(studentID),
+		Limit:     int32(limit),
+		Offset:    int32(offset),
+		StartDate: startDateParam,
+		EndDate:   endDateParam,
+	})
+	if err != nil {
+		c.HTML(http.StatusInternalServerError, "error.html", gin.H{
+			"error": "출석 기록을 불러오는데 실패했습니다.",
+		})
+		return
+	}
+
+	summaryRows, err := h.queries.SummarizeAttendanceByStudent(c.Request.Context(), sqlc.SummarizeAttendanceByStudentParams{
+		StudentID: int32(studentID),
+		StartDate: startDateParam,
+		EndDate:   endDateParam,
+	})
+	if err != nil {
+		c.HTML(http.StatusInternalServerError, "error.html", gin.H{
+			"error": "출석 통계를 불러오는데 실패했습니다.",
+		})
+		return
+	}
+
+	summary := map[string]int64{}
+	for _, s := range summaryRows {
+		summary[s.Status] = s.Count
+	}
+
+	renderPage(c, http.StatusOK, "student_attendance.html", gin.H{
+		"student":     student,
+		"records":     records,
+		"summary":     summary,
+		"today":       time.Now().Format("2006-01-02"),
+		"page":        page,
+		"totalCount":  totalCount,
+		"totalPages":  totalPages,
+		"startDate":   startDateStr,
+		"endDate":     endDateStr,
+		"currentPage": "students",
+	})
+}
+
+// RecordAttendance records (or overwrites) a single day's attendance for a student
+func (h *AttendanceHandler) RecordAttendance(c *gin.Context) {
+	session := sessions.Default(c)
+	userID := session.Get("user_id").(int32)
+
+	studentID, err := strconv.ParseInt(c.Param("id"), 10, 32)
+	if err != nil {
+		c.Redirect(http.StatusFound, "/students")
+		return
+	}
+
+	date, err := time.Parse("2006-01-02", c.PostForm("date"))
+	if err != nil {
+		c.HTML(http.StatusBadRequest, "error.html", gin.H{
+			"error": "출석 날짜가 올바르지 않습니다.",
+		})
+		return
+	}
+
+	status := c.PostForm("status")
+	if !isValidAttendanceStatus(status) {
+		c.HTML(http.StatusBadRequest, "error.html", gin.H{
+			"error": "출석 상태를 선택해주세요.",
+		})
+		return
+	}
+
+	memo := c.PostForm("memo")
+
+	_, err = h.queries.UpsertAttendance(c.Request.Context(), sqlc.UpsertAttendanceParams{
+		StudentID:      int32(studentID),
+		AttendanceDate: pgtype.Date{Time: date, Valid: true},
+		Status:         status,
+		Memo:           pgtype.Text{String: memo, Valid: memo != ""},
+		RecordedBy:     pgtype.Int4{Int32: userID, Valid: true},
+	})
+	if err != nil {
+		c.HTML(http.StatusInternalServerError, "error.html", gin.H{
+			"error": "출석 저장에 실패했습니다.",
+		})
+		return
+	}
+
+	c.Redirect(http.StatusFound, "/students/"+c.Param("id")+"/attendance")
+}
+
+func (h *AttendanceHandler) DeleteAttendance(c *gin.Context) {
+	studentID, err := strconv.ParseInt(c.Param("id"), 10, 32)
+	if err != nil {
+		c.Redirect(http.StatusFound, "/students")
+		return
+	}
+
+	attendanceID, err := strconv.ParseInt(c.Param("attendance_id"), 10, 32)
+	if err != nil {
+		c.Redirect(http.StatusFound, "/students/"+c.Param("id")+"/attendance")
+		return
+	}
+
+	h.queries.DeleteAttendance(c.Request.Context(), sqlc.DeleteAttendanceParams{
+		ID:        int32(attendanceID),
+		StudentID: int32(studentID),
+	})
+
+	c.Redirect(http.StatusFound, "/students/"+c.Param("id")+"/attendance")
+}
@@ -0,0 +1,300 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/gin-contrib/sessions"
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/choiexe1/hongik-academy/internal/db/sqlc"
+)
+
+const (
+	auditActionCreate        = "create"
+	auditActionUpdate        = "update"
+	auditActionDelete        = "delete"
+	auditActionRestore       = "restore"
+	auditActionPurge         = "purge"
+	auditActionUnlock        = "unlock"
+	auditActionReset2FA      = "reset_2fa"
+	auditActionResetPassword = "reset_password"
+	auditActionRevokeShare   = "revoke_share"
+
+	auditEntityStudent    = "student"
+	auditEntityEvaluation = "evaluation"
+	auditEntityUser       = "user"
+	auditEntityRole       = "role"
+	auditEntityAPIToken   = "api_token"
+)
+
+// auditPerPage is the page size of /admin/audit
+const auditPerPage = 20
+
+// recordAudit stores a before/after snapshot of a mutation. Pass nil for the side that does
+// not exist (before on create, after on delete). A failure is logged but never fails the
+// request that triggered it.
+func recordAudit(ctx context.Context, queries *sqlc.Queries, actorID int32, action, entityType string, entityID int32, before, after interface{}) {
+	beforeData, err := marshalAuditSnapshot(before)
+	if err != nil {
+		log.Printf("audit: failed to encode %s %d: %v", entityType, entityID, err)
+		return
+	}
+	afterData, err := marshalAuditSnapshot(after)
+	if err != nil {
+		log.Printf("audit: failed to encode %s %d: %v", entityType, entityID, err)
+		return
+	}
+
+	err = queries.CreateAuditLog(ctx, sqlc.CreateAuditLogParams{
+		ActorID:    pgtype.Int4{Int32: actorID, Valid: actorID != 0},
+		Action:     action,
+		EntityType: entityType,
+		EntityID:   entityID,
+		BeforeData: beforeData,
+		AfterData:  afterData,
+	})
+	if err != nil {
+		log.Printf("audit: failed to record %s %s %d: %v", action, entityType, entityID, err)
+	}
+}
+
+// sessionUserID returns the user logged in to the HTML pages, used as the audit actor
+func sessionUserID(c *gin.Context) int32 {
+	userID, _ := sessions.Default(c).Get("user_id").(int32)
+	return userID
+}
+
+func marshalAuditSnapshot(v interface{}) ([]byte, error) {
+	if v == nil {
+		return nil, nil
+	}
+	return json.Marshal(v)
+}
+
+// auditUser is the snapshot stored for users; the password hash is never written to the log,
+// only the fact that it was changed
+type auditUser struct {
+	sqlc.ListUsersRow
+	PasswordChanged bool `json:"password_changed,omitempty"`
+}
+
+// auditLoginLock is the snapshot stored when an administrator unlocks an account
+type auditLoginLock struct {
+	FailedLoginCount int32              `json:"failed_login_count"`
+	LockedUntil      pgtype.Timestamptz `json:"locked_until"`
+}
+
+// auditTwoFactor is the snapshot stored when a super admin resets a user's 2FA
+type auditTwoFactor struct {
+	Enabled             bool               `json:"enabled"`
+	EnabledAt           pgtype.Timestamptz `json:"enabled_at"`
+	UnusedRecoveryCodes int64              `json:"unused_recovery_codes"`
+}
+
+// auditToken is the snapshot stored for API tokens; the token hash is never written to the log
+type auditToken struct {
+	ID          int32              `json:"id"`
+	UserID      int32              `json:"user_id"`
+	Name        string             `json:"name"`
+	TokenPrefix string             `json:"token_prefix"`
+	ExpiresAt   pgtype.Timestamptz `json:"expires_at"`
+	RevokedAt   pgtype.Timestamptz `json:"revoked_at"`
+}
+
+func auditAPIToken(t sqlc.ApiToken) auditToken {
+	return auditToken{
+		ID:          t.ID,
+		UserID:      t.UserID,
+		Name:        t.Name,
+		TokenPrefix: t.TokenPrefix,
+		ExpiresAt:   t.ExpiresAt,
+		RevokedAt:   t.RevokedAt,
+	}
+}
+
+// auditEvaluation drops the joined author/student names so before and after snapshots of an
+// evaluation have the same shape
+func auditEvaluation(e sqlc.GetEvaluationByIDRow) sqlc.Evaluation {
+	return sqlc.Evaluation{
+		ID:        e.ID,
+		StudentID: e.StudentID,
+		AuthorID:  e.AuthorID,
+		Content:   e.Content,
+		CreatedAt: e.CreatedAt,
+		UpdatedAt: e.UpdatedAt,
+		DeletedAt: e.DeletedAt,
+	}
+}
+
+// auditChange is one field of an audit entry as shown on /admin/audit
+type auditChange struct {
+	Field  string
+	Before string
+	After  string
+}
+
+type auditEntry struct {
+	sqlc.ListAuditLogsRow
+	Changes []auditChange
+}
+
+// auditChanges lists the fields that differ between the two snapshots. For creates and
+// deletes every field of the existing side is listed.
+func auditChanges(beforeData, afterData []byte) []auditChange {
+	before := map[string]json.RawMessage{}
+	after := map[string]json.RawMessage{}
+	json.Unmarshal(beforeData, &before)
+	json.Unmarshal(afterData, &after)
+
+	fields := make(map[string]bool)
+	for k := range before {
+		fields[k] = true
+	}
+	for k := range after {
+		fields[k] = true
+	}
+
+	var changes []auditChange
+	for field := range fields {
+		b, a := before[field], after[field]
+		if string(b) == string(a) {
+			continue
+		}
+		// 수정 시각은 모든 수정에서 바뀌므로 생략
+		if field == "updated_at" && len(beforeData) > 0 && len(afterData) > 0 {
+			continue
+		}
+		changes = append(changes, auditChange{
+			Field:  field,
+			Before: auditValue(b),
+			After:  auditValue(a),
+		})
+	}
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Field < changes[j].Field })
+	return changes
+}
+
+func auditValue(raw json.RawMessage) string {
+	if len(raw) == 0 || string(raw) == "null" {
+		return ""
+	}
+	var s string
+	if err := json.Unmarshal(raw, &s); err == nil {
+		return s
+	}
+	return string(raw)
+}
+
+type AuditHandler struct {
+	queries *sqlc.Queries
+}
+
+func NewAuditHandler(queries *sqlc.Queries) *AuditHandler {
+	return &AuditHandler{queries: queries}
+}
+
+// ListAuditLogs renders /admin/audit with user_id, entity_type, start_date and end_date filters
+func (h *AuditHandler) ListAuditLogs(c *gin.Context) {
+	// 필터 파라미터
+	userID := c.Query("user_id")
+	entityType := c.Query("entity_type")
+	startDate := c.Query("start_date")
+	endDate := c.Query("end_date")
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	if page < 1 {
+		page = 1
+	}
+
+	actorParam := pgtype.Int4{Valid: false}
+	if id, err := strconv.ParseInt(userID, 10, 32); err == nil {
+		actorParam = pgtype.Int4{Int32: int32(id), Valid: true}
+	} else {
+		userID = ""
+	}
+	entityParam := pgtype.Text{Valid: false}
+	if entityType == auditEntityStudent || entityType == auditEntityEvaluation || entityType == auditEntityUser || entityType == auditEntityRole || entityType == auditEntityAPIToken {
+		entityParam = pgtype.Text{String: entityType, Valid: true}
+	} else {
+		entityType = ""
+	}
+	startDateParam := pgtype.Date{Valid: false}
+	if t, err := time.Parse("2006-01-02", startDate); err == nil {
+		startDateParam = pgtype.Date{Time: t, Valid: true}
+	} else {
+		startDate = ""
+	}
+	endDateParam := pgtype.Date{Valid: false}
+	if t, err := time.Parse("2006-01-02", endDate); err == nil {
+		endDateParam = pgtype.Date{Time: t, Valid: true}
+	} else {
+		endDate = ""
+	}
+
+	totalCount, err := h.queries.CountAuditLogs(c.Request.Context(), sqlc.CountAuditLogsParams{
+		ActorID:    actorParam,
+		EntityType: entityParam,
+		StartDate:  startDateParam,
+		EndDate:    endDateParam,
+	})
+	if err != nil {
+		c.HTML(http.StatusInternalServerError, "error.html", gin.H{
+			"error": "감사 로그 개수를 조회하는데 실패했습니다.",
+		})
+		return
+	}
+
+	logs, err := h.queries.ListAuditLogs(c.Request.Context(), sqlc.ListAuditLogsParams{
+		ActorID:    actorParam,
+		EntityType: entityParam,
+		StartDate:  startDateParam,
+		EndDate:    endDateParam,
+		Limit:      auditPerPage,
+		Offset:     int32((page - 1) * auditPerPage),
+	})
+	if err != nil {
+		c.HTML(http.StatusInternalServerError, "error.html", gin.H{
+			"error": "감사 로그를 불러오는데 실패했습니다.",
+		})
+		return
+	}
+
+	entries := make([]auditEntry, 0, len(logs))
+	for _, l := range logs {
+		entries = append(entries, auditEntry{
+			ListAuditLogsRow: l,
+			Changes:          auditChanges(l.BeforeData, l.AfterData),
+		})
+	}
+
+	// 사용자 필터 선택지
+	users, err := h.queries.ListUsers(c.Request.Context())
+	if err != nil {
+		c.HTML(http.StatusInternalServerError, "error.html", gin.H{
+			"error": "사용자 목록을 불러오는데 실패했습니다.",
+		})
+		return
+	}
+
+	totalPages := int(math.Ceil(float64(totalCount) / float64(auditPerPage)))
+
+	renderPage(c, http.StatusOK, "audit_log.html", gin.H{
+		"entries":     entries,
+		"users":       users,
+		"userID":      userID,
+		"entityType":  entityType,
+		"startDate":   startDate,
+		"endDate":     endDate,
+		"page":        page,
+		"totalCount":  totalCount,
+		"totalPages":  totalPages,
+		"currentPage": "users",
+	})
+}
@@ -1,18 +1,31 @@
 package handlers
 
 import (
-	"crypto/rand"
-	"encoding/hex"
+	"context"
+	"errors"
+	"log"
 	"net/http"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/gin-contrib/sessions"
 	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgtype"
 	"golang.org/x/crypto/bcrypt"
 
 	"github.com/choiexe1/hongik-academy/internal/db/sqlc"
+	"github.com/choiexe1/hongik-academy/internal/loginguard"
+	"github.com/choiexe1/hongik-academy/internal/middleware"
+	"github.com/choiexe1/hongik-academy/internal/twofactor"
+	"github.com/choiexe1/hongik-academy/internal/usersession"
 )
 
+// dummyPasswordHash is compared against when the username does not exist, so an unknown
+// username takes as long to reject as a wrong password and cannot be told apart by timing
+const dummyPasswordHash = "$2a$10$V2bsu4YBoDjW2wQGIw6aVOGPFsaIyc6wksTS36jQYJ/WztJKYyOta"
+
 type AuthHandler struct {
 	queries *sqlc.Queries
 }
@@ -32,11 +45,14 @@ func (h *AuthHandler) ShowLoginPage(c *gin.Context) {
 	// 로그아웃 사유 확인
 	reason := c.Query("reason")
 	errorMsg := ""
-	if reason == "session_expired" {
-		errorMsg = "다른 기기에서 로그인하여 현재 세션이 종료되었습니다."
+	switch reason {
+	case "session_expired":
+		errorMsg = "세션이 만료되었거나 종료되었습니다. 다시 로그인해주세요."
+	case "2fa_expired":
+		errorMsg = "인증 시간이 지났습니다. 다시 로그인해주세요."
 	}
 
-	c.HTML(http.StatusOK, "login.html", gin.H{
+	renderHTML(c, http.StatusOK, "login.html", gin.H{
 		"error": errorMsg,
 	})
 }
@@ -46,72 +62,395 @@ type LoginRequest struct {
 	Password string `form:"password" binding:"required"`
 }
 
-// generateSessionToken generates a random session token
-func generateSessionToken() (string, error) {
-	bytes := make([]byte, 32)
-	if _, err := rand.Read(bytes); err != nil {
-		return "", err
-	}
-	return hex.EncodeToString(bytes), nil
-}
-
 func (h *AuthHandler) Login(c *gin.Context) {
 	var req LoginRequest
 	if err := c.ShouldBind(&req); err != nil {
-		c.HTML(http.StatusBadRequest, "login.html", gin.H{
+		renderHTML(c, http.StatusBadRequest, "login.html", gin.H{
 			"error": "사용자명과 비밀번호를 입력해주세요.",
 		})
 		return
 	}
 
-	user, err := h.queries.GetUserByUsername(c.Request.Context(), req.Username)
+	ctx := c.Request.Context()
+	ip := c.ClientIP()
+
+	// IP 단위 제한: 사용자명을 바꿔가며 시도하는 경우까지 차단
+	ipFailures, err := h.queries.CountRecentFailedLoginsByIP(ctx, sqlc.CountRecentFailedLoginsByIPParams{
+		IpAddress: ip,
+		Since:     pgtype.Timestamptz{Time: time.Now().Add(-loginguard.IPWindow), Valid: true},
+	})
+	if err != nil {
+		renderHTML(c, http.StatusInternalServerError, "login.html", gin.H{
+			"error": "로그인 처리 중 오류가 발생했습니다.",
+		})
+		return
+	}
+	if ipFailures >= loginguard.MaxIPFailures {
+		h.recordAttempt(c, req.Username, 0, loginguard.ReasonIPBlocked)
+		renderHTML(c, http.StatusTooManyRequests, "login.html", gin.H{
+			"error": "로그인 실패가 너무 많습니다. " + minutesText(loginguard.IPWindow) + " 후 다시 시도해주세요.",
+		})
+		return
+	}
+
+	user, err := h.queries.GetUserByUsername(ctx, req.Username)
 	if err != nil {
-		c.HTML(http.StatusUnauthorized, "login.html", gin.H{
+		bcrypt.CompareHashAndPassword([]byte(dummyPasswordHash), []byte(req.Password))
+		h.recordAttempt(c, req.Username, 0, loginguard.ReasonUnknownUser)
+		loginguard.Wait(ctx, loginguard.Delay(ipFailures+1))
+		renderHTML(c, http.StatusUnauthorized, "login.html", gin.H{
 			"error": "사용자명 또는 비밀번호가 올바르지 않습니다.",
 		})
 		return
 	}
 
+	// 잠긴 계정은 비밀번호가 맞아도 거부
+	if user.LockedUntil.Valid && user.LockedUntil.Time.After(time.Now()) {
+		h.recordAttempt(c, req.Username, user.ID, loginguard.ReasonLocked)
+		renderHTML(c, http.StatusForbidden, "login.html", gin.H{
+			"error": lockedMessage(user.LockedUntil.Time),
+		})
+		return
+	}
+
+	attempt, reserved, err := reserveAttempt(ctx, h.queries, user.ID, user.FailedLoginCount)
+	if err != nil {
+		renderHTML(c, http.StatusInternalServerError, "login.html", gin.H{
+			"error": "로그인 처리 중 오류가 발생했습니다.",
+		})
+		return
+	}
+	if !reserved {
+		// 동시에 들어온 다른 시도가 방금 계정을 잠근 경우
+		h.recordAttempt(c, req.Username, user.ID, loginguard.ReasonLocked)
+		renderHTML(c, http.StatusForbidden, "login.html", gin.H{
+			"error": lockedMessage(lockedUntil(ctx, h.queries, user.ID)),
+		})
+		return
+	}
+
 	if err := bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(req.Password)); err != nil {
-		c.HTML(http.StatusUnauthorized, "login.html", gin.H{
-			"error": "사용자명 또는 비밀번호가 올바르지 않습니다.",
+		h.recordAttempt(c, req.Username, user.ID, loginguard.ReasonBadPassword)
+
+		failures := attempt.FailedLoginCount
+		if failures >= loginguard.MaxFailures {
+			renderHTML(c, http.StatusForbidden, "login.html", gin.H{
+				"error": lockedMessage(attempt.LockedUntil.Time),
+			})
+			return
+		}
+
+		loginguard.Wait(ctx, loginguard.Delay(max(int64(failures), ipFailures+1)))
+		errMsg := "사용자명 또는 비밀번호가 올바르지 않습니다."
+		if left := loginguard.MaxFailures - failures; left <= 2 {
+			errMsg += " " + strconv.Itoa(int(left)) + "번 더 실패하면 계정이 잠깁니다."
+		}
+		renderHTML(c, http.StatusUnauthorized, "login.html", gin.H{
+			"error": errMsg,
 		})
 		return
 	}
 
-	// 세션 토큰 생성
-	sessionToken, err := generateSessionToken()
+	// 2단계 인증 사용자는 코드 확인 후에 세션을 발급. 실패 횟수도 코드까지 맞아야 초기화
+	tf, err := h.queries.GetUserTwoFactor(ctx, user.ID)
 	if err != nil {
-		c.HTML(http.StatusInternalServerError, "login.html", gin.H{
+		renderHTML(c, http.StatusInternalServerError, "login.html", gin.H{
 			"error": "로그인 처리 중 오류가 발생했습니다.",
 		})
 		return
 	}
+	if tf.TotpEnabledAt.Valid {
+		// 비밀번호는 맞았으므로 미리 센 실패 한 번을 되돌림
+		if err := h.queries.ReleaseLoginAttempt(ctx, sqlc.ReleaseLoginAttemptParams{
+			ID:               user.ID,
+			FailedLoginCount: attempt.FailedLoginCount,
+			LockedUntil:      user.LockedUntil,
+		}); err != nil {
+			renderHTML(c, http.StatusInternalServerError, "login.html", gin.H{
+				"error": "로그인 처리 중 오류가 발생했습니다.",
+			})
+			return
+		}
+
+		session := sessions.Default(c)
+		session.Set(pendingTwoFactorUserKey, user.ID)
+		session.Set(pendingTwoFactorAtKey, time.Now().Unix())
+		session.Save()
+		c.Redirect(http.StatusFound, "/login/2fa")
+		return
+	}
+
+	h.completeLogin(c, user.ID, user.Username, user.Role, "/dashboard")
+}
 
-	// DB에 세션 토큰 저장 (기존 토큰 덮어쓰기 = 다른 기기 로그아웃)
-	h.queries.UpdateSessionToken(c.Request.Context(), sqlc.UpdateSessionTokenParams{
-		ID:           user.ID,
-		SessionToken: pgtype.Text{String: sessionToken, Valid: true},
+// reserveAttempt counts a login attempt as failed before its password or code is checked.
+// The lock check, the increment and the lock itself are one statement, so parallel guesses
+// cannot all pass the check before any failure is counted. reserved is false when the
+// account is locked; a correct password or code afterwards resets or releases the count.
+func reserveAttempt(ctx context.Context, queries *sqlc.Queries, userID, failures int32) (sqlc.ReserveLoginAttemptRow, bool, error) {
+	// 잠금 기간은 읽어 둔 횟수로 계산. 동시 요청으로 어긋나도 최소 BaseLockout만큼은 잠김
+	lockout := loginguard.Lockout(max(failures+1, loginguard.MaxFailures))
+	attempt, err := queries.ReserveLoginAttempt(ctx, sqlc.ReserveLoginAttemptParams{
+		MaxFailures: loginguard.MaxFailures,
+		LockUntil:   pgtype.Timestamptz{Time: time.Now().Add(lockout), Valid: true},
+		ID:          userID,
 	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return attempt, false, nil
+	}
+	if err != nil {
+		return attempt, false, err
+	}
+	return attempt, true, nil
+}
 
+// lockedUntil returns when the account's current lock ends
+func lockedUntil(ctx context.Context, queries *sqlc.Queries, userID int32) time.Time {
+	user, err := queries.GetUserByID(ctx, userID)
+	if err != nil || !user.LockedUntil.Valid {
+		return time.Now().Add(loginguard.BaseLockout)
+	}
+	return user.LockedUntil.Time
+}
+
+// Session keys of a login whose password was accepted but whose 2FA code is still pending
+const (
+	pendingTwoFactorUserKey = "pending_2fa_user_id"
+	pendingTwoFactorAtKey   = "pending_2fa_at"
+)
+
+// pendingTwoFactorUser returns the user waiting at the 2FA step, unless the password step
+// is older than twofactor.PendingLifetime
+func pendingTwoFactorUser(session sessions.Session) (int32, bool) {
+	userID, ok := session.Get(pendingTwoFactorUserKey).(int32)
+	if !ok {
+		return 0, false
+	}
+	at, ok := session.Get(pendingTwoFactorAtKey).(int64)
+	if !ok || time.Since(time.Unix(at, 0)) > twofactor.PendingLifetime {
+		return 0, false
+	}
+	return userID, true
+}
+
+func clearPendingTwoFactor(session sessions.Session) {
+	session.Delete(pendingTwoFactorUserKey)
+	session.Delete(pendingTwoFactorAtKey)
+}
+
+// ShowTwoFactorPage asks for the authenticator or recovery code after the password step
+func (h *AuthHandler) ShowTwoFactorPage(c *gin.Context) {
+	if _, ok := pendingTwoFactorUser(sessions.Default(c)); !ok {
+		c.Redirect(http.StatusFound, "/login?reason=2fa_expired")
+		return
+	}
+
+	renderHTML(c, http.StatusOK, "login_2fa.html", gin.H{})
+}
+
+// VerifyTwoFactor finishes a login with a 6 digit authenticator code or a recovery code.
+// Wrong codes count toward the same lockout as wrong passwords.
+func (h *AuthHandler) VerifyTwoFactor(c *gin.Context) {
+	ctx := c.Request.Context()
 	session := sessions.Default(c)
-	session.Set("user_id", user.ID)
-	session.Set("username", user.Username)
-	session.Set("role", user.Role)
-	session.Set("session_token", sessionToken)
-	session.Save()
 
-	c.Redirect(http.StatusFound, "/dashboard")
+	userID, ok := pendingTwoFactorUser(session)
+	if !ok {
+		clearPendingTwoFactor(session)
+		session.Save()
+		c.Redirect(http.StatusFound, "/login?reason=2fa_expired")
+		return
+	}
+
+	code := strings.TrimSpace(c.PostForm("code"))
+	if code == "" {
+		renderHTML(c, http.StatusBadRequest, "login_2fa.html", gin.H{
+			"error": "인증 코드를 입력해주세요.",
+		})
+		return
+	}
+
+	user, err := h.queries.GetUserByID(ctx, userID)
+	if err != nil {
+		clearPendingTwoFactor(session)
+		session.Save()
+		c.Redirect(http.StatusFound, "/login")
+		return
+	}
+
+	if user.LockedUntil.Valid && user.LockedUntil.Time.After(time.Now()) {
+		h.recordAttempt(c, user.Username, user.ID, loginguard.ReasonLocked)
+		clearPendingTwoFactor(session)
+		session.Save()
+		renderHTML(c, http.StatusForbidden, "login.html", gin.H{
+			"error": lockedMessage(user.LockedUntil.Time),
+		})
+		return
+	}
+
+	tf, err := h.queries.GetUserTwoFactor(ctx, user.ID)
+	if err != nil {
+		renderHTML(c, http.StatusInternalServerError, "login_2fa.html", gin.H{
+			"error": "로그인 처리 중 오류가 발생했습니다.",
+		})
+		return
+	}
+
+	// 비밀번호 확인 후 관리자가 2단계 인증을 초기화한 경우에는 그대로 로그인
+	recovery := false
+	if tf.TotpEnabledAt.Valid {
+		attempt, reserved, err := reserveAttempt(ctx, h.queries, user.ID, user.FailedLoginCount)
+		if err != nil {
+			renderHTML(c, http.StatusInternalServerError, "login_2fa.html", gin.H{
+				"error": "로그인 처리 중 오류가 발생했습니다.",
+			})
+			return
+		}
+		if !reserved {
+			h.recordAttempt(c, user.Username, user.ID, loginguard.ReasonLocked)
+			clearPendingTwoFactor(session)
+			session.Save()
+			renderHTML(c, http.StatusForbidden, "login.html", gin.H{
+				"error": lockedMessage(lockedUntil(ctx, h.queries, user.ID)),
+			})
+			return
+		}
+
+		var valid bool
+		valid, recovery, err = verifyTwoFactorCode(ctx, h.queries, user.ID, tf, code)
+		if err != nil {
+			renderHTML(c, http.StatusInternalServerError, "login_2fa.html", gin.H{
+				"error": "로그인 처리 중 오류가 발생했습니다.",
+			})
+			return
+		}
+		if !valid {
+			h.recordAttempt(c, user.Username, user.ID, loginguard.ReasonBadOTP)
+
+			failures := attempt.FailedLoginCount
+			if failures >= loginguard.MaxFailures {
+				clearPendingTwoFactor(session)
+				session.Save()
+				renderHTML(c, http.StatusForbidden, "login.html", gin.H{
+					"error": lockedMessage(attempt.LockedUntil.Time),
+				})
+				return
+			}
+
+			loginguard.Wait(ctx, loginguard.Delay(int64(failures)))
+			errMsg := "인증 코드가 올바르지 않습니다."
+			if left := loginguard.MaxFailures - failures; left <= 2 {
+				errMsg += " " + strconv.Itoa(int(left)) + "번 더 실패하면 계정이 잠깁니다."
+			}
+			renderHTML(c, http.StatusUnauthorized, "login_2fa.html", gin.H{
+				"error": errMsg,
+			})
+			return
+		}
+	}
+
+	clearPendingTwoFactor(session)
+
+	// 복구 코드로 로그인하면 남은 개수를 볼 수 있도록 2단계 인증 설정으로 이동
+	next := "/dashboard"
+	if recovery {
+		next = "/account/2fa?recovery=used"
+	}
+	h.completeLogin(c, user.ID, user.Username, user.Role, next)
+}
+
+// completeLogin starts the session of a user who passed every login step and redirects to next.
+// It also clears the failure count, including the attempt reserveAttempt counted in advance.
+func (h *AuthHandler) completeLogin(c *gin.Context, userID int32, username, role, next string) {
+	h.queries.ResetLoginFailures(c.Request.Context(), userID)
+
+	if err := h.startSession(c, userID, username, role); err != nil {
+		renderHTML(c, http.StatusInternalServerError, "login.html", gin.H{
+			"error": "로그인 처리 중 오류가 발생했습니다.",
+		})
+		return
+	}
+	h.recordAttempt(c, username, userID, loginguard.ReasonSuccess)
+
+	c.Redirect(http.StatusFound, next)
+}
+
+// recordAttempt stores one login attempt; userID is 0 when the username does not exist.
+// A failure to record is logged but does not change the login result.
+func (h *AuthHandler) recordAttempt(c *gin.Context, username string, userID int32, reason string) {
+	// login_attempts.username은 VARCHAR(50)
+	if r := []rune(username); len(r) > 50 {
+		username = string(r[:50])
+	}
+	err := h.queries.CreateLoginAttempt(c.Request.Context(), sqlc.CreateLoginAttemptParams{
+		Username:  username,
+		UserID:    pgtype.Int4{Int32: userID, Valid: userID != 0},
+		IpAddress: c.ClientIP(),
+		UserAgent: c.Request.UserAgent(),
+		Success:   reason == loginguard.ReasonSuccess,
+		Reason:    reason,
+	})
+	if err != nil {
+		log.Printf("login: failed to record attempt for %q: %v", username, err)
+	}
+}
+
+func lockedMessage(until time.Time) string {
+	return "로그인 실패가 반복되어 계정이 잠겼습니다. " + minutesText(time.Until(until)) + " 후 다시 시도하거나 관리자에게 잠금 해제를 요청해주세요."
+}
+
+// minutesText rounds a duration up to whole minutes, e.g. "15분"
+func minutesText(d time.Duration) string {
+	minutes := int((d + time.Minute - 1) / time.Minute)
+	if minutes < 1 {
+		minutes = 1
+	}
+	return strconv.Itoa(minutes) + "분"
+}
+
+// startSession records a new login session for the user and stores its token in the cookie.
+// When the user's role limits concurrent logins, the least recently used sessions beyond
+// the limit are ended.
+func (h *AuthHandler) startSession(c *gin.Context, userID int32, username, role string) error {
+	ctx := c.Request.Context()
+
+	token, hash, err := usersession.Generate()
+	if err != nil {
+		return err
+	}
+
+	h.queries.DeleteExpiredUserSessions(ctx, userID)
+	if _, err := h.queries.CreateUserSession(ctx, sqlc.CreateUserSessionParams{
+		UserID:    userID,
+		TokenHash: hash,
+		UserAgent: c.Request.UserAgent(),
+		IpAddress: c.ClientIP(),
+		ExpiresAt: pgtype.Timestamptz{Time: time.Now().Add(usersession.Lifetime), Valid: true},
+	}); err != nil {
+		return err
+	}
+
+	// 역할별 동시 로그인 제한을 넘으면 가장 오래 사용하지 않은 세션부터 종료
+	if limit, err := h.queries.GetUserMaxSessions(ctx, userID); err == nil && limit.Valid {
+		h.queries.TrimUserSessions(ctx, sqlc.TrimUserSessionsParams{UserID: userID, Limit: limit.Int32})
+	}
+
+	session := sessions.Default(c)
+	session.Set("user_id", userID)
+	session.Set("username", username)
+	session.Set("role", role)
+	session.Set("session_token", token)
+	// 로그인 전에 발급된 CSRF 토큰은 폐기하고 다음 요청에서 새로 발급
+	session.Delete(middleware.CSRFTokenKey)
+	return session.Save()
 }
 
 func (h *AuthHandler) Logout(c *gin.Context) {
 	session := sessions.Default(c)
 
-	// DB에서 세션 토큰 제거
-	if userID := session.Get("user_id"); userID != nil {
-		if id, ok := userID.(int32); ok {
-			h.queries.ClearSessionToken(c.Request.Context(), id)
-		}
+	// 현재 기기의 세션만 종료 (다른 기기의 로그인은 유지)
+	if token, ok := session.Get("session_token").(string); ok {
+		h.queries.DeleteUserSessionByHash(c.Request.Context(), usersession.Hash(token))
 	}
 
 	session.Clear()
@@ -0,0 +1,492 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-contrib/sessions"
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/choiexe1/hongik-academy/internal/db/sqlc"
+)
+
+// weekdayNames maps ISO weekdays (1=월 ... 7=일) to their Korean labels
+var weekdayNames = map[int16]string{
+	1: "월", 2: "화", 3: "수", 4: "목", 5: "금", 6: "토", 7: "일",
+}
+
+// classSlot is one row of the weekday/time slot inputs on the class form
+type classSlot struct {
+	Weekday int16
+	Label   string
+	Checked bool
+	Start   string
+	End     string
+}
+
+// parseClockTime parses an "HH:MM" string into a pgtype.Time
+func parseClockTime(value string) (pgtype.Time, error) {
+	t, err := time.Parse("15:04", value)
+	if err != nil {
+		return pgtype.Time{}, err
+	}
+	micros := int64(t.Hour())*int64(time.Hour/time.Microsecond) + int64(t.Minute())*int64(time.Minute/time.Microsecond)
+	return pgtype.Time{Microseconds: micros, Valid: true}, nil
+}
+
+// formatClockTime formats a pgtype.Time as "HH:MM"
+func formatClockTime(t pgtype.Time) string {
+	if !t.Valid {
+		return ""
+	}
+	minutes := t.Microseconds / int64(time.Minute/time.Microsecond)
+	return fmt.Sprintf("%02d:%02d", minutes/60, minutes%60)
+}
+
+// buildClassSlots builds the seven weekday rows for the class form
+func buildClassSlots(schedules []sqlc.ClassSchedule) []classSlot {
+	slots := make([]classSlot, 0, 7)
+	for day := int16(1); day <= 7; day++ {
+		slot := classSlot{Weekday: day, Label: weekdayNames[day]}
+		for _, s := range schedules {
+			if s.Weekday == day {
+				slot.Checked = true
+				slot.Start = formatClockTime(s.StartTime)
+				slot.End = formatClockTime(s.EndTime)
+				break
+			}
+		}
+		slots = append(slots, slot)
+	}
+	return slots
+}
+
+// describeSchedule renders a schedule as e.g. "월 14:00-16:00"
+func describeSchedule(s sqlc.ClassSchedule) string {
+	return weekdayNames[s.Weekday] + " " + formatClockTime(s.StartTime) + "-" + formatClockTime(s.EndTime)
+}
+
+// errClassFull is returned from the enrollment transaction when the class has no seat left
+var errClassFull = errors.New("class is full")
+
+type ClassHandler struct {
+	pool    *pgxpool.Pool
+	queries *sqlc.Queries
+}
+
+func NewClassHandler(pool *pgxpool.Pool, queries *sqlc.Queries) *ClassHandler {
+	return &ClassHandler{pool: pool, queries: queries}
+}
+
+func (h *ClassHandler) ListClasses(c *gin.Context) {
+	classes, err := h.queries.ListClasses(c.Request.Context())
+	if err != nil {
+		c.HTML(http.StatusInternalServerError, "error.html", gin.H{
+			"error": "반 목록을 불러오는데 실패했습니다.",
+		})
+		return
+	}
+
+	schedules, err := h.queries.ListClassSchedules(c.Request.Context())
+	if err != nil {
+		c.HTML(http.StatusInternalServerError, "error.html", gin.H{
+			"error": "수업 시간표를 불러오는데 실패했습니다.",
+		})
+		return
+	}
+
+	scheduleMap := map[int32][]string{}
+	for _, s := range schedules {
+		scheduleMap[s.ClassID] = append(scheduleMap[s.ClassID], describeSchedule(s))
+	}
+
+	renderPage(c, http.StatusOK, "classes.html", gin.H{
+		"classes":     classes,
+		"schedules":   scheduleMap,
+		"currentPage": "classes",
+	})
+}
+
+func (h *ClassHandler) ShowClass(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 32)
+	if err != nil {
+		c.Redirect(http.StatusFound, "/classes")
+		return
+	}
+
+	class, err := h.queries.GetClassByID(c.Request.Context(), int32(id))
+	if err != nil {
+		c.HTML(http.StatusNotFound, "error.html", gin.H{
+			"error": "반을 찾을 수 없습니다.",
+		})
+		return
+	}
+
+	schedules, err := h.queries.ListClassSchedulesByClass(c.Request.Context(), int32(id))
+	if err != nil {
+		c.HTML(http.StatusInternalServerError, "error.html", gin.H{
+			"error": "수업 시간표를 불러오는데 실패했습니다.",
+		})
+		return
+	}
+	scheduleLabels := make([]string, 0, len(schedules))
+	for _, s := range schedules {
+		scheduleLabels = append(scheduleLabels, describeSchedule(s))
+	}
+
+	students, err := h.queries.ListClassStudents(c.Request.Context(), int32(id))
+	if err != nil {
+		c.HTML(http.StatusInternalServerError, "error.html", gin.H{
+			"error": "수강생 목록을 불러오는데 실패했습니다.",
+		})
+		return
+	}
+
+	candidates, err := h.queries.ListStudentsNotInClass(c.Request.Context(), int32(id))
+	if err != nil {
+		c.HTML(http.StatusInternalServerError, "error.html", gin.H{
+			"error": "원생 목록을 불러오는데 실패했습니다.",
+		})
+		return
+	}
+
+	isFull := class.Capacity.Valid && class.StudentCount >= int64(class.Capacity.Int32)
+
+	renderPage(c, http.StatusOK, "class_detail.html", gin.H{
+		"class":       class,
+		"schedules":   scheduleLabels,
+		"students":    students,
+		"candidates":  candidates,
+		"isFull":      isFull,
+		"error":       classEnrollErrors[c.Query("error")],
+		"currentPage": "classes",
+	})
+}
+
+// classEnrollErrors maps the ?error= code used by enrollment redirects to a message
+var classEnrollErrors = map[string]string{
+	"full":    "정원이 가득 찼습니다.",
+	"invalid": "원생을 선택해주세요.",
+	"failed":  "수강 등록에 실패했습니다.",
+}
+
+func (h *ClassHandler) ShowCreateForm(c *gin.Context) {
+	h.renderForm(c, http.StatusOK, "반 등록", "/classes", nil, buildClassSlots(nil), "")
+}
+
+func (h *ClassHandler) CreateClass(c *gin.Context) {
+	params, slots, errMsg := parseClassForm(c)
+	if errMsg != "" {
+		h.renderForm(c, http.StatusBadRequest, "반 등록", "/classes", nil, slots, errMsg)
+		return
+	}
+
+	class, err := h.queries.CreateClass(c.Request.Context(), sqlc.CreateClassParams{
+		Name:         params.Name,
+		InstructorID: params.InstructorID,
+		Capacity:     params.Capacity,
+		Description:  params.Description,
+	})
+	if err != nil {
+		h.renderForm(c, http.StatusInternalServerError, "반 등록", "/classes", nil, slots, "반 등록에 실패했습니다.")
+		return
+	}
+
+	if err := h.saveSchedules(c, class.ID, slots); err != nil {
+		h.renderForm(c, http.StatusInternalServerError, "반 등록", "/classes", nil, slots, "수업 시간 저장에 실패했습니다.")
+		return
+	}
+
+	c.Redirect(http.StatusFound, "/classes/"+strconv.Itoa(int(class.ID)))
+}
+
+func (h *ClassHandler) ShowEditForm(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 32)
+	if err != nil {
+		c.Redirect(http.StatusFound, "/classes")
+		return
+	}
+
+	class, err := h.queries.GetClassByID(c.Request.Context(), int32(id))
+	if err != nil {
+		c.Redirect(http.StatusFound, "/classes")
+		return
+	}
+
+	schedules, err := h.queries.ListClassSchedulesByClass(c.Request.Context(), int32(id))
+	if err != nil {
+		c.HTML(http.StatusInternalServerError, "error.html", gin.H{
+			"error": "수업 시간표를 불러오는데 실패했습니다.",
+		})
+		return
+	}
+
+	h.renderForm(c, http.StatusOK, "반 수정", "/classes/"+c.Param("id"), &class, buildClassSlots(schedules), "")
+}
+
+func (h *ClassHandler) UpdateClass(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 32)
+	if err != nil {
+		c.Redirect(http.StatusFound, "/classes")
+		return
+	}
+
+	class, err := h.queries.GetClassByID(c.Request.Context(), int32(id))
+	if err != nil {
+		c.Redirect(http.StatusFound, "/classes")
+		return
+	}
+
+	action := "/classes/" + c.Param("id")
+	params, slots, errMsg := parseClassForm(c)
+	if errMsg != "" {
+		h.renderForm(c, http.StatusBadRequest, "반 수정", action, &class, slots, errMsg)
+		return
+	}
+
+	if params.Capacity.Valid && int64(params.Capacity.Int32) < class.StudentCount {
+		h.renderForm(c, http.StatusBadRequest, "반 수정", action, &class, slots, "정원은 현재 수강생 수보다 작을 수 없습니다.")
+		return
+	}
+
+	_, err = h.queries.UpdateClass(c.Request.Context(), sqlc.UpdateClassParams{
+		ID:           int32(id),
+		Name:         params.Name,
+		InstructorID: params.InstructorID,
+		Capacity:     params.Capacity,
+		Description:  params.Description,
+	})
+	if err != nil {
+		h.renderForm(c, http.StatusInternalServerError, "반 수정", action, &class, slots, "반 수정에 실패했습니다.")
+		return
+	}
+
+	if err := h.queries.DeleteClassSchedulesByClass(c.Request.Context(), int32(id)); err != nil {
+		h.renderForm(c, http.StatusInternalServerError, "반 수정", action, &class, slots, "수업 시간 저장에 실패했습니다.")
+		return
+	}
+	if err := h.saveSchedules(c, int32(id), slots); err != nil {
+		h.renderForm(c, http.StatusInternalServerError, "반 수정", action, &class, slots, "수업 시간 저장에 실패했습니다.")
+		return
+	}
+
+	c.Redirect(http.StatusFound, "/classes/"+c.Param("id"))
+}
+
+func (h *ClassHandler) DeleteClass(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 32)
+	if err != nil {
+		c.Redirect(http.StatusFound, "/classes")
+		return
+	}
+
+	h.queries.DeleteClass(c.Request.Context(), int32(id))
+
+	c.Redirect(http.StatusFound, "/classes")
+}
+
+// EnrollStudent adds a student to the class, respecting its capacity
+func (h *ClassHandler) EnrollStudent(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 32)
+	if err != nil {
+		c.Redirect(http.StatusFound, "/classes")
+		return
+	}
+	detailURL := "/classes/" + c.Param("id")
+
+	studentID, err := strconv.ParseInt(c.PostForm("student_id"), 10, 32)
+	if err != nil {
+		c.Redirect(http.StatusFound, detailURL+"?error=invalid")
+		return
+	}
+
+	if _, err := h.queries.GetClassByID(c.Request.Context(), int32(id)); err != nil {
+		c.Redirect(http.StatusFound, "/classes")
+		return
+	}
+
+	// 삭제된 원생은 등록 불가
+	if _, err := h.queries.GetStudentByID(c.Request.Context(), int32(studentID)); err != nil {
+		c.Redirect(http.StatusFound, detailURL+"?error=invalid")
+		return
+	}
+
+	// 동시에 배정해도 정원을 넘지 않도록 반 행을 잠근 뒤 인원을 세고 배정
+	ctx := c.Request.Context()
+	err = func() error {
+		tx, err := h.pool.Begin(ctx)
+		if err != nil {
+			return err
+		}
+		defer tx.Rollback(ctx)
+		qtx := h.queries.WithTx(tx)
+
+		if _, err := qtx.LockClass(ctx, int32(id)); err != nil {
+			return err
+		}
+		class, err := qtx.GetClassByID(ctx, int32(id))
+		if err != nil {
+			return err
+		}
+		if class.Capacity.Valid && class.StudentCount >= int64(class.Capacity.Int32) {
+			return errClassFull
+		}
+		if err := qtx.EnrollStudent(ctx, sqlc.EnrollStudentParams{
+			ClassID:   int32(id),
+			StudentID: int32(studentID),
+		}); err != nil {
+			return err
+		}
+		return tx.Commit(ctx)
+	}()
+	if errors.Is(err, errClassFull) {
+		c.Redirect(http.StatusFound, detailURL+"?error=full")
+		return
+	}
+	if err != nil {
+		c.Redirect(http.StatusFound, detailURL+"?error=failed")
+		return
+	}
+
+	c.Redirect(http.StatusFound, detailURL)
+}
+
+// WithdrawStudent removes a student from the class
+func (h *ClassHandler) WithdrawStudent(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 32)
+	if err != nil {
+		c.Redirect(http.StatusFound, "/classes")
+		return
+	}
+
+	studentID, err := strconv.ParseInt(c.Param("student_id"), 10, 32)
+	if err != nil {
+		c.Redirect(http.StatusFound, "/classes/"+c.Param("id"))
+		return
+	}
+
+	h.queries.WithdrawStudent(c.Request.Context(), sqlc.WithdrawStudentParams{
+		ClassID:   int32(id),
+		StudentID: int32(studentID),
+	})
+
+	c.Redirect(http.StatusFound, "/classes/"+c.Param("id"))
+}
+
+func (h *ClassHandler) renderForm(c *gin.Context, status int, title, action string, class *sqlc.GetClassByIDRow, slots []classSlot, errMsg string) {
+	session := sessions.Default(c)
+	username := session.Get("username")
+	role := session.Get("role")
+
+	instructors, err := h.queries.ListUsers(c.Request.Context())
+	if err != nil {
+		c.HTML(http.StatusInternalServerError, "error.html", gin.H{
+			"error": "강사 목록을 불러오는데 실패했습니다.",
+		})
+		return
+	}
+
+	data := gin.H{
+		"title":       title,
+		"action":      action,
+		"class":       nil,
+		"slots":       slots,
+		"instructors": instructors,
+		"error":       errMsg,
+		"username":    username,
+		"role":        role,
+		"currentPage": "classes",
+	}
+	if class != nil {
+		data["class"] = class
+	}
+
+	renderPage(c, status, "class_form.html", data)
+}
+
+func (h *ClassHandler) saveSchedules(c *gin.Context, classID int32, slots []classSlot) error {
+	for _, slot := range slots {
+		if !slot.Checked {
+			continue
+		}
+		start, _ := parseClockTime(slot.Start)
+		end, _ := parseClockTime(slot.End)
+		err := h.queries.CreateClassSchedule(c.Request.Context(), sqlc.CreateClassScheduleParams{
+			ClassID:   classID,
+			Weekday:   slot.Weekday,
+			StartTime: start,
+			EndTime:   end,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// parseClassForm reads and validates the class form. Weekday slots are posted as
+// weekday[<n>]=on, start_time[<n>]=HH:MM and end_time[<n>]=HH:MM for n in 1..7.
+func parseClassForm(c *gin.Context) (sqlc.CreateClassParams, []classSlot, string) {
+	name := c.PostForm("name")
+	description := c.PostForm("description")
+	weekdays := c.PostFormMap("weekday")
+	starts := c.PostFormMap("start_time")
+	ends := c.PostFormMap("end_time")
+
+	slots := make([]classSlot, 0, 7)
+	for day := int16(1); day <= 7; day++ {
+		key := strconv.Itoa(int(day))
+		slots = append(slots, classSlot{
+			Weekday: day,
+			Label:   weekdayNames[day],
+			Checked: weekdays[key] != "",
+			Start:   starts[key],
+			End:     ends[key],
+		})
+	}
+
+	params := sqlc.CreateClassParams{
+		Name:        name,
+		Description: pgtype.Text{String: description, Valid: description != ""},
+	}
+
+	if name == "" {
+		return params, slots, "반 이름을 입력해주세요."
+	}
+
+	if instructorID, err := strconv.ParseInt(c.PostForm("instructor_id"), 10, 32); err == nil {
+		params.InstructorID = pgtype.Int4{Int32: int32(instructorID), Valid: true}
+	}
+
+	if capacityStr := c.PostForm("capacity"); capacityStr != "" {
+		capacity, err := strconv.ParseInt(capacityStr, 10, 32)
+		if err != nil || capacity < 1 {
+			return params, slots, "정원은 1 이상의 숫자로 입력해주세요."
+		}
+		params.Capacity = pgtype.Int4{Int32: int32(capacity), Valid: true}
+	}
+
+	for _, slot := range slots {
+		if !slot.Checked {
+			continue
+		}
+		start, err := parseClockTime(slot.Start)
+		if err != nil {
+			return params, slots, slot.Label + "요일 시작 시간을 입력해주세요."
+		}
+		end, err := parseClockTime(slot.End)
+		if err != nil {
+			return params, slots, slot.Label + "요일 종료 시간을 입력해주세요."
+		}
+		if end.Microseconds <= start.Microseconds {
+			return params, slots, slot.Label + "요일 종료 시간은 시작 시간보다 늦어야 합니다."
+		}
+	}
+
+	return params, slots, ""
+}
@@ -1,26 +1,180 @@
 package handlers
 
 import (
+	"math"
 	"net/http"
+	"strconv"
+	"time"
 
-	"github.com/gin-contrib/sessions"
 	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/choiexe1/hongik-academy/internal/db/sqlc"
+)
+
+// defaultStaleDays is how many days without an evaluation marks a student as needing one
+const defaultStaleDays = 30
+
+const (
+	// dashboardStaleStudents is how many students without a recent evaluation the dashboard
+	// lists; the rest are on /dashboard/stale-students
+	dashboardStaleStudents = 10
+	staleStudentsPerPage   = 50
 )
 
-type DashboardHandler struct{}
+type DashboardHandler struct {
+	queries *sqlc.Queries
+}
+
+func NewDashboardHandler(queries *sqlc.Queries) *DashboardHandler {
+	return &DashboardHandler{queries: queries}
+}
+
+// staleDaysParam reads the ?days=N threshold for students without a recent evaluation and
+// returns it with the start of that period
+func staleDaysParam(c *gin.Context, today time.Time) (int, pgtype.Timestamptz) {
+	staleDays, err := strconv.Atoi(c.DefaultQuery("days", strconv.Itoa(defaultStaleDays)))
+	if err != nil || staleDays < 1 || staleDays > 365 {
+		staleDays = defaultStaleDays
+	}
+	return staleDays, pgtype.Timestamptz{Time: today.AddDate(0, 0, -staleDays), Valid: true}
+}
 
-func NewDashboardHandler() *DashboardHandler {
-	return &DashboardHandler{}
+func startOfDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
 }
 
 func (h *DashboardHandler) ShowDashboard(c *gin.Context) {
-	session := sessions.Default(c)
-	username := session.Get("username")
-	role := session.Get("role")
+	now := time.Now()
+	today := startOfDay(now)
+	monthStart := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, now.Location())
+	// 이번 주는 월요일부터
+	weekStart := today.AddDate(0, 0, -((int(today.Weekday()) + 6) % 7))
+	// 평가 미작성 기준 일수 (?days=N)
+	staleDays, staleSince := staleDaysParam(c, today)
+
+	ctx := c.Request.Context()
+
+	totalStudents, err := h.queries.CountActiveStudents(ctx)
+	if err != nil {
+		c.HTML(http.StatusInternalServerError, "error.html", gin.H{
+			"error": "원생 수를 조회하는데 실패했습니다.",
+		})
+		return
+	}
+
+	newStudents, err := h.queries.CountStudentsCreatedSince(ctx, pgtype.Timestamptz{Time: monthStart, Valid: true})
+	if err != nil {
+		c.HTML(http.StatusInternalServerError, "error.html", gin.H{
+			"error": "신규 등록 원생 수를 조회하는데 실패했습니다.",
+		})
+		return
+	}
+
+	genderRows, err := h.queries.CountStudentsByGender(ctx)
+	if err != nil {
+		c.HTML(http.StatusInternalServerError, "error.html", gin.H{
+			"error": "성별 통계를 조회하는데 실패했습니다.",
+		})
+		return
+	}
+	genderCounts := map[string]int64{}
+	for _, g := range genderRows {
+		genderCounts[g.Gender] = g.Count
+	}
+	malePercent := 0
+	if genderTotal := genderCounts["M"] + genderCounts["F"]; genderTotal > 0 {
+		malePercent = int(genderCounts["M"] * 100 / genderTotal)
+	}
+
+	authorCounts, err := h.queries.CountEvaluationsByAuthorSince(ctx, pgtype.Timestamptz{Time: weekStart, Valid: true})
+	if err != nil {
+		c.HTML(http.StatusInternalServerError, "error.html", gin.H{
+			"error": "강사별 평가 통계를 조회하는데 실패했습니다.",
+		})
+		return
+	}
+	var weeklyEvaluations int64
+	for _, a := range authorCounts {
+		weeklyEvaluations += a.Count
+	}
+
+	staleCount, err := h.queries.CountStudentsWithoutRecentEvaluation(ctx, staleSince)
+	if err != nil {
+		c.HTML(http.StatusInternalServerError, "error.html", gin.H{
+			"error": "평가 미작성 원생을 조회하는데 실패했습니다.",
+		})
+		return
+	}
+	staleStudents, err := h.queries.ListStudentsWithoutRecentEvaluation(ctx, sqlc.ListStudentsWithoutRecentEvaluationParams{
+		Since: staleSince,
+		Limit: dashboardStaleStudents,
+	})
+	if err != nil {
+		c.HTML(http.StatusInternalServerError, "error.html", gin.H{
+			"error": "평가 미작성 원생을 조회하는데 실패했습니다.",
+		})
+		return
+	}
+
+	renderPage(c, http.StatusOK, "dashboard.html", gin.H{
+		"totalStudents":     totalStudents,
+		"newStudents":       newStudents,
+		"maleCount":         genderCounts["M"],
+		"femaleCount":       genderCounts["F"],
+		"malePercent":       malePercent,
+		"authorCounts":      authorCounts,
+		"weeklyEvaluations": weeklyEvaluations,
+		"weekStart":         weekStart.Format("2006-01-02"),
+		"staleStudents":     staleStudents,
+		"staleCount":        staleCount,
+		"staleDays":         staleDays,
+		"currentPage":       "dashboard",
+	})
+}
+
+// ListStaleStudents lists every enrolled student without an evaluation in the last ?days=N
+// days, of which the dashboard shows only the first few
+func (h *DashboardHandler) ListStaleStudents(c *gin.Context) {
+	staleDays, staleSince := staleDaysParam(c, startOfDay(time.Now()))
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	if page < 1 {
+		page = 1
+	}
+
+	ctx := c.Request.Context()
+
+	totalCount, err := h.queries.CountStudentsWithoutRecentEvaluation(ctx, staleSince)
+	if err != nil {
+		c.HTML(http.StatusInternalServerError, "error.html", gin.H{
+			"error": "평가 미작성 원생을 조회하는데 실패했습니다.",
+		})
+		return
+	}
+	// 큰 page 값으로 OFFSET이 넘치지 않도록 마지막 페이지로 맞춤
+	totalPages := int(math.Ceil(float64(totalCount) / float64(staleStudentsPerPage)))
+	if page > totalPages {
+		page = max(totalPages, 1)
+	}
+
+	students, err := h.queries.ListStudentsWithoutRecentEvaluation(ctx, sqlc.ListStudentsWithoutRecentEvaluationParams{
+		Since:  staleSince,
+		Limit:  staleStudentsPerPage,
+		Offset: int32((page - 1) * staleStudentsPerPage),
+	})
+	if err != nil {
+		c.HTML(http.StatusInternalServerError, "error.html", gin.H{
+			"error": "평가 미작성 원생을 조회하는데 실패했습니다.",
+		})
+		return
+	}
 
-	c.HTML(http.StatusOK, "dashboard.html", gin.H{
-		"username":    username,
-		"role":        role,
+	renderPage(c, http.StatusOK, "stale_students.html", gin.H{
+		"students":    students,
+		"staleDays":   staleDays,
+		"page":        page,
+		"totalCount":  totalCount,
+		"totalPages":  totalPages,
 		"currentPage": "dashboard",
 	})
 }
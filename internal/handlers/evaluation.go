@@ -1,6 +1,8 @@
 package handlers
 
 import (
+	"context"
+	"log"
 	"net/http"
 	"strconv"
 	"time"
@@ -8,23 +10,29 @@ import (
 	"github.com/gin-contrib/sessions"
 	"github.com/gin-gonic/gin"
 	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
 
 	"github.com/choiexe1/hongik-academy/internal/db/sqlc"
+	"github.com/choiexe1/hongik-academy/internal/permission"
+	"github.com/choiexe1/hongik-academy/internal/policy"
+	"github.com/choiexe1/hongik-academy/internal/spreadsheet"
+	"github.com/choiexe1/hongik-academy/internal/storage"
 )
 
+// EvaluationHandler needs the pool so an evaluation and its rubric scores are saved in one
+// transaction. Routes for a single evaluation run behind middleware.EvaluationAccess, which
+// puts the checked evaluation in the context.
 type EvaluationHandler struct {
+	pool    *pgxpool.Pool
 	queries *sqlc.Queries
+	store   storage.Storage
 }
 
-func NewEvaluationHandler(queries *sqlc.Queries) *EvaluationHandler {
-	return &EvaluationHandler{queries: queries}
+func NewEvaluationHandler(pool *pgxpool.Pool, queries *sqlc.Queries, store storage.Storage) *EvaluationHandler {
+	return &EvaluationHandler{pool: pool, queries: queries, store: store}
 }
 
 func (h *EvaluationHandler) ListEvaluations(c *gin.Context) {
-	session := sessions.Default(c)
-	username := session.Get("username")
-	role := session.Get("role")
-
 	studentID, err := strconv.ParseInt(c.Param("id"), 10, 32)
 	if err != nil {
 		c.Redirect(http.StatusFound, "/students")
@@ -115,11 +123,57 @@ func (h *EvaluationHandler) ListEvaluations(c *gin.Context) {
 		return
 	}
 
-	c.HTML(http.StatusOK, "evaluations.html", gin.H{
+	// 목록에 보이는 평가표의 첨부 이미지를 한 번에 조회하고, 수정/삭제 버튼은 권한이 있는 평가표에만 표시
+	userID := sessionUserID(c)
+	perms := permission.FromContext(c)
+	evaluationIDs := make([]int32, len(evaluations))
+	editable := make(map[int32]bool, len(evaluations))
+	for i, e := range evaluations {
+		evaluationIDs[i] = e.ID
+		editable[e.ID] = policy.CanEditEvaluation(userID, perms, e.AuthorID)
+	}
+	imageRows, err := h.queries.ListEvaluationImagesByEvaluations(c.Request.Context(), evaluationIDs)
+	if err != nil {
+		c.HTML(http.StatusInternalServerError, "error.html", gin.H{
+			"error": "첨부 이미지를 불러오는데 실패했습니다.",
+		})
+		return
+	}
+	images := make(map[int32][]sqlc.EvaluationImage)
+	for _, img := range imageRows {
+		images[img.EvaluationID] = append(images[img.EvaluationID], img)
+	}
+
+	// 루브릭 점수와 성장 추이 그래프 (그래프는 검색 조건과 관계없이 전체 기간)
+	criteria, err := h.queries.ListRubricCriteria(c.Request.Context())
+	if err != nil {
+		c.HTML(http.StatusInternalServerError, "error.html", gin.H{
+			"error": "평가 기준을 불러오는데 실패했습니다.",
+		})
+		return
+	}
+	scoreRows, err := h.queries.ListEvaluationScoresByEvaluations(c.Request.Context(), evaluationIDs)
+	if err != nil {
+		c.HTML(http.StatusInternalServerError, "error.html", gin.H{
+			"error": "평가 점수를 불러오는데 실패했습니다.",
+		})
+		return
+	}
+	progressRows, err := h.queries.ListScoreProgressByStudent(c.Request.Context(), student.ID)
+	if err != nil {
+		c.HTML(http.StatusInternalServerError, "error.html", gin.H{
+			"error": "평가 점수를 불러오는데 실패했습니다.",
+		})
+		return
+	}
+
+	renderPage(c, http.StatusOK, "evaluations.html", gin.H{
 		"student":     student,
 		"evaluations": evaluations,
-		"username":    username,
-		"role":        role,
+		"images":      images,
+		"editable":    editable,
+		"scores":      evaluationScoreBadges(criteria, scoreRows),
+		"progress":    buildScoreProgress(criteria, progressRows),
 		"currentPage": "students",
 		"page":        page,
 		"limit":       limit,
@@ -131,10 +185,56 @@ func (h *EvaluationHandler) ListEvaluations(c *gin.Context) {
 	})
 }
 
+// ExportEvaluations downloads a student's evaluations matching the list filters as CSV or
+// XLSX (format=xlsx)
+func (h *EvaluationHandler) ExportEvaluations(c *gin.Context) {
+	studentID, err := strconv.ParseInt(c.Param("id"), 10, 32)
+	if err != nil {
+		c.Redirect(http.StatusFound, "/students")
+		return
+	}
+
+	student, err := h.queries.GetStudentByID(c.Request.Context(), int32(studentID))
+	if err != nil {
+		c.HTML(http.StatusNotFound, "error.html", gin.H{
+			"error": "원생을 찾을 수 없습니다.",
+		})
+		return
+	}
+
+	var searchParam pgtype.Text
+	if search := c.Query("search"); search != "" {
+		searchParam = pgtype.Text{String: search, Valid: true}
+	}
+	var startDateParam pgtype.Date
+	if t, err := time.Parse("2006-01-02", c.Query("start_date")); err == nil {
+		startDateParam = pgtype.Date{Time: t, Valid: true}
+	}
+	var endDateParam pgtype.Date
+	if t, err := time.Parse("2006-01-02", c.Query("end_date")); err == nil {
+		endDateParam = pgtype.Date{Time: t, Valid: true}
+	}
+
+	evaluations, err := h.queries.ExportEvaluationsByStudent(c.Request.Context(), sqlc.ExportEvaluationsByStudentParams{
+		StudentID: student.ID,
+		Search:    searchParam,
+		StartDate: startDateParam,
+		EndDate:   endDateParam,
+	})
+	if err != nil {
+		c.HTML(http.StatusInternalServerError, "error.html", gin.H{
+			"error": "평가표 목록을 불러오는데 실패했습니다.",
+		})
+		return
+	}
+
+	filename := student.Name + "_평가표_" + time.Now().Format("20060102")
+	sendSpreadsheet(c, spreadsheet.ParseFormat(c.Query("format")), filename, "평가표", evaluationExportHeader, evaluationExportRows(evaluations))
+}
+
 func (h *EvaluationHandler) ShowCreateForm(c *gin.Context) {
 	session := sessions.Default(c)
-	username := session.Get("username")
-	role := session.Get("role")
+	userID := session.Get("user_id").(int32)
 
 	studentID, err := strconv.ParseInt(c.Param("id"), 10, 32)
 	if err != nil {
@@ -150,22 +250,51 @@ func (h *EvaluationHandler) ShowCreateForm(c *gin.Context) {
 		return
 	}
 
-	c.HTML(http.StatusOK, "evaluation_form.html", gin.H{
-		"title":       "평가표 작성",
-		"action":      "/students/" + c.Param("id") + "/evaluations",
-		"student":     student,
-		"evaluation":  nil,
-		"username":    username,
-		"role":        role,
-		"currentPage": "students",
+	criteria, err := h.queries.ListRubricCriteria(c.Request.Context())
+	if err != nil {
+		c.HTML(http.StatusInternalServerError, "error.html", gin.H{
+			"error": "평가 기준을 불러오는데 실패했습니다.",
+		})
+		return
+	}
+
+	templates, err := h.queries.ListEvaluationTemplatesForUser(c.Request.Context(), pgtype.Int4{Int32: userID, Valid: true})
+	if err != nil {
+		c.HTML(http.StatusInternalServerError, "error.html", gin.H{
+			"error": "템플릿 목록을 불러오는데 실패했습니다.",
+		})
+		return
+	}
+
+	// ?template=ID로 선택한 템플릿의 내용을 원생에 맞게 채워서 미리 입력
+	var content string
+	var selectedTemplate int32
+	if templateID, err := strconv.ParseInt(c.Query("template"), 10, 32); err == nil {
+		for _, t := range templates {
+			if t.ID == int32(templateID) {
+				content = expandEvaluationTemplate(t.Content, student, time.Now())
+				selectedTemplate = t.ID
+				break
+			}
+		}
+	}
+
+	renderPage(c, http.StatusOK, "evaluation_form.html", gin.H{
+		"title":            "평가표 작성",
+		"action":           "/students/" + c.Param("id") + "/evaluations",
+		"student":          student,
+		"evaluation":       nil,
+		"content":          content,
+		"templates":        templates,
+		"selectedTemplate": selectedTemplate,
+		"scoreInputs":      rubricScoreInputs(criteria, nil),
+		"currentPage":      "students",
 	})
 }
 
 func (h *EvaluationHandler) CreateEvaluation(c *gin.Context) {
 	session := sessions.Default(c)
 	userID := session.Get("user_id").(int32)
-	username := session.Get("username")
-	role := session.Get("role")
 
 	studentID, err := strconv.ParseInt(c.Param("id"), 10, 32)
 	if err != nil {
@@ -181,162 +310,271 @@ func (h *EvaluationHandler) CreateEvaluation(c *gin.Context) {
 		return
 	}
 
+	criteria, err := h.queries.ListRubricCriteria(c.Request.Context())
+	if err != nil {
+		c.HTML(http.StatusInternalServerError, "error.html", gin.H{
+			"error": "평가 기준을 불러오는데 실패했습니다.",
+		})
+		return
+	}
+	scoreInputs := rubricScoreInputs(criteria, nil)
+
+	uploads, uploadErr := parseEvaluationImageUploads(c, 0)
+
 	content := c.PostForm("content")
+	scores, errMsg := parseEvaluationScores(c, scoreInputs)
 	if content == "" {
-		c.HTML(http.StatusBadRequest, "evaluation_form.html", gin.H{
+		errMsg = "평가 내용을 입력해주세요."
+	} else if errMsg == "" {
+		errMsg = uploadErr
+	}
+	if errMsg != "" {
+		renderPage(c, http.StatusBadRequest, "evaluation_form.html", gin.H{
 			"title":       "평가표 작성",
 			"action":      "/students/" + c.Param("id") + "/evaluations",
 			"student":     student,
-			"error":       "평가 내용을 입력해주세요.",
-			"username":    username,
-			"role":        role,
+			"content":     content,
+			"scoreInputs": rubricScoreInputs(criteria, scores),
+			"error":       errMsg,
 			"currentPage": "students",
 		})
 		return
 	}
 
-	_, err = h.queries.CreateEvaluation(c.Request.Context(), sqlc.CreateEvaluationParams{
-		StudentID: int32(studentID),
-		AuthorID:  userID,
-		Content:   content,
-	})
+	// 평가 내용과 점수는 함께 저장되어야 함
+	ctx := c.Request.Context()
+	evaluation, err := func() (sqlc.Evaluation, error) {
+		tx, err := h.pool.Begin(ctx)
+		if err != nil {
+			return sqlc.Evaluation{}, err
+		}
+		defer tx.Rollback(ctx)
+		qtx := h.queries.WithTx(tx)
+
+		evaluation, err := qtx.CreateEvaluation(ctx, sqlc.CreateEvaluationParams{
+			StudentID: int32(studentID),
+			AuthorID:  userID,
+			Content:   content,
+		})
+		if err != nil {
+			return sqlc.Evaluation{}, err
+		}
+		if err := saveEvaluationRevision(ctx, qtx, evaluation, userID); err != nil {
+			return sqlc.Evaluation{}, err
+		}
+		if err := saveEvaluationScores(ctx, qtx, evaluation.ID, scoreInputs, scores); err != nil {
+			return sqlc.Evaluation{}, err
+		}
+		return evaluation, tx.Commit(ctx)
+	}()
 
 	if err != nil {
-		c.HTML(http.StatusInternalServerError, "evaluation_form.html", gin.H{
+		renderPage(c, http.StatusInternalServerError, "evaluation_form.html", gin.H{
 			"title":       "평가표 작성",
 			"action":      "/students/" + c.Param("id") + "/evaluations",
 			"student":     student,
+			"content":     content,
+			"scoreInputs": rubricScoreInputs(criteria, scores),
 			"error":       "평가표 저장에 실패했습니다.",
-			"username":    username,
-			"role":        role,
 			"currentPage": "students",
 		})
 		return
 	}
 
+	recordAudit(ctx, h.queries, userID, auditActionCreate, auditEntityEvaluation, evaluation.ID, nil, evaluation)
+
+	if err := storeEvaluationImages(ctx, h.queries, h.store, evaluation.ID, userID, uploads); err != nil {
+		log.Printf("evaluation %d: store images: %v", evaluation.ID, err)
+		c.HTML(http.StatusInternalServerError, "error.html", gin.H{
+			"error": "평가표는 저장되었지만 이미지를 저장하지 못했습니다. 평가표 수정 화면에서 이미지를 다시 올려주세요.",
+		})
+		return
+	}
+
 	c.Redirect(http.StatusFound, "/students/"+c.Param("id")+"/evaluations")
 }
 
 func (h *EvaluationHandler) ShowEditForm(c *gin.Context) {
-	session := sessions.Default(c)
-	username := session.Get("username")
-	role := session.Get("role")
-
 	studentID, err := strconv.ParseInt(c.Param("id"), 10, 32)
 	if err != nil {
 		c.Redirect(http.StatusFound, "/students")
 		return
 	}
 
-	evalID, err := strconv.ParseInt(c.Param("eval_id"), 10, 32)
+	student, err := h.queries.GetStudentByID(c.Request.Context(), int32(studentID))
 	if err != nil {
-		c.Redirect(http.StatusFound, "/students/"+c.Param("id")+"/evaluations")
+		c.HTML(http.StatusNotFound, "error.html", gin.H{
+			"error": "원생을 찾을 수 없습니다.",
+		})
 		return
 	}
 
-	student, err := h.queries.GetStudentByID(c.Request.Context(), int32(studentID))
+	evaluation := c.MustGet("evaluation").(sqlc.GetEvaluationByIDRow)
+
+	images, err := h.queries.ListEvaluationImagesByEvaluation(c.Request.Context(), evaluation.ID)
 	if err != nil {
-		c.HTML(http.StatusNotFound, "error.html", gin.H{
-			"error": "원생을 찾을 수 없습니다.",
+		c.HTML(http.StatusInternalServerError, "error.html", gin.H{
+			"error": "첨부 이미지를 불러오는데 실패했습니다.",
 		})
 		return
 	}
 
-	evaluation, err := h.queries.GetEvaluationByID(c.Request.Context(), int32(evalID))
+	criteria, scores, err := h.loadScores(c.Request.Context(), evaluation.ID)
 	if err != nil {
-		c.Redirect(http.StatusFound, "/students/"+c.Param("id")+"/evaluations")
+		c.HTML(http.StatusInternalServerError, "error.html", gin.H{
+			"error": "평가 점수를 불러오는데 실패했습니다.",
+		})
 		return
 	}
 
-	c.HTML(http.StatusOK, "evaluation_form.html", gin.H{
+	renderPage(c, http.StatusOK, "evaluation_form.html", gin.H{
 		"title":       "평가표 수정",
 		"action":      "/students/" + c.Param("id") + "/evaluations/" + c.Param("eval_id"),
 		"student":     student,
 		"evaluation":  evaluation,
-		"username":    username,
-		"role":        role,
+		"images":      images,
+		"scoreInputs": rubricScoreInputs(criteria, scores),
 		"currentPage": "students",
 	})
 }
 
 func (h *EvaluationHandler) UpdateEvaluation(c *gin.Context) {
-	session := sessions.Default(c)
-	username := session.Get("username")
-	role := session.Get("role")
-
 	studentID, err := strconv.ParseInt(c.Param("id"), 10, 32)
 	if err != nil {
 		c.Redirect(http.StatusFound, "/students")
 		return
 	}
 
-	evalID, err := strconv.ParseInt(c.Param("eval_id"), 10, 32)
+	student, err := h.queries.GetStudentByID(c.Request.Context(), int32(studentID))
 	if err != nil {
-		c.Redirect(http.StatusFound, "/students/"+c.Param("id")+"/evaluations")
+		c.HTML(http.StatusNotFound, "error.html", gin.H{
+			"error": "원생을 찾을 수 없습니다.",
+		})
 		return
 	}
 
-	student, err := h.queries.GetStudentByID(c.Request.Context(), int32(studentID))
+	evaluation := c.MustGet("evaluation").(sqlc.GetEvaluationByIDRow)
+
+	images, err := h.queries.ListEvaluationImagesByEvaluation(c.Request.Context(), evaluation.ID)
 	if err != nil {
-		c.HTML(http.StatusNotFound, "error.html", gin.H{
-			"error": "원생을 찾을 수 없습니다.",
+		c.HTML(http.StatusInternalServerError, "error.html", gin.H{
+			"error": "첨부 이미지를 불러오는데 실패했습니다.",
 		})
 		return
 	}
 
-	evaluation, err := h.queries.GetEvaluationByID(c.Request.Context(), int32(evalID))
+	criteria, existingScores, err := h.loadScores(c.Request.Context(), evaluation.ID)
 	if err != nil {
-		c.Redirect(http.StatusFound, "/students/"+c.Param("id")+"/evaluations")
+		c.HTML(http.StatusInternalServerError, "error.html", gin.H{
+			"error": "평가 점수를 불러오는데 실패했습니다.",
+		})
 		return
 	}
+	scoreInputs := rubricScoreInputs(criteria, existingScores)
+
+	uploads, uploadErr := parseEvaluationImageUploads(c, len(images))
 
 	content := c.PostForm("content")
+	scores, errMsg := parseEvaluationScores(c, scoreInputs)
 	if content == "" {
-		c.HTML(http.StatusBadRequest, "evaluation_form.html", gin.H{
+		errMsg = "평가 내용을 입력해주세요."
+	} else if errMsg == "" {
+		errMsg = uploadErr
+	}
+	if errMsg != "" {
+		renderPage(c, http.StatusBadRequest, "evaluation_form.html", gin.H{
 			"title":       "평가표 수정",
 			"action":      "/students/" + c.Param("id") + "/evaluations/" + c.Param("eval_id"),
 			"student":     student,
 			"evaluation":  evaluation,
-			"error":       "평가 내용을 입력해주세요.",
-			"username":    username,
-			"role":        role,
+			"images":      images,
+			"scoreInputs": withSubmittedScores(scoreInputs, scores),
+			"error":       errMsg,
 			"currentPage": "students",
 		})
 		return
 	}
 
-	_, err = h.queries.UpdateEvaluation(c.Request.Context(), sqlc.UpdateEvaluationParams{
-		ID:      int32(evalID),
-		Content: content,
-	})
+	ctx := c.Request.Context()
+	updated, err := func() (sqlc.Evaluation, error) {
+		tx, err := h.pool.Begin(ctx)
+		if err != nil {
+			return sqlc.Evaluation{}, err
+		}
+		defer tx.Rollback(ctx)
+		qtx := h.queries.WithTx(tx)
+
+		updated, err := qtx.UpdateEvaluation(ctx, sqlc.UpdateEvaluationParams{
+			ID:      evaluation.ID,
+			Content: content,
+		})
+		if err != nil {
+			return sqlc.Evaluation{}, err
+		}
+		// 점수만 바꾼 경우에는 새 버전을 남기지 않음
+		if updated.Content != evaluation.Content {
+			if err := saveEvaluationRevision(ctx, qtx, updated, sessionUserID(c)); err != nil {
+				return sqlc.Evaluation{}, err
+			}
+		}
+		if err := saveEvaluationScores(ctx, qtx, updated.ID, scoreInputs, scores); err != nil {
+			return sqlc.Evaluation{}, err
+		}
+		return updated, tx.Commit(ctx)
+	}()
 
 	if err != nil {
-		c.HTML(http.StatusInternalServerError, "evaluation_form.html", gin.H{
+		renderPage(c, http.StatusInternalServerError, "evaluation_form.html", gin.H{
 			"title":       "평가표 수정",
 			"action":      "/students/" + c.Param("id") + "/evaluations/" + c.Param("eval_id"),
 			"student":     student,
 			"evaluation":  evaluation,
+			"images":      images,
+			"scoreInputs": withSubmittedScores(scoreInputs, scores),
 			"error":       "평가표 수정에 실패했습니다.",
-			"username":    username,
-			"role":        role,
 			"currentPage": "students",
 		})
 		return
 	}
 
+	recordAudit(ctx, h.queries, sessionUserID(c), auditActionUpdate, auditEntityEvaluation, updated.ID, auditEvaluation(evaluation), updated)
+
+	if err := storeEvaluationImages(ctx, h.queries, h.store, updated.ID, sessionUserID(c), uploads); err != nil {
+		log.Printf("evaluation %d: store images: %v", updated.ID, err)
+		c.HTML(http.StatusInternalServerError, "error.html", gin.H{
+			"error": "평가 내용은 수정되었지만 이미지를 저장하지 못했습니다. 다시 시도해주세요.",
+		})
+		return
+	}
+
 	c.Redirect(http.StatusFound, "/students/"+c.Param("id")+"/evaluations")
 }
 
+// loadScores returns all rubric criteria and the evaluation's scores keyed by criterion id
+func (h *EvaluationHandler) loadScores(ctx context.Context, evaluationID int32) ([]sqlc.RubricCriterion, map[int32]int32, error) {
+	criteria, err := h.queries.ListRubricCriteria(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	rows, err := h.queries.ListEvaluationScores(ctx, evaluationID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	scores := make(map[int32]int32, len(rows))
+	for _, r := range rows {
+		scores[r.CriterionID] = r.Score
+	}
+	return criteria, scores, nil
+}
+
 func (h *EvaluationHandler) DeleteEvaluation(c *gin.Context) {
 	studentID := c.Param("id")
+	evaluation := c.MustGet("evaluation").(sqlc.GetEvaluationByIDRow)
 
-	evalID, err := strconv.ParseInt(c.Param("eval_id"), 10, 32)
-	if err != nil {
-		c.Redirect(http.StatusFound, "/students/"+studentID+"/evaluations")
-		return
+	if err := h.queries.DeleteEvaluation(c.Request.Context(), evaluation.ID); err == nil {
+		recordAudit(c.Request.Context(), h.queries, sessionUserID(c), auditActionDelete, auditEntityEvaluation, evaluation.ID, auditEvaluation(evaluation), nil)
 	}
 
-	h.queries.DeleteEvaluation(c.Request.Context(), int32(evalID))
-
 	c.Redirect(http.StatusFound, "/students/"+studentID+"/evaluations")
 }
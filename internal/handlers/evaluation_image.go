@@ -0,0 +1,305 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"math"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/choiexe1/hongik-academy/internal/db/sqlc"
+	"github.com/choiexe1/hongik-academy/internal/imaging"
+	"github.com/choiexe1/hongik-academy/internal/storage"
+)
+
+const (
+	// evaluationImageMaxFileSize limits each uploaded image to 10MB
+	evaluationImageMaxFileSize = 10 << 20
+	// evaluationImageMaxCount limits how many images one evaluation may have
+	evaluationImageMaxCount = 10
+	// evaluationThumbnailSize is the longest side of a generated thumbnail in pixels
+	evaluationThumbnailSize = 400
+	galleryPerPage          = 24
+)
+
+// evaluationImageExtensions maps the detected image format to the stored file extension
+var evaluationImageExtensions = map[string]string{
+	"jpeg": ".jpg",
+	"png":  ".png",
+	"gif":  ".gif",
+	"webp": ".webp",
+}
+
+// evaluationImageUpload is an uploaded image that passed validation and has its thumbnail
+// rendered, so nothing is written until every file in the request is known to be good
+type evaluationImageUpload struct {
+	name      string
+	info      imaging.Info
+	data      []byte
+	thumbnail []byte
+}
+
+// prepareEvaluationImages reads and validates the uploaded files. existing is the number of
+// images the evaluation already has; the returned message is shown to the user.
+func prepareEvaluationImages(files []*multipart.FileHeader, existing int) ([]evaluationImageUpload, string) {
+	var uploads []evaluationImageUpload
+	for _, file := range files {
+		// 파일을 선택하지 않고 제출한 경우 빈 항목이 올 수 있음
+		if file.Filename == "" && file.Size == 0 {
+			continue
+		}
+		if file.Size > evaluationImageMaxFileSize {
+			return nil, fmt.Sprintf("%s: 이미지 크기는 10MB 이하여야 합니다.", file.Filename)
+		}
+
+		f, err := file.Open()
+		if err != nil {
+			return nil, fmt.Sprintf("%s: 파일을 열 수 없습니다.", file.Filename)
+		}
+		data, err := io.ReadAll(io.LimitReader(f, evaluationImageMaxFileSize+1))
+		f.Close()
+		if err != nil {
+			return nil, fmt.Sprintf("%s: 파일을 읽는 중 오류가 발생했습니다.", file.Filename)
+		}
+		if len(data) > evaluationImageMaxFileSize {
+			return nil, fmt.Sprintf("%s: 이미지 크기는 10MB 이하여야 합니다.", file.Filename)
+		}
+
+		info, err := imaging.Inspect(data)
+		if errors.Is(err, imaging.ErrTooLarge) {
+			return nil, fmt.Sprintf("%s: 해상도가 너무 큽니다. 4천만 화소 이하로 줄여서 올려주세요.", file.Filename)
+		}
+		if err != nil {
+			return nil, fmt.Sprintf("%s: JPG, PNG, GIF, WebP 이미지만 올릴 수 있습니다.", file.Filename)
+		}
+		thumbnail, err := imaging.Thumbnail(data, evaluationThumbnailSize)
+		if err != nil {
+			return nil, fmt.Sprintf("%s: 이미지를 처리하는 중 오류가 발생했습니다.", file.Filename)
+		}
+
+		uploads = append(uploads, evaluationImageUpload{name: file.Filename, info: info, data: data, thumbnail: thumbnail})
+	}
+
+	if existing+len(uploads) > evaluationImageMaxCount {
+		return nil, fmt.Sprintf("평가표 하나에 이미지는 최대 %d장까지 첨부할 수 있습니다.", evaluationImageMaxCount)
+	}
+	return uploads, ""
+}
+
+// storeEvaluationImages writes the files to storage and records them. Files of an image
+// whose row could not be inserted are removed again so storage holds no orphans.
+func storeEvaluationImages(ctx context.Context, queries *sqlc.Queries, store storage.Storage, evaluationID, userID int32, uploads []evaluationImageUpload) error {
+	for _, u := range uploads {
+		name, err := randomImageName()
+		if err != nil {
+			return err
+		}
+		base := fmt.Sprintf("evaluations/%d/%s", evaluationID, name)
+		key := base + evaluationImageExtensions[u.info.Format]
+		thumbnailKey := base + "_thumb.jpg"
+
+		if err := store.Put(ctx, key, bytes.NewReader(u.data), u.info.ContentType); err != nil {
+			return err
+		}
+		if err := store.Put(ctx, thumbnailKey, bytes.NewReader(u.thumbnail), "image/jpeg"); err != nil {
+			deleteStoredFiles(ctx, store, key)
+			return err
+		}
+
+		_, err = queries.CreateEvaluationImage(ctx, sqlc.CreateEvaluationImageParams{
+			EvaluationID: evaluationID,
+			StorageKey:   key,
+			ThumbnailKey: thumbnailKey,
+			OriginalName: u.name,
+			ContentType:  u.info.ContentType,
+			SizeBytes:    int64(len(u.data)),
+			Width:        int32(u.info.Width),
+			Height:       int32(u.info.Height),
+			UploadedBy:   pgtype.Int4{Int32: userID, Valid: true},
+		})
+		if err != nil {
+			deleteStoredFiles(ctx, store, key, thumbnailKey)
+			return err
+		}
+	}
+	return nil
+}
+
+// deleteStoredFiles removes files whose rows are gone; failures only leave unused files
+// behind, so they are logged instead of failing the request
+func deleteStoredFiles(ctx context.Context, store storage.Storage, keys ...string) {
+	for _, key := range keys {
+		if err := store.Delete(ctx, key); err != nil {
+			log.Printf("storage: %v", err)
+		}
+	}
+}
+
+func randomImageName() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// EvaluationImageHandler serves evaluation images and the per-student gallery
+type EvaluationImageHandler struct {
+	queries *sqlc.Queries
+	store   storage.Storage
+}
+
+func NewEvaluationImageHandler(queries *sqlc.Queries, store storage.Storage) *EvaluationImageHandler {
+	return &EvaluationImageHandler{queries: queries, store: store}
+}
+
+// ShowGallery lists every image attached to the student's evaluations, newest evaluation first
+func (h *EvaluationImageHandler) ShowGallery(c *gin.Context) {
+	studentID, err := strconv.ParseInt(c.Param("id"), 10, 32)
+	if err != nil {
+		c.Redirect(http.StatusFound, "/students")
+		return
+	}
+
+	student, err := h.queries.GetStudentByID(c.Request.Context(), int32(studentID))
+	if err != nil {
+		c.HTML(http.StatusNotFound, "error.html", gin.H{
+			"error": "원생을 찾을 수 없습니다.",
+		})
+		return
+	}
+
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	if page < 1 {
+		page = 1
+	}
+
+	totalCount, err := h.queries.CountEvaluationImagesByStudent(c.Request.Context(), student.ID)
+	if err != nil {
+		c.HTML(http.StatusInternalServerError, "error.html", gin.H{
+			"error": "작품 이미지를 불러오는데 실패했습니다.",
+		})
+		return
+	}
+
+	images, err := h.queries.ListEvaluationImagesByStudent(c.Request.Context(), sqlc.ListEvaluationImagesByStudentParams{
+		StudentID: student.ID,
+		Limit:     galleryPerPage,
+		Offset:    int32((page - 1) * galleryPerPage),
+	})
+	if err != nil {
+		c.HTML(http.StatusInternalServerError, "error.html", gin.H{
+			"error": "작품 이미지를 불러오는데 실패했습니다.",
+		})
+		return
+	}
+
+	renderPage(c, http.StatusOK, "student_gallery.html", gin.H{
+		"student":     student,
+		"images":      images,
+		"page":        page,
+		"totalCount":  totalCount,
+		"totalPages":  int(math.Ceil(float64(totalCount) / float64(galleryPerPage))),
+		"currentPage": "students",
+	})
+}
+
+func (h *EvaluationImageHandler) ServeImage(c *gin.Context) {
+	image, ok := h.loadImage(c)
+	if !ok {
+		return
+	}
+
+	h.serve(c, image.StorageKey, image.ContentType, image.SizeBytes, map[string]string{
+		"Content-Disposition": fmt.Sprintf(`inline; filename="image"; filename*=UTF-8''%s`, url.PathEscape(image.OriginalName)),
+	})
+}
+
+func (h *EvaluationImageHandler) ServeThumbnail(c *gin.Context) {
+	image, ok := h.loadImage(c)
+	if !ok {
+		return
+	}
+
+	h.serve(c, image.ThumbnailKey, "image/jpeg", -1, nil)
+}
+
+// DeleteImage removes one image from an evaluation and returns to its edit form
+func (h *EvaluationImageHandler) DeleteImage(c *gin.Context) {
+	image, ok := h.loadImage(c)
+	if !ok {
+		return
+	}
+
+	if err := h.queries.DeleteEvaluationImage(c.Request.Context(), image.ID); err == nil {
+		deleteStoredFiles(c.Request.Context(), h.store, image.StorageKey, image.ThumbnailKey)
+	}
+
+	c.Redirect(http.StatusFound, "/students/"+c.Param("id")+"/evaluations/"+c.Param("eval_id")+"/edit")
+}
+
+// loadImage looks up :image_id and checks that it belongs to the evaluation that
+// middleware.EvaluationAccess already matched to student :id
+func (h *EvaluationImageHandler) loadImage(c *gin.Context) (sqlc.GetEvaluationImageByIDRow, bool) {
+	evaluation := c.MustGet("evaluation").(sqlc.GetEvaluationByIDRow)
+
+	imageID, err := strconv.ParseInt(c.Param("image_id"), 10, 32)
+	if err != nil {
+		c.Status(http.StatusNotFound)
+		return sqlc.GetEvaluationImageByIDRow{}, false
+	}
+
+	image, err := h.queries.GetEvaluationImageByID(c.Request.Context(), int32(imageID))
+	if err != nil || image.EvaluationID != evaluation.ID {
+		c.Status(http.StatusNotFound)
+		return sqlc.GetEvaluationImageByIDRow{}, false
+	}
+	return image, true
+}
+
+func (h *EvaluationImageHandler) serve(c *gin.Context, key, contentType string, size int64, headers map[string]string) {
+	f, err := h.store.Open(c.Request.Context(), key)
+	if err != nil {
+		if !errors.Is(err, storage.ErrNotFound) {
+			log.Printf("storage: %v", err)
+		}
+		c.Status(http.StatusNotFound)
+		return
+	}
+	defer f.Close()
+
+	// 이미지는 바뀌지 않으므로 브라우저 캐시를 허용하되 공유 캐시에는 남기지 않음
+	c.Header("Cache-Control", "private, max-age=86400")
+	c.Header("X-Content-Type-Options", "nosniff")
+	c.DataFromReader(http.StatusOK, size, contentType, f, headers)
+}
+
+// evaluationMaxRequestSize caps the whole evaluation form so one request cannot carry more
+// than the per-image and per-evaluation limits allow
+const evaluationMaxRequestSize = evaluationImageMaxCount*evaluationImageMaxFileSize + 1<<20
+
+// parseEvaluationImageUploads reads the "images" files of the evaluation form. It must run
+// before any other form field is read so the request size limit applies to the parse.
+func parseEvaluationImageUploads(c *gin.Context, existing int) ([]evaluationImageUpload, string) {
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, evaluationMaxRequestSize)
+
+	form, err := c.MultipartForm()
+	if errors.Is(err, http.ErrNotMultipart) {
+		return nil, ""
+	}
+	if err != nil {
+		return nil, "첨부한 이미지의 전체 용량이 너무 큽니다."
+	}
+	return prepareEvaluationImages(form.File["images"], existing)
+}
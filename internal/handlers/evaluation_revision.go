@@ -0,0 +1,131 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/choiexe1/hongik-academy/internal/db/sqlc"
+	"github.com/choiexe1/hongik-academy/internal/permission"
+	"github.com/choiexe1/hongik-academy/internal/policy"
+	"github.com/choiexe1/hongik-academy/internal/textdiff"
+)
+
+// saveEvaluationRevision records the evaluation's current content as a new version. It runs
+// in the same transaction as the write that produced the content.
+func saveEvaluationRevision(ctx context.Context, queries *sqlc.Queries, evaluation sqlc.Evaluation, editorID int32) error {
+	return queries.CreateEvaluationRevision(ctx, sqlc.CreateEvaluationRevisionParams{
+		EvaluationID: evaluation.ID,
+		Content:      evaluation.Content,
+		EditedBy:     pgtype.Int4{Int32: editorID, Valid: editorID != 0},
+	})
+}
+
+// evaluationRevisionView is one version on the history page with its changes against the
+// version before it
+type evaluationRevisionView struct {
+	sqlc.ListEvaluationRevisionsRow
+	Number  int
+	Current bool
+	Diff    []textdiff.Op
+}
+
+// buildRevisionViews numbers the revisions (newest first) and diffs each against the next
+// older one; the first version has no diff
+func buildRevisionViews(revisions []sqlc.ListEvaluationRevisionsRow) []evaluationRevisionView {
+	views := make([]evaluationRevisionView, len(revisions))
+	for i, r := range revisions {
+		views[i] = evaluationRevisionView{
+			ListEvaluationRevisionsRow: r,
+			Number:                     len(revisions) - i,
+			Current:                    i == 0,
+		}
+		if i+1 < len(revisions) {
+			views[i].Diff = textdiff.Diff(revisions[i+1].Content, r.Content)
+		}
+	}
+	return views
+}
+
+// ShowHistory lists every saved version of an evaluation with what changed in each
+func (h *EvaluationHandler) ShowHistory(c *gin.Context) {
+	evaluation := c.MustGet("evaluation").(sqlc.GetEvaluationByIDRow)
+
+	revisions, err := h.queries.ListEvaluationRevisions(c.Request.Context(), evaluation.ID)
+	if err != nil {
+		c.HTML(http.StatusInternalServerError, "error.html", gin.H{
+			"error": "수정 이력을 불러오는데 실패했습니다.",
+		})
+		return
+	}
+
+	renderPage(c, http.StatusOK, "evaluation_history.html", gin.H{
+		"evaluation":  evaluation,
+		"revisions":   buildRevisionViews(revisions),
+		"canEdit":     policy.CanEditEvaluation(sessionUserID(c), permission.FromContext(c), evaluation.AuthorID),
+		"currentPage": "students",
+	})
+}
+
+// RevertEvaluation restores the content of an earlier version. The restored content is
+// saved as a new version, so the history itself is never rewritten.
+func (h *EvaluationHandler) RevertEvaluation(c *gin.Context) {
+	evaluation := c.MustGet("evaluation").(sqlc.GetEvaluationByIDRow)
+	historyURL := "/students/" + c.Param("id") + "/evaluations/" + c.Param("eval_id") + "/history"
+
+	revisionID, err := strconv.ParseInt(c.Param("revision_id"), 10, 32)
+	if err != nil {
+		c.Redirect(http.StatusFound, historyURL)
+		return
+	}
+
+	revision, err := h.queries.GetEvaluationRevisionByID(c.Request.Context(), int32(revisionID))
+	if err != nil || revision.EvaluationID != evaluation.ID {
+		c.HTML(http.StatusNotFound, "error.html", gin.H{
+			"error": "해당 버전을 찾을 수 없습니다.",
+		})
+		return
+	}
+
+	// 이미 같은 내용이면 새 버전을 만들지 않음
+	if revision.Content == evaluation.Content {
+		c.Redirect(http.StatusFound, historyURL)
+		return
+	}
+
+	ctx := c.Request.Context()
+	userID := sessionUserID(c)
+	updated, err := func() (sqlc.Evaluation, error) {
+		tx, err := h.pool.Begin(ctx)
+		if err != nil {
+			return sqlc.Evaluation{}, err
+		}
+		defer tx.Rollback(ctx)
+		qtx := h.queries.WithTx(tx)
+
+		updated, err := qtx.UpdateEvaluation(ctx, sqlc.UpdateEvaluationParams{
+			ID:      evaluation.ID,
+			Content: revision.Content,
+		})
+		if err != nil {
+			return sqlc.Evaluation{}, err
+		}
+		if err := saveEvaluationRevision(ctx, qtx, updated, userID); err != nil {
+			return sqlc.Evaluation{}, err
+		}
+		return updated, tx.Commit(ctx)
+	}()
+	if err != nil {
+		c.HTML(http.StatusInternalServerError, "error.html", gin.H{
+			"error": "이전 버전으로 되돌리지 못했습니다.",
+		})
+		return
+	}
+
+	recordAudit(ctx, h.queries, userID, auditActionUpdate, auditEntityEvaluation, updated.ID, auditEvaluation(evaluation), updated)
+
+	c.Redirect(http.StatusFound, historyURL)
+}
@@ -0,0 +1,212 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-contrib/sessions"
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/choiexe1/hongik-academy/internal/db/sqlc"
+	"github.com/choiexe1/hongik-academy/internal/permission"
+)
+
+// Evaluation template scopes as submitted by the template form
+const (
+	templateScopePersonal = "personal"
+	templateScopeAcademy  = "academy"
+)
+
+// expandEvaluationTemplate fills the placeholders of a template for one student.
+// Unknown placeholders are left as they are so the instructor notices them.
+func expandEvaluationTemplate(content string, student sqlc.Student, date time.Time) string {
+	return strings.NewReplacer(
+		"{{student_name}}", student.Name,
+		"{{date}}", date.Format("2006년 1월 2일"),
+	).Replace(content)
+}
+
+// canUseTemplate reports whether the user may pick the template: academy-wide templates
+// are visible to everyone, personal ones only to their owner
+func canUseTemplate(t sqlc.EvaluationTemplate, userID int32) bool {
+	return !t.OwnerID.Valid || t.OwnerID.Int32 == userID
+}
+
+// canManageTemplate reports whether the user may edit or delete the template. Academy-wide
+// templates need templates.manage.
+func canManageTemplate(t sqlc.EvaluationTemplate, userID int32, perms permission.Set) bool {
+	if !t.OwnerID.Valid {
+		return perms.Has(permission.TemplatesManage)
+	}
+	return t.OwnerID.Int32 == userID
+}
+
+// EvaluationTemplateHandler manages the reusable skeletons instructors start evaluations from
+type EvaluationTemplateHandler struct {
+	queries *sqlc.Queries
+}
+
+func NewEvaluationTemplateHandler(queries *sqlc.Queries) *EvaluationTemplateHandler {
+	return &EvaluationTemplateHandler{queries: queries}
+}
+
+func (h *EvaluationTemplateHandler) ListTemplates(c *gin.Context) {
+	h.renderTemplates(c, http.StatusOK, "")
+}
+
+func (h *EvaluationTemplateHandler) CreateTemplate(c *gin.Context) {
+	session := sessions.Default(c)
+	userID := session.Get("user_id").(int32)
+
+	params, errMsg := parseEvaluationTemplateForm(c, userID, permission.FromContext(c))
+	if errMsg != "" {
+		h.renderTemplates(c, http.StatusBadRequest, errMsg)
+		return
+	}
+
+	if _, err := h.queries.CreateEvaluationTemplate(c.Request.Context(), params); err != nil {
+		h.renderTemplates(c, http.StatusInternalServerError, "템플릿 등록에 실패했습니다.")
+		return
+	}
+
+	c.Redirect(http.StatusFound, "/evaluation-templates")
+}
+
+func (h *EvaluationTemplateHandler) ShowEditForm(c *gin.Context) {
+	template, ok := h.loadManagedTemplate(c)
+	if !ok {
+		return
+	}
+
+	renderPage(c, http.StatusOK, "evaluation_template_form.html", gin.H{
+		"template":    template,
+		"action":      "/evaluation-templates/" + c.Param("id"),
+		"currentPage": "students",
+	})
+}
+
+func (h *EvaluationTemplateHandler) UpdateTemplate(c *gin.Context) {
+	session := sessions.Default(c)
+	userID := session.Get("user_id").(int32)
+
+	template, ok := h.loadManagedTemplate(c)
+	if !ok {
+		return
+	}
+
+	params, errMsg := parseEvaluationTemplateForm(c, userID, permission.FromContext(c))
+	if errMsg == "" {
+		// 다른 강사의 개인 템플릿은 여기까지 오지 않으므로, 개인 템플릿의 소유자는 본인
+		_, err := h.queries.UpdateEvaluationTemplate(c.Request.Context(), sqlc.UpdateEvaluationTemplateParams{
+			ID:      template.ID,
+			OwnerID: params.OwnerID,
+			Title:   params.Title,
+			Content: params.Content,
+		})
+		if err != nil {
+			errMsg = "템플릿 수정에 실패했습니다."
+		}
+	}
+
+	if errMsg != "" {
+		template.Title = params.Title
+		template.Content = params.Content
+		renderPage(c, http.StatusBadRequest, "evaluation_template_form.html", gin.H{
+			"template":    template,
+			"action":      "/evaluation-templates/" + c.Param("id"),
+			"error":       errMsg,
+			"currentPage": "students",
+		})
+		return
+	}
+
+	c.Redirect(http.StatusFound, "/evaluation-templates")
+}
+
+func (h *EvaluationTemplateHandler) DeleteTemplate(c *gin.Context) {
+	template, ok := h.loadManagedTemplate(c)
+	if !ok {
+		return
+	}
+
+	if err := h.queries.DeleteEvaluationTemplate(c.Request.Context(), template.ID); err != nil {
+		h.renderTemplates(c, http.StatusInternalServerError, "템플릿 삭제에 실패했습니다.")
+		return
+	}
+
+	c.Redirect(http.StatusFound, "/evaluation-templates")
+}
+
+// loadManagedTemplate looks up :id and checks that the current user may change it
+func (h *EvaluationTemplateHandler) loadManagedTemplate(c *gin.Context) (sqlc.EvaluationTemplate, bool) {
+	session := sessions.Default(c)
+	userID := session.Get("user_id").(int32)
+
+	id, err := strconv.ParseInt(c.Param("id"), 10, 32)
+	if err != nil {
+		c.Redirect(http.StatusFound, "/evaluation-templates")
+		return sqlc.EvaluationTemplate{}, false
+	}
+
+	template, err := h.queries.GetEvaluationTemplateByID(c.Request.Context(), int32(id))
+	if err != nil || !canUseTemplate(template, userID) {
+		c.HTML(http.StatusNotFound, "error.html", gin.H{
+			"error": "템플릿을 찾을 수 없습니다.",
+		})
+		return sqlc.EvaluationTemplate{}, false
+	}
+	if !canManageTemplate(template, userID, permission.FromContext(c)) {
+		c.HTML(http.StatusForbidden, "error.html", gin.H{
+			"error": "학원 공용 템플릿을 수정할 권한이 없습니다.",
+		})
+		return sqlc.EvaluationTemplate{}, false
+	}
+	return template, true
+}
+
+func (h *EvaluationTemplateHandler) renderTemplates(c *gin.Context, status int, errMsg string) {
+	session := sessions.Default(c)
+	userID := session.Get("user_id").(int32)
+
+	templates, err := h.queries.ListEvaluationTemplatesForUser(c.Request.Context(), pgtype.Int4{Int32: userID, Valid: true})
+	if err != nil {
+		c.HTML(http.StatusInternalServerError, "error.html", gin.H{
+			"error": "템플릿 목록을 불러오는데 실패했습니다.",
+		})
+		return
+	}
+
+	renderPage(c, status, "evaluation_templates.html", gin.H{
+		"templates":     templates,
+		"currentUserID": userID,
+		"error":         errMsg,
+		"currentPage":   "students",
+	})
+}
+
+// parseEvaluationTemplateForm reads the template form. Only users with templates.manage may
+// save a template as academy-wide; everyone else always saves a personal one.
+func parseEvaluationTemplateForm(c *gin.Context, userID int32, perms permission.Set) (sqlc.CreateEvaluationTemplateParams, string) {
+	params := sqlc.CreateEvaluationTemplateParams{
+		OwnerID: pgtype.Int4{Int32: userID, Valid: true},
+		Title:   strings.TrimSpace(c.PostForm("title")),
+		Content: c.PostForm("content"),
+	}
+	if c.PostForm("scope") == templateScopeAcademy && perms.Has(permission.TemplatesManage) {
+		params.OwnerID = pgtype.Int4{}
+	}
+
+	if params.Title == "" {
+		return params, "템플릿 이름을 입력해주세요."
+	}
+	if len([]rune(params.Title)) > 100 {
+		return params, "템플릿 이름은 100자 이하로 입력해주세요."
+	}
+	if strings.TrimSpace(params.Content) == "" {
+		return params, "템플릿 내용을 입력해주세요."
+	}
+	return params, ""
+}
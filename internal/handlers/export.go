@@ -0,0 +1,96 @@
+package handlers
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/choiexe1/hongik-academy/internal/db/sqlc"
+	"github.com/choiexe1/hongik-academy/internal/spreadsheet"
+)
+
+// studentExportHeader matches the column names accepted by the import page, so an exported
+// file can be edited and imported again
+var studentExportHeader = []string{"번호", "이름", "성별", "연락처", "학부모 연락처", "상태", "입학일", "휴원/종료일", "특이사항", "등록일"}
+
+var evaluationExportHeader = []string{"번호", "작성일", "작성자", "내용", "수정일"}
+
+var studentStatusLabels = map[string]string{
+	"enrolled":  "재원",
+	"on_leave":  "휴원",
+	"graduated": "졸업",
+	"withdrawn": "퇴원",
+}
+
+// sendSpreadsheet writes rows as a CSV or XLSX attachment. filename is given without an
+// extension; Korean characters are kept for browsers that understand filename*.
+func sendSpreadsheet(c *gin.Context, format spreadsheet.Format, filename, sheet string, header []string, rows [][]string) {
+	filename += "." + string(format)
+	fallback := strings.Map(func(r rune) rune {
+		if r > 0x7e || r < 0x20 || r == '"' || r == '\\' {
+			return '_'
+		}
+		return r
+	}, filename)
+
+	c.Header("Content-Type", format.ContentType())
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"; filename*=UTF-8''%s`, fallback, url.PathEscape(filename)))
+	c.Status(http.StatusOK)
+
+	if err := spreadsheet.Write(c.Writer, format, sheet, header, rows); err != nil {
+		log.Printf("export: failed to write %s: %v", filename, err)
+	}
+}
+
+func studentExportRows(students []sqlc.Student) [][]string {
+	rows := make([][]string, 0, len(students))
+	for _, s := range students {
+		gender := "여"
+		if s.Gender == "M" {
+			gender = "남"
+		}
+		rows = append(rows, []string{
+			fmt.Sprint(s.ID),
+			s.Name,
+			gender,
+			s.Phone.String,
+			s.ParentPhone.String,
+			studentStatusLabels[s.Status],
+			exportDate(s.EnrolledOn.Time, s.EnrolledOn.Valid),
+			exportDate(s.LeftOn.Time, s.LeftOn.Valid),
+			s.Remarks.String,
+			exportDate(s.CreatedAt.Time, s.CreatedAt.Valid),
+		})
+	}
+	return rows
+}
+
+func evaluationExportRows(evaluations []sqlc.ExportEvaluationsByStudentRow) [][]string {
+	rows := make([][]string, 0, len(evaluations))
+	for _, e := range evaluations {
+		updatedAt := ""
+		if e.UpdatedAt.Valid && !e.UpdatedAt.Time.Equal(e.CreatedAt.Time) {
+			updatedAt = e.UpdatedAt.Time.Format("2006-01-02 15:04")
+		}
+		rows = append(rows, []string{
+			fmt.Sprint(e.ID),
+			e.CreatedAt.Time.Format("2006-01-02 15:04"),
+			e.AuthorName,
+			e.Content,
+			updatedAt,
+		})
+	}
+	return rows
+}
+
+func exportDate(t time.Time, valid bool) string {
+	if !valid {
+		return ""
+	}
+	return t.Format("2006-01-02")
+}
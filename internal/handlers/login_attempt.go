@@ -0,0 +1,87 @@
+package handlers
+
+import (
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/choiexe1/hongik-academy/internal/db/sqlc"
+)
+
+// loginAttemptsPerPage is the page size of /admin/login-attempts
+const loginAttemptsPerPage = 50
+
+type LoginAttemptHandler struct {
+	queries *sqlc.Queries
+}
+
+func NewLoginAttemptHandler(queries *sqlc.Queries) *LoginAttemptHandler {
+	return &LoginAttemptHandler{queries: queries}
+}
+
+// ListLoginAttempts renders /admin/login-attempts with username, ip and result filters
+func (h *LoginAttemptHandler) ListLoginAttempts(c *gin.Context) {
+	// 필터 파라미터
+	username := strings.TrimSpace(c.Query("username"))
+	ip := strings.TrimSpace(c.Query("ip"))
+	result := c.Query("result")
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	if page < 1 {
+		page = 1
+	}
+
+	usernameParam := pgtype.Text{String: username, Valid: username != ""}
+	ipParam := pgtype.Text{String: ip, Valid: ip != ""}
+	successParam := pgtype.Bool{Valid: false}
+	switch result {
+	case "success":
+		successParam = pgtype.Bool{Bool: true, Valid: true}
+	case "failure":
+		successParam = pgtype.Bool{Bool: false, Valid: true}
+	default:
+		result = ""
+	}
+
+	totalCount, err := h.queries.CountLoginAttempts(c.Request.Context(), sqlc.CountLoginAttemptsParams{
+		Username:  usernameParam,
+		IpAddress: ipParam,
+		Success:   successParam,
+	})
+	if err != nil {
+		c.HTML(http.StatusInternalServerError, "error.html", gin.H{
+			"error": "로그인 기록 개수를 조회하는데 실패했습니다.",
+		})
+		return
+	}
+
+	attempts, err := h.queries.ListLoginAttempts(c.Request.Context(), sqlc.ListLoginAttemptsParams{
+		Username:  usernameParam,
+		IpAddress: ipParam,
+		Success:   successParam,
+		Limit:     loginAttemptsPerPage,
+		Offset:    int32((page - 1) * loginAttemptsPerPage),
+	})
+	if err != nil {
+		c.HTML(http.StatusInternalServerError, "error.html", gin.H{
+			"error": "로그인 기록을 불러오는데 실패했습니다.",
+		})
+		return
+	}
+
+	totalPages := int(math.Ceil(float64(totalCount) / float64(loginAttemptsPerPage)))
+
+	renderPage(c, http.StatusOK, "login_attempts.html", gin.H{
+		"attempts":       attempts,
+		"filterUsername": username, // username은 상단 메뉴에서 사용
+		"ip":             ip,
+		"result":         result,
+		"page":           page,
+		"totalCount":     totalCount,
+		"totalPages":     totalPages,
+		"currentPage":    "users",
+	})
+}
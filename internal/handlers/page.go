@@ -0,0 +1,28 @@
+package handlers
+
+import (
+	"github.com/gin-contrib/sessions"
+	"github.com/gin-gonic/gin"
+
+	"github.com/choiexe1/hongik-academy/internal/middleware"
+	"github.com/choiexe1/hongik-academy/internal/permission"
+)
+
+// renderPage renders a logged-in page. It adds what the shared navigation needs: the user's
+// name, role and the role's permissions, which decide the menus and buttons shown.
+func renderPage(c *gin.Context, status int, name string, data gin.H) {
+	data["username"] = sessions.Default(c).Get("username")
+	data["role"] = c.GetString("role")
+	data["roleName"] = c.GetString("role_name")
+	data["permissions"] = permission.FromContext(c)
+
+	renderHTML(c, status, name, data)
+}
+
+// renderHTML renders a page that has forms, adding the CSRF token every POST form must send
+// back. Pages rendered before login, like the login form, call it directly.
+func renderHTML(c *gin.Context, status int, name string, data gin.H) {
+	data["csrfToken"] = c.GetString(middleware.CSRFTokenKey)
+
+	c.HTML(status, name, data)
+}
@@ -0,0 +1,314 @@
+package handlers
+
+import (
+	"errors"
+	"math"
+	"net/http"
+	"net/netip"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5"
+
+	"github.com/choiexe1/hongik-academy/internal/db/sqlc"
+	"github.com/choiexe1/hongik-academy/internal/sharelink"
+)
+
+// shareLinkExpiryDays lists the selectable lifetimes of a parent share link
+var shareLinkExpiryDays = []int{7, 30, 90}
+
+const (
+	parentEvaluationsPerPage = 10
+	parentAttendancePerPage  = 20
+)
+
+// ShareLinkHandler issues parent share links from the admin pages. A nil signer means
+// SHARE_LINK_KEY is not configured: no links can be issued, but existing ones can still be
+// revoked.
+type ShareLinkHandler struct {
+	queries *sqlc.Queries
+	signer  *sharelink.Signer
+	// trustedProxies are the proxies whose X-Forwarded-Proto is believed
+	trustedProxies []string
+}
+
+func NewShareLinkHandler(queries *sqlc.Queries, signer *sharelink.Signer, trustedProxies []string) *ShareLinkHandler {
+	return &ShareLinkHandler{queries: queries, signer: signer, trustedProxies: trustedProxies}
+}
+
+func (h *ShareLinkHandler) ShowShareForm(c *gin.Context) {
+	student, ok := h.loadStudent(c)
+	if !ok {
+		return
+	}
+
+	h.renderShare(c, student, gin.H{})
+}
+
+// CreateShareLink signs a new link; links are not stored, so the page shows it once
+func (h *ShareLinkHandler) CreateShareLink(c *gin.Context) {
+	student, ok := h.loadStudent(c)
+	if !ok {
+		return
+	}
+	if h.signer == nil {
+		h.renderShare(c, student, gin.H{})
+		return
+	}
+
+	days, err := strconv.Atoi(c.PostForm("expires_in_days"))
+	if err != nil || !isValidShareLinkExpiry(days) {
+		days = shareLinkExpiryDays[0]
+	}
+	expiresAt := time.Now().AddDate(0, 0, days)
+
+	shareLink, err := h.currentShareLink(c, student.ID)
+	if err != nil {
+		c.HTML(http.StatusInternalServerError, "error.html", gin.H{
+			"error": "공유 링크를 만드는데 실패했습니다.",
+		})
+		return
+	}
+
+	link := requestBaseURL(c, h.trustedProxies) + "/parent/" + h.signer.Sign(student.ID, shareLink.Version, expiresAt)
+	h.renderShare(c, student, gin.H{
+		"link":      link,
+		"expiresAt": expiresAt,
+	})
+}
+
+// RevokeShareLinks invalidates every link issued for the student so far
+func (h *ShareLinkHandler) RevokeShareLinks(c *gin.Context) {
+	student, ok := h.loadStudent(c)
+	if !ok {
+		return
+	}
+
+	before, err := h.currentShareLink(c, student.ID)
+	if err != nil {
+		c.HTML(http.StatusInternalServerError, "error.html", gin.H{
+			"error": "공유 링크를 취소하는데 실패했습니다.",
+		})
+		return
+	}
+	after, err := h.queries.RevokeStudentShareLinks(c.Request.Context(), student.ID)
+	if err != nil {
+		c.HTML(http.StatusInternalServerError, "error.html", gin.H{
+			"error": "공유 링크를 취소하는데 실패했습니다.",
+		})
+		return
+	}
+
+	recordAudit(c.Request.Context(), h.queries, sessionUserID(c), auditActionRevokeShare, auditEntityStudent, student.ID, before, after)
+
+	h.renderShare(c, student, gin.H{
+		"success": "지금까지 발급한 공유 링크를 모두 취소했습니다.",
+	})
+}
+
+// currentShareLink returns the student's link version; students whose links were never
+// revoked have no row and version 0
+func (h *ShareLinkHandler) currentShareLink(c *gin.Context, studentID int32) (sqlc.StudentShareLink, error) {
+	shareLink, err := h.queries.GetStudentShareLink(c.Request.Context(), studentID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return sqlc.StudentShareLink{StudentID: studentID}, nil
+	}
+	return shareLink, err
+}
+
+func (h *ShareLinkHandler) loadStudent(c *gin.Context) (sqlc.Student, bool) {
+	studentID, err := strconv.ParseInt(c.Param("id"), 10, 32)
+	if err != nil {
+		c.Redirect(http.StatusFound, "/students")
+		return sqlc.Student{}, false
+	}
+
+	student, err := h.queries.GetStudentByID(c.Request.Context(), int32(studentID))
+	if err != nil {
+		c.HTML(http.StatusNotFound, "error.html", gin.H{
+			"error": "원생을 찾을 수 없습니다.",
+		})
+		return sqlc.Student{}, false
+	}
+	return student, true
+}
+
+func (h *ShareLinkHandler) renderShare(c *gin.Context, student sqlc.Student, data gin.H) {
+	shareLink, err := h.currentShareLink(c, student.ID)
+	if err != nil {
+		c.HTML(http.StatusInternalServerError, "error.html", gin.H{
+			"error": "공유 링크 정보를 불러오는데 실패했습니다.",
+		})
+		return
+	}
+
+	data["student"] = student
+	data["revokedAt"] = shareLink.RevokedAt
+	data["shareDisabled"] = h.signer == nil
+	data["expiryDays"] = shareLinkExpiryDays
+	data["currentPage"] = "students"
+	renderPage(c, http.StatusOK, "student_share.html", data)
+}
+
+func isValidShareLinkExpiry(days int) bool {
+	for _, d := range shareLinkExpiryDays {
+		if d == days {
+			return true
+		}
+	}
+	return false
+}
+
+// requestBaseURL returns the scheme and host the admin used to reach the server. The
+// X-Forwarded-Proto of a TLS terminating proxy is only honored when the request came
+// straight from one of the trusted proxies; anyone else could send the header.
+func requestBaseURL(c *gin.Context, trustedProxies []string) string {
+	scheme := "http"
+	if c.Request.TLS != nil || (c.GetHeader("X-Forwarded-Proto") == "https" && isTrustedProxy(c.RemoteIP(), trustedProxies)) {
+		scheme = "https"
+	}
+	return scheme + "://" + c.Request.Host
+}
+
+// isTrustedProxy reports whether ip matches one of the proxies, given as IPs or CIDRs in the
+// same format as TRUSTED_PROXIES
+func isTrustedProxy(ip string, proxies []string) bool {
+	addr, err := netip.ParseAddr(ip)
+	if err != nil {
+		return false
+	}
+	addr = addr.Unmap()
+	for _, proxy := range proxies {
+		if prefix, err := netip.ParsePrefix(proxy); err == nil {
+			if prefix.Contains(addr) {
+				return true
+			}
+			continue
+		}
+		if proxyAddr, err := netip.ParseAddr(proxy); err == nil && proxyAddr.Unmap() == addr {
+			return true
+		}
+	}
+	return false
+}
+
+// ParentHandler serves the read-only parent portal. Every handler runs behind
+// middleware.ParentLinkRequired and only ever reads the student the link was signed for.
+type ParentHandler struct {
+	queries *sqlc.Queries
+}
+
+func NewParentHandler(queries *sqlc.Queries) *ParentHandler {
+	return &ParentHandler{queries: queries}
+}
+
+func (h *ParentHandler) ShowEvaluations(c *gin.Context) {
+	student, ok := h.loadStudent(c)
+	if !ok {
+		return
+	}
+
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	if page < 1 {
+		page = 1
+	}
+
+	totalCount, err := h.queries.CountEvaluationsByStudent(c.Request.Context(), sqlc.CountEvaluationsByStudentParams{
+		StudentID: student.ID,
+	})
+	if err != nil {
+		h.renderError(c, http.StatusInternalServerError, "평가 기록을 불러오는데 실패했습니다.")
+		return
+	}
+
+	evaluations, err := h.queries.ListEvaluationsByStudent(c.Request.Context(), sqlc.ListEvaluationsByStudentParams{
+		StudentID: student.ID,
+		Limit:     parentEvaluationsPerPage,
+		Offset:    int32((page - 1) * parentEvaluationsPerPage),
+	})
+	if err != nil {
+		h.renderError(c, http.StatusInternalServerError, "평가 기록을 불러오는데 실패했습니다.")
+		return
+	}
+
+	h.render(c, student, gin.H{
+		"tab":         "evaluations",
+		"evaluations": evaluations,
+		"page":        page,
+		"totalCount":  totalCount,
+		"totalPages":  int(math.Ceil(float64(totalCount) / float64(parentEvaluationsPerPage))),
+	})
+}
+
+func (h *ParentHandler) ShowAttendance(c *gin.Context) {
+	student, ok := h.loadStudent(c)
+	if !ok {
+		return
+	}
+
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	if page < 1 {
+		page = 1
+	}
+
+	totalCount, err := h.queries.CountAttendanceByStudent(c.Request.Context(), sqlc.CountAttendanceByStudentParams{
+		StudentID: student.ID,
+	})
+	if err != nil {
+		h.renderError(c, http.StatusInternalServerError, "출석 기록을 불러오는데 실패했습니다.")
+		return
+	}
+
+	records, err := h.queries.ListAttendanceByStudent(c.Request.Context(), sqlc.ListAttendanceByStudentParams{
+		StudentID: student.ID,
+		Limit:     parentAttendancePerPage,
+		Offset:    int32((page - 1) * parentAttendancePerPage),
+	})
+	if err != nil {
+		h.renderError(c, http.StatusInternalServerError, "출석 기록을 불러오는데 실패했습니다.")
+		return
+	}
+
+	summaryRows, err := h.queries.SummarizeAttendanceByStudent(c.Request.Context(), sqlc.SummarizeAttendanceByStudentParams{
+		StudentID: student.ID,
+	})
+	if err != nil {
+		h.renderError(c, http.StatusInternalServerError, "출석 통계를 불러오는데 실패했습니다.")
+		return
+	}
+	summary := map[string]int64{}
+	for _, s := range summaryRows {
+		summary[s.Status] = s.Count
+	}
+
+	h.render(c, student, gin.H{
+		"tab":        "attendance",
+		"records":    records,
+		"summary":    summary,
+		"page":       page,
+		"totalCount": totalCount,
+		"totalPages": int(math.Ceil(float64(totalCount) / float64(parentAttendancePerPage))),
+	})
+}
+
+func (h *ParentHandler) loadStudent(c *gin.Context) (sqlc.Student, bool) {
+	student, err := h.queries.GetStudentByID(c.Request.Context(), c.GetInt32("parent_student_id"))
+	if err != nil {
+		h.renderError(c, http.StatusNotFound, "원생 정보를 찾을 수 없습니다. 학원에 문의해주세요.")
+		return sqlc.Student{}, false
+	}
+	return student, true
+}
+
+func (h *ParentHandler) render(c *gin.Context, student sqlc.Student, data gin.H) {
+	data["student"] = student
+	data["token"] = c.Param("token")
+	data["expiresAt"] = c.GetTime("parent_link_expires_at")
+
+	c.HTML(http.StatusOK, "parent_portal.html", data)
+}
+
+func (h *ParentHandler) renderError(c *gin.Context, status int, message string) {
+	c.HTML(status, "parent_error.html", gin.H{"error": message})
+}
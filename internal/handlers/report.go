@@ -0,0 +1,98 @@
+package handlers
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/choiexe1/hongik-academy/internal/db/sqlc"
+	"github.com/choiexe1/hongik-academy/internal/report"
+)
+
+// ReportHandler renders PDF reports for parents
+type ReportHandler struct {
+	queries  *sqlc.Queries
+	fontPath string
+}
+
+func NewReportHandler(queries *sqlc.Queries, fontPath string) *ReportHandler {
+	return &ReportHandler{queries: queries, fontPath: fontPath}
+}
+
+// EvaluationReport renders a student's evaluations in the start_date/end_date range as a PDF
+func (h *ReportHandler) EvaluationReport(c *gin.Context) {
+	studentID, err := strconv.ParseInt(c.Param("id"), 10, 32)
+	if err != nil {
+		c.Redirect(http.StatusFound, "/students")
+		return
+	}
+
+	student, err := h.queries.GetStudentByID(c.Request.Context(), int32(studentID))
+	if err != nil {
+		c.HTML(http.StatusNotFound, "error.html", gin.H{
+			"error": "원생을 찾을 수 없습니다.",
+		})
+		return
+	}
+
+	var startDate, endDate time.Time
+	var startDateParam, endDateParam pgtype.Date
+	if t, err := time.Parse("2006-01-02", c.Query("start_date")); err == nil {
+		startDate = t
+		startDateParam = pgtype.Date{Time: t, Valid: true}
+	}
+	if t, err := time.Parse("2006-01-02", c.Query("end_date")); err == nil {
+		endDate = t
+		endDateParam = pgtype.Date{Time: t, Valid: true}
+	}
+
+	evaluations, err := h.queries.ExportEvaluationsByStudent(c.Request.Context(), sqlc.ExportEvaluationsByStudentParams{
+		StudentID: student.ID,
+		StartDate: startDateParam,
+		EndDate:   endDateParam,
+	})
+	if err != nil {
+		c.HTML(http.StatusInternalServerError, "error.html", gin.H{
+			"error": "평가표 목록을 불러오는데 실패했습니다.",
+		})
+		return
+	}
+
+	// 오류 페이지를 보여줄 수 있도록 PDF를 먼저 만든 뒤 전송
+	var buf bytes.Buffer
+	err = report.WriteEvaluationReport(&buf, h.fontPath, report.EvaluationReport{
+		Student:     student,
+		StartDate:   startDate,
+		EndDate:     endDate,
+		Evaluations: evaluations,
+		GeneratedAt: time.Now(),
+	})
+	if errors.Is(err, report.ErrFontUnavailable) {
+		log.Printf("report: student %d: %v", student.ID, err)
+		c.HTML(http.StatusServiceUnavailable, "error.html", gin.H{
+			"title": "PDF 리포트를 만들 수 없습니다",
+			"error": "서버에 리포트용 한글 글꼴이 설치되지 않았습니다. 서버 관리자에게 글꼴(REPORT_FONT_PATH) 설정을 요청해주세요.",
+			"back":  true,
+		})
+		return
+	}
+	if err != nil {
+		log.Printf("report: student %d: %v", student.ID, err)
+		c.HTML(http.StatusInternalServerError, "error.html", gin.H{
+			"error": "PDF 리포트를 만드는데 실패했습니다.",
+		})
+		return
+	}
+
+	filename := fmt.Sprintf("%s_평가리포트_%s.pdf", student.Name, time.Now().Format("20060102"))
+	c.Header("Content-Disposition", fmt.Sprintf(`inline; filename="report.pdf"; filename*=UTF-8''%s`, url.PathEscape(filename)))
+	c.Data(http.StatusOK, "application/pdf", buf.Bytes())
+}
@@ -0,0 +1,480 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/choiexe1/hongik-academy/internal/db/sqlc"
+	"github.com/choiexe1/hongik-academy/internal/permission"
+)
+
+// roleNamePattern is the role key stored in users.role: lowercase letters, digits and
+// underscores, starting with a letter
+var roleNamePattern = regexp.MustCompile(`^[a-z][a-z0-9_]{1,19}$`)
+
+// maxRoleSessions caps the concurrent login limit that can be set on a role
+const maxRoleSessions = 20
+
+// permissionGroup is one category of checkboxes on the role form
+type permissionGroup struct {
+	Category    string
+	Permissions []sqlc.Permission
+}
+
+func groupPermissions(perms []sqlc.Permission) []permissionGroup {
+	var groups []permissionGroup
+	for _, p := range perms {
+		if n := len(groups); n > 0 && groups[n-1].Category == p.Category {
+			groups[n-1].Permissions = append(groups[n-1].Permissions, p)
+			continue
+		}
+		groups = append(groups, permissionGroup{Category: p.Category, Permissions: []sqlc.Permission{p}})
+	}
+	return groups
+}
+
+// rolePermissionSets returns the permissions of every role by role name
+func rolePermissionSets(ctx context.Context, queries *sqlc.Queries) (map[string]permission.Set, error) {
+	rows, err := queries.ListAllRolePermissions(ctx)
+	if err != nil {
+		return nil, err
+	}
+	sets := make(map[string]permission.Set)
+	for _, r := range rows {
+		if sets[r.Role] == nil {
+			sets[r.Role] = permission.Set{}
+		}
+		sets[r.Role][r.Permission] = true
+	}
+	return sets, nil
+}
+
+// canAssignRole reports whether a user may grant the role to someone. The super admin role is
+// granted by super admins only; any other role only by a user holding all of its permissions,
+// so nobody can hand out more than they have.
+func canAssignRole(role string, rolePerms permission.Set, currentRole string, perms permission.Set) bool {
+	if role == permission.SuperAdminRole {
+		return currentRole == permission.SuperAdminRole
+	}
+	for p := range rolePerms {
+		if !perms.Has(p) {
+			return false
+		}
+	}
+	return true
+}
+
+// assignableRoles lists the roles the current user may grant, for the role picker
+func assignableRoles(ctx context.Context, queries *sqlc.Queries, currentRole string, perms permission.Set) ([]sqlc.ListRolesRow, error) {
+	roles, err := queries.ListRoles(ctx)
+	if err != nil {
+		return nil, err
+	}
+	sets, err := rolePermissionSets(ctx, queries)
+	if err != nil {
+		return nil, err
+	}
+	var assignable []sqlc.ListRolesRow
+	for _, r := range roles {
+		if canAssignRole(r.Name, sets[r.Name], currentRole, perms) {
+			assignable = append(assignable, r)
+		}
+	}
+	return assignable, nil
+}
+
+// checkAssignableRole validates a submitted role; the returned message is shown to the user
+func checkAssignableRole(ctx context.Context, queries *sqlc.Queries, role, currentRole string, perms permission.Set) string {
+	if _, err := queries.GetRoleByName(ctx, role); err != nil {
+		return "존재하지 않는 역할입니다."
+	}
+	rolePerms, err := queries.ListRolePermissions(ctx, role)
+	if err != nil {
+		return "역할 정보를 불러오는데 실패했습니다."
+	}
+	if !canAssignRole(role, permission.New(rolePerms), currentRole, perms) {
+		return "본인에게 없는 권한이 포함된 역할은 부여할 수 없습니다."
+	}
+	return ""
+}
+
+// roleDisplayNames maps role keys to the names shown in the admin pages
+func roleDisplayNames(ctx context.Context, queries *sqlc.Queries) (map[string]string, error) {
+	roles, err := queries.ListRoles(ctx)
+	if err != nil {
+		return nil, err
+	}
+	names := make(map[string]string, len(roles))
+	for _, r := range roles {
+		names[r.Name] = r.DisplayName
+	}
+	return names, nil
+}
+
+// auditRole is the snapshot stored for roles, including the permissions granted
+type auditRole struct {
+	sqlc.Role
+	Permissions []string `json:"permissions"`
+}
+
+// RoleHandler manages roles and the permissions each role holds. Permissions themselves are
+// fixed by migrations; only their assignment to roles is edited here.
+type RoleHandler struct {
+	pool    *pgxpool.Pool
+	queries *sqlc.Queries
+}
+
+func NewRoleHandler(pool *pgxpool.Pool, queries *sqlc.Queries) *RoleHandler {
+	return &RoleHandler{pool: pool, queries: queries}
+}
+
+func (h *RoleHandler) ListRoles(c *gin.Context) {
+	h.renderRoles(c, http.StatusOK, "", roleFormInput{})
+}
+
+// roleFormInput keeps what was submitted so a failed create re-renders the form filled in
+type roleFormInput struct {
+	Name        string
+	DisplayName string
+	Description string
+	MaxSessions string
+	// RequireTwoFactor sends users of the role to 2FA enrollment until they turn it on
+	RequireTwoFactor bool
+	Permissions      permission.Set
+}
+
+// maxSessions converts the validated limit; an empty field means no limit
+func (in roleFormInput) maxSessions() pgtype.Int4 {
+	n, err := strconv.Atoi(in.MaxSessions)
+	if err != nil {
+		return pgtype.Int4{}
+	}
+	return pgtype.Int4{Int32: int32(n), Valid: true}
+}
+
+func (h *RoleHandler) CreateRole(c *gin.Context) {
+	ctx := c.Request.Context()
+	input := parseRoleForm(c)
+	input.Name = strings.TrimSpace(c.PostForm("name"))
+
+	errMsg := validateRoleForm(input)
+	if errMsg == "" && !roleNamePattern.MatchString(input.Name) {
+		errMsg = "역할 키는 영문 소문자로 시작하고 영문 소문자, 숫자, 밑줄(_)만 사용해 2~20자로 입력해주세요."
+	}
+	if errMsg == "" {
+		if _, err := h.queries.GetRoleByName(ctx, input.Name); err == nil {
+			errMsg = "이미 사용 중인 역할 키입니다."
+		}
+	}
+	if errMsg != "" {
+		h.renderRoles(c, http.StatusBadRequest, errMsg, input)
+		return
+	}
+
+	// 본인에게 없는 권한은 새 역할에 넣을 수 없음
+	actorPerms := permission.FromContext(c)
+	var granted []string
+	for p := range input.Permissions {
+		if actorPerms.Has(p) {
+			granted = append(granted, p)
+		}
+	}
+	sort.Strings(granted)
+
+	role, err := func() (sqlc.Role, error) {
+		tx, err := h.pool.Begin(ctx)
+		if err != nil {
+			return sqlc.Role{}, err
+		}
+		defer tx.Rollback(ctx)
+		qtx := h.queries.WithTx(tx)
+
+		role, err := qtx.CreateRole(ctx, sqlc.CreateRoleParams{
+			Name:             input.Name,
+			DisplayName:      input.DisplayName,
+			Description:      pgtype.Text{String: input.Description, Valid: input.Description != ""},
+			MaxSessions:      input.maxSessions(),
+			RequireTwoFactor: input.RequireTwoFactor,
+		})
+		if err != nil {
+			return sqlc.Role{}, err
+		}
+		for _, p := range granted {
+			if err := qtx.AddRolePermission(ctx, sqlc.AddRolePermissionParams{RoleID: role.ID, Permission: p}); err != nil {
+				return sqlc.Role{}, err
+			}
+		}
+		return role, tx.Commit(ctx)
+	}()
+	if err != nil {
+		h.renderRoles(c, http.StatusInternalServerError, "역할 등록에 실패했습니다.", input)
+		return
+	}
+
+	recordAudit(ctx, h.queries, sessionUserID(c), auditActionCreate, auditEntityRole, role.ID, nil, auditRole{Role: role, Permissions: granted})
+
+	c.Redirect(http.StatusFound, "/admin/roles/"+strconv.Itoa(int(role.ID))+"/edit")
+}
+
+func (h *RoleHandler) ShowEditForm(c *gin.Context) {
+	role, ok := h.loadRole(c)
+	if !ok {
+		return
+	}
+
+	rolePerms, err := h.queries.ListRolePermissions(c.Request.Context(), role.Name)
+	if err != nil {
+		c.HTML(http.StatusInternalServerError, "error.html", gin.H{
+			"error": "역할 정보를 불러오는데 실패했습니다.",
+		})
+		return
+	}
+
+	input := roleFormInput{
+		DisplayName:      role.DisplayName,
+		Description:      role.Description.String,
+		RequireTwoFactor: role.RequireTwoFactor,
+		Permissions:      permission.New(rolePerms),
+	}
+	if role.MaxSessions.Valid {
+		input.MaxSessions = strconv.Itoa(int(role.MaxSessions.Int32))
+	}
+	h.renderForm(c, http.StatusOK, role, input, "")
+}
+
+// UpdateRole saves the name, description and permissions of a role. Permissions the editor
+// does not hold are kept as they were, so editing a role never grants or takes away more
+// than the editor could grant themselves.
+func (h *RoleHandler) UpdateRole(c *gin.Context) {
+	ctx := c.Request.Context()
+	role, ok := h.loadRole(c)
+	if !ok {
+		return
+	}
+
+	input := parseRoleForm(c)
+	errMsg := validateRoleForm(input)
+	switch {
+	case role.Name == permission.SuperAdminRole:
+		errMsg = "최고관리자 역할은 항상 모든 권한을 가지므로 수정할 수 없습니다."
+	case role.Name == c.GetString("role"):
+		errMsg = "본인에게 부여된 역할은 수정할 수 없습니다."
+	}
+	if errMsg != "" {
+		h.renderForm(c, http.StatusBadRequest, role, input, errMsg)
+		return
+	}
+
+	before, err := h.queries.ListRolePermissions(ctx, role.Name)
+	if err != nil {
+		h.renderForm(c, http.StatusInternalServerError, role, input, "역할 정보를 불러오는데 실패했습니다.")
+		return
+	}
+
+	actorPerms := permission.FromContext(c)
+	var after []string
+	for _, p := range before {
+		if !actorPerms.Has(p) {
+			after = append(after, p)
+		}
+	}
+	for p := range input.Permissions {
+		if actorPerms.Has(p) {
+			after = append(after, p)
+		}
+	}
+	sort.Strings(after)
+
+	updated, err := func() (sqlc.Role, error) {
+		tx, err := h.pool.Begin(ctx)
+		if err != nil {
+			return sqlc.Role{}, err
+		}
+		defer tx.Rollback(ctx)
+		qtx := h.queries.WithTx(tx)
+
+		updated, err := qtx.UpdateRole(ctx, sqlc.UpdateRoleParams{
+			ID:               role.ID,
+			DisplayName:      input.DisplayName,
+			Description:      pgtype.Text{String: input.Description, Valid: input.Description != ""},
+			MaxSessions:      input.maxSessions(),
+			RequireTwoFactor: input.RequireTwoFactor,
+		})
+		if err != nil {
+			return sqlc.Role{}, err
+		}
+		if err := qtx.DeleteRolePermissions(ctx, role.ID); err != nil {
+			return sqlc.Role{}, err
+		}
+		for _, p := range after {
+			if err := qtx.AddRolePermission(ctx, sqlc.AddRolePermissionParams{RoleID: role.ID, Permission: p}); err != nil {
+				return sqlc.Role{}, err
+			}
+		}
+		return updated, tx.Commit(ctx)
+	}()
+	if err != nil {
+		h.renderForm(c, http.StatusInternalServerError, role, input, "역할 수정에 실패했습니다.")
+		return
+	}
+
+	recordAudit(ctx, h.queries, sessionUserID(c), auditActionUpdate, auditEntityRole, role.ID,
+		auditRole{Role: role, Permissions: before}, auditRole{Role: updated, Permissions: after})
+
+	c.Redirect(http.StatusFound, "/admin/roles")
+}
+
+// DeleteRole removes a custom role that no user holds anymore
+func (h *RoleHandler) DeleteRole(c *gin.Context) {
+	ctx := c.Request.Context()
+	role, ok := h.loadRole(c)
+	if !ok {
+		return
+	}
+
+	if role.IsSystem {
+		h.renderRoles(c, http.StatusBadRequest, "기본 역할은 삭제할 수 없습니다.", roleFormInput{})
+		return
+	}
+	count, err := h.queries.CountUsersByRole(ctx, role.Name)
+	if err != nil {
+		h.renderRoles(c, http.StatusInternalServerError, "역할 삭제에 실패했습니다.", roleFormInput{})
+		return
+	}
+	if count > 0 {
+		h.renderRoles(c, http.StatusBadRequest, "이 역할을 가진 관리자가 있어 삭제할 수 없습니다. 먼저 다른 역할로 변경해주세요.", roleFormInput{})
+		return
+	}
+
+	rolePerms, _ := h.queries.ListRolePermissions(ctx, role.Name)
+	if err := h.queries.DeleteRole(ctx, role.ID); err != nil {
+		h.renderRoles(c, http.StatusInternalServerError, "역할 삭제에 실패했습니다.", roleFormInput{})
+		return
+	}
+
+	recordAudit(ctx, h.queries, sessionUserID(c), auditActionDelete, auditEntityRole, role.ID, auditRole{Role: role, Permissions: rolePerms}, nil)
+
+	c.Redirect(http.StatusFound, "/admin/roles")
+}
+
+func (h *RoleHandler) loadRole(c *gin.Context) (sqlc.Role, bool) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 32)
+	if err != nil {
+		c.Redirect(http.StatusFound, "/admin/roles")
+		return sqlc.Role{}, false
+	}
+
+	role, err := h.queries.GetRoleByID(c.Request.Context(), int32(id))
+	if errors.Is(err, pgx.ErrNoRows) {
+		c.HTML(http.StatusNotFound, "error.html", gin.H{
+			"error": "역할을 찾을 수 없습니다.",
+		})
+		return sqlc.Role{}, false
+	}
+	if err != nil {
+		c.HTML(http.StatusInternalServerError, "error.html", gin.H{
+			"error": "역할 정보를 불러오는데 실패했습니다.",
+		})
+		return sqlc.Role{}, false
+	}
+	return role, true
+}
+
+func (h *RoleHandler) renderRoles(c *gin.Context, status int, errMsg string, input roleFormInput) {
+	ctx := c.Request.Context()
+
+	roles, err := h.queries.ListRoles(ctx)
+	if err != nil {
+		c.HTML(http.StatusInternalServerError, "error.html", gin.H{
+			"error": "역할 목록을 불러오는데 실패했습니다.",
+		})
+		return
+	}
+	sets, err := rolePermissionSets(ctx, h.queries)
+	if err != nil {
+		c.HTML(http.StatusInternalServerError, "error.html", gin.H{
+			"error": "역할 목록을 불러오는데 실패했습니다.",
+		})
+		return
+	}
+	perms, err := h.queries.ListPermissions(ctx)
+	if err != nil {
+		c.HTML(http.StatusInternalServerError, "error.html", gin.H{
+			"error": "권한 목록을 불러오는데 실패했습니다.",
+		})
+		return
+	}
+
+	counts := make(map[string]int, len(roles))
+	for _, r := range roles {
+		counts[r.Name] = len(sets[r.Name])
+	}
+
+	renderPage(c, status, "roles.html", gin.H{
+		"roles":            roles,
+		"permissionCounts": counts,
+		"totalPermissions": len(perms),
+		"groups":           groupPermissions(perms),
+		"maxRoleSessions":  maxRoleSessions,
+		"input":            input,
+		"error":            errMsg,
+		"currentPage":      "users",
+	})
+}
+
+func (h *RoleHandler) renderForm(c *gin.Context, status int, role sqlc.Role, input roleFormInput, errMsg string) {
+	perms, err := h.queries.ListPermissions(c.Request.Context())
+	if err != nil {
+		c.HTML(http.StatusInternalServerError, "error.html", gin.H{
+			"error": "권한 목록을 불러오는데 실패했습니다.",
+		})
+		return
+	}
+
+	renderPage(c, status, "role_form.html", gin.H{
+		"roleItem":    role,
+		"input":       input,
+		"groups":      groupPermissions(perms),
+		"maxSessions": maxRoleSessions,
+		"locked":      role.Name == permission.SuperAdminRole || role.Name == c.GetString("role"),
+		"error":       errMsg,
+		"currentPage": "users",
+	})
+}
+
+// parseRoleForm reads the fields shared by the create and edit forms. Submitted permissions
+// are filtered against the editor's own before saving, which also drops unknown keys.
+func parseRoleForm(c *gin.Context) roleFormInput {
+	return roleFormInput{
+		DisplayName:      strings.TrimSpace(c.PostForm("display_name")),
+		Description:      strings.TrimSpace(c.PostForm("description")),
+		MaxSessions:      strings.TrimSpace(c.PostForm("max_sessions")),
+		RequireTwoFactor: c.PostForm("require_two_factor") != "",
+		Permissions:      permission.New(c.PostFormArray("permissions")),
+	}
+}
+
+func validateRoleForm(input roleFormInput) string {
+	if input.DisplayName == "" {
+		return "역할 이름을 입력해주세요."
+	}
+	if len([]rune(input.DisplayName)) > 50 {
+		return "역할 이름은 50자 이하로 입력해주세요."
+	}
+	if input.MaxSessions != "" {
+		if n, err := strconv.Atoi(input.MaxSessions); err != nil || n < 1 || n > maxRoleSessions {
+			return "동시 로그인 수는 1~" + strconv.Itoa(maxRoleSessions) + " 사이로 입력하거나 비워두세요."
+		}
+	}
+	return ""
+}
@@ -0,0 +1,349 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/choiexe1/hongik-academy/internal/db/sqlc"
+)
+
+// rubricMaxScoreLimit is the highest max_score a criterion may use (matches the DB check)
+const rubricMaxScoreLimit = 100
+
+// RubricHandler manages the rubric criteria evaluations are scored against
+type RubricHandler struct {
+	queries *sqlc.Queries
+}
+
+func NewRubricHandler(queries *sqlc.Queries) *RubricHandler {
+	return &RubricHandler{queries: queries}
+}
+
+func (h *RubricHandler) ListCriteria(c *gin.Context) {
+	h.renderCriteria(c, http.StatusOK, "")
+}
+
+func (h *RubricHandler) CreateCriterion(c *gin.Context) {
+	params, _, errMsg := parseCriterionForm(c)
+	if errMsg != "" {
+		h.renderCriteria(c, http.StatusBadRequest, errMsg)
+		return
+	}
+
+	_, err := h.queries.CreateRubricCriterion(c.Request.Context(), sqlc.CreateRubricCriterionParams{
+		Name:        params.Name,
+		Description: params.Description,
+		MaxScore:    params.MaxScore,
+		SortOrder:   params.SortOrder,
+	})
+	if err != nil {
+		h.renderCriteria(c, http.StatusInternalServerError, "평가 기준 등록에 실패했습니다. 같은 이름의 기준이 있는지 확인해주세요.")
+		return
+	}
+
+	c.Redirect(http.StatusFound, "/admin/rubric")
+}
+
+func (h *RubricHandler) ShowEditForm(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 32)
+	if err != nil {
+		c.Redirect(http.StatusFound, "/admin/rubric")
+		return
+	}
+
+	criterion, err := h.queries.GetRubricCriterionByID(c.Request.Context(), int32(id))
+	if err != nil {
+		c.Redirect(http.StatusFound, "/admin/rubric")
+		return
+	}
+
+	renderPage(c, http.StatusOK, "rubric_form.html", gin.H{
+		"criterion":   criterion,
+		"action":      "/admin/rubric/" + c.Param("id"),
+		"currentPage": "users",
+	})
+}
+
+// UpdateCriterion edits a criterion. The max score cannot drop below a score already given,
+// so recorded scores never exceed their scale.
+func (h *RubricHandler) UpdateCriterion(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 32)
+	if err != nil {
+		c.Redirect(http.StatusFound, "/admin/rubric")
+		return
+	}
+
+	criterion, err := h.queries.GetRubricCriterionByID(c.Request.Context(), int32(id))
+	if err != nil {
+		c.Redirect(http.StatusFound, "/admin/rubric")
+		return
+	}
+
+	params, active, errMsg := parseCriterionForm(c)
+	if errMsg == "" {
+		highest, err := h.queries.GetHighestScoreForCriterion(c.Request.Context(), criterion.ID)
+		if err != nil {
+			errMsg = "평가 기준 수정에 실패했습니다."
+		} else if params.MaxScore < highest {
+			errMsg = fmt.Sprintf("이미 %d점이 기록된 평가가 있어 만점을 %d점보다 낮출 수 없습니다.", highest, highest)
+		}
+	}
+	if errMsg == "" {
+		_, err = h.queries.UpdateRubricCriterion(c.Request.Context(), sqlc.UpdateRubricCriterionParams{
+			ID:          criterion.ID,
+			Name:        params.Name,
+			Description: params.Description,
+			MaxScore:    params.MaxScore,
+			SortOrder:   params.SortOrder,
+			Active:      active,
+		})
+		if err != nil {
+			errMsg = "평가 기준 수정에 실패했습니다. 같은 이름의 기준이 있는지 확인해주세요."
+		}
+	}
+
+	if errMsg != "" {
+		renderPage(c, http.StatusBadRequest, "rubric_form.html", gin.H{
+			"criterion":   criterion,
+			"action":      "/admin/rubric/" + c.Param("id"),
+			"error":       errMsg,
+			"currentPage": "users",
+		})
+		return
+	}
+
+	c.Redirect(http.StatusFound, "/admin/rubric")
+}
+
+// DeleteCriterion removes a criterion that has never been scored; scored criteria can only
+// be deactivated so past evaluations keep their scores
+func (h *RubricHandler) DeleteCriterion(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 32)
+	if err != nil {
+		c.Redirect(http.StatusFound, "/admin/rubric")
+		return
+	}
+
+	deleted, err := h.queries.DeleteRubricCriterion(c.Request.Context(), int32(id))
+	if err != nil {
+		h.renderCriteria(c, http.StatusInternalServerError, "평가 기준 삭제에 실패했습니다.")
+		return
+	}
+	if deleted == 0 {
+		h.renderCriteria(c, http.StatusBadRequest, "점수가 기록된 평가 기준은 삭제할 수 없습니다. 대신 사용 중지해주세요.")
+		return
+	}
+
+	c.Redirect(http.StatusFound, "/admin/rubric")
+}
+
+func (h *RubricHandler) renderCriteria(c *gin.Context, status int, errMsg string) {
+	criteria, err := h.queries.ListRubricCriteria(c.Request.Context())
+	if err != nil {
+		c.HTML(http.StatusInternalServerError, "error.html", gin.H{
+			"error": "평가 기준을 불러오는데 실패했습니다.",
+		})
+		return
+	}
+
+	renderPage(c, status, "rubric.html", gin.H{
+		"criteria":    criteria,
+		"error":       errMsg,
+		"currentPage": "users",
+	})
+}
+
+func parseCriterionForm(c *gin.Context) (sqlc.CreateRubricCriterionParams, bool, string) {
+	name := strings.TrimSpace(c.PostForm("name"))
+	description := strings.TrimSpace(c.PostForm("description"))
+
+	params := sqlc.CreateRubricCriterionParams{
+		Name:        name,
+		Description: pgtype.Text{String: description, Valid: description != ""},
+	}
+	active := c.PostForm("active") == "on"
+
+	if name == "" {
+		return params, active, "기준 이름을 입력해주세요."
+	}
+	if len([]rune(name)) > 50 {
+		return params, active, "기준 이름은 50자 이하로 입력해주세요."
+	}
+
+	maxScore, err := strconv.Atoi(c.PostForm("max_score"))
+	if err != nil || maxScore < 1 || maxScore > rubricMaxScoreLimit {
+		return params, active, fmt.Sprintf("만점은 1~%d 사이의 숫자로 입력해주세요.", rubricMaxScoreLimit)
+	}
+	params.MaxScore = int32(maxScore)
+
+	sortOrder, err := strconv.Atoi(c.DefaultPostForm("sort_order", "0"))
+	if err != nil {
+		return params, active, "표시 순서는 숫자로 입력해주세요."
+	}
+	params.SortOrder = int32(sortOrder)
+
+	return params, active, ""
+}
+
+// rubricScoreInput is one criterion row of the evaluation form
+type rubricScoreInput struct {
+	ID          int32
+	Name        string
+	Description string
+	MaxScore    int32
+	Options     []int32
+	Score       int32 // 0이면 미채점
+	Active      bool
+}
+
+// rubricScoreInputs builds the score inputs of the evaluation form: every active criterion,
+// plus inactive ones the evaluation already has a score for so editing does not drop them
+func rubricScoreInputs(criteria []sqlc.RubricCriterion, scores map[int32]int32) []rubricScoreInput {
+	var inputs []rubricScoreInput
+	for _, cr := range criteria {
+		score, scored := scores[cr.ID]
+		if !cr.Active && !scored {
+			continue
+		}
+
+		options := make([]int32, cr.MaxScore)
+		for i := range options {
+			options[i] = int32(i + 1)
+		}
+		inputs = append(inputs, rubricScoreInput{
+			ID:          cr.ID,
+			Name:        cr.Name,
+			Description: cr.Description.String,
+			MaxScore:    cr.MaxScore,
+			Options:     options,
+			Score:       score,
+			Active:      cr.Active,
+		})
+	}
+	return inputs
+}
+
+// parseEvaluationScores reads the score_<criterion id> fields of the evaluation form. An
+// empty field leaves the criterion unscored, so text-only evaluations stay valid.
+func parseEvaluationScores(c *gin.Context, inputs []rubricScoreInput) (map[int32]int32, string) {
+	scores := make(map[int32]int32)
+	for _, in := range inputs {
+		value := c.PostForm(fmt.Sprintf("score_%d", in.ID))
+		if value == "" {
+			continue
+		}
+		score, err := strconv.Atoi(value)
+		if err != nil || score < 1 || score > int(in.MaxScore) {
+			return scores, fmt.Sprintf("%s 점수는 1~%d점 사이로 선택해주세요.", in.Name, in.MaxScore)
+		}
+		scores[in.ID] = int32(score)
+	}
+	return scores, ""
+}
+
+// withSubmittedScores returns a copy of inputs holding the submitted scores, so a re-rendered
+// form keeps what the user picked
+func withSubmittedScores(inputs []rubricScoreInput, scores map[int32]int32) []rubricScoreInput {
+	submitted := make([]rubricScoreInput, len(inputs))
+	for i, in := range inputs {
+		in.Score = scores[in.ID]
+		submitted[i] = in
+	}
+	return submitted
+}
+
+// saveEvaluationScores stores the scores of the criteria shown on the form and clears the
+// ones left empty
+func saveEvaluationScores(ctx context.Context, queries *sqlc.Queries, evaluationID int32, inputs []rubricScoreInput, scores map[int32]int32) error {
+	for _, in := range inputs {
+		if score, ok := scores[in.ID]; ok {
+			if err := queries.UpsertEvaluationScore(ctx, sqlc.UpsertEvaluationScoreParams{
+				EvaluationID: evaluationID,
+				CriterionID:  in.ID,
+				Score:        score,
+			}); err != nil {
+				return err
+			}
+			continue
+		}
+		if err := queries.DeleteEvaluationScore(ctx, sqlc.DeleteEvaluationScoreParams{
+			EvaluationID: evaluationID,
+			CriterionID:  in.ID,
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// evaluationScoreBadge is a score as shown in the evaluation list
+type evaluationScoreBadge struct {
+	Name     string
+	Score    int32
+	MaxScore int32
+}
+
+// evaluationScoreBadges groups scores by evaluation id; rows come in criterion display order
+func evaluationScoreBadges(criteria []sqlc.RubricCriterion, rows []sqlc.EvaluationScore) map[int32][]evaluationScoreBadge {
+	byID := make(map[int32]sqlc.RubricCriterion, len(criteria))
+	for _, cr := range criteria {
+		byID[cr.ID] = cr
+	}
+
+	badges := make(map[int32][]evaluationScoreBadge)
+	for _, r := range rows {
+		cr := byID[r.CriterionID]
+		badges[r.EvaluationID] = append(badges[r.EvaluationID], evaluationScoreBadge{Name: cr.Name, Score: r.Score, MaxScore: cr.MaxScore})
+	}
+	return badges
+}
+
+// scoreProgressSeries is one line of the progress chart: a criterion's scores over time
+type scoreProgressSeries struct {
+	Name     string   `json:"name"`
+	MaxScore int32    `json:"maxScore"`
+	Scores   []*int32 `json:"scores"`
+}
+
+// scoreProgress is the data of a student's progress chart; Scores line up with Labels and
+// hold nil where an evaluation did not score that criterion
+type scoreProgress struct {
+	Labels []string              `json:"labels"`
+	Series []scoreProgressSeries `json:"series"`
+}
+
+// buildScoreProgress turns the student's scores (oldest evaluation first) into chart series,
+// one point per scored evaluation
+func buildScoreProgress(criteria []sqlc.RubricCriterion, rows []sqlc.ListScoreProgressByStudentRow) scoreProgress {
+	var progress scoreProgress
+
+	index := make(map[int32]int) // evaluation id -> label index
+	for _, r := range rows {
+		if _, ok := index[r.EvaluationID]; !ok {
+			index[r.EvaluationID] = len(progress.Labels)
+			progress.Labels = append(progress.Labels, r.CreatedAt.Time.Format("2006-01-02"))
+		}
+	}
+
+	for _, cr := range criteria {
+		series := scoreProgressSeries{Name: cr.Name, MaxScore: cr.MaxScore, Scores: make([]*int32, len(progress.Labels))}
+		scored := false
+		for _, r := range rows {
+			if r.CriterionID == cr.ID {
+				score := r.Score
+				series.Scores[index[r.EvaluationID]] = &score
+				scored = true
+			}
+		}
+		if scored {
+			progress.Series = append(progress.Series, series)
+		}
+	}
+	return progress
+}
@@ -0,0 +1,51 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestParseEvaluationScores(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	inputs := []rubricScoreInput{
+		{ID: 1, Name: "구도", MaxScore: 5},
+		{ID: 2, Name: "명암", MaxScore: 3},
+	}
+
+	tests := []struct {
+		name    string
+		form    url.Values
+		want    map[int32]int32
+		wantErr string
+	}{
+		{"all scored", url.Values{"score_1": {"5"}, "score_2": {"1"}}, map[int32]int32{1: 5, 2: 1}, ""},
+		{"empty fields stay unscored", url.Values{"score_1": {""}, "score_2": {"2"}}, map[int32]int32{2: 2}, ""},
+		{"nothing submitted", url.Values{}, map[int32]int32{}, ""},
+		{"unknown criterion ignored", url.Values{"score_9": {"4"}}, map[int32]int32{}, ""},
+		{"zero", url.Values{"score_1": {"0"}}, nil, "구도 점수는 1~5점 사이로 선택해주세요."},
+		{"negative", url.Values{"score_1": {"-1"}}, nil, "구도 점수는 1~5점 사이로 선택해주세요."},
+		{"above the criterion's max", url.Values{"score_2": {"4"}}, nil, "명암 점수는 1~3점 사이로 선택해주세요."},
+		{"not a number", url.Values{"score_1": {"다섯"}}, nil, "구도 점수는 1~5점 사이로 선택해주세요."},
+		{"overflows int32", url.Values{"score_1": {"4294967297"}}, nil, "구도 점수는 1~5점 사이로 선택해주세요."},
+	}
+	for _, tt := range tests {
+		c, _ := gin.CreateTestContext(httptest.NewRecorder())
+		c.Request = httptest.NewRequest(http.MethodPost, "/", strings.NewReader(tt.form.Encode()))
+		c.Request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+		got, errMsg := parseEvaluationScores(c, inputs)
+		if errMsg != tt.wantErr {
+			t.Errorf("%s: error = %q, want %q", tt.name, errMsg, tt.wantErr)
+			continue
+		}
+		if tt.wantErr == "" && !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: scores = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
@@ -1,16 +1,19 @@
 package handlers
 
 import (
+	"context"
 	"math"
 	"net/http"
 	"regexp"
 	"strconv"
+	"time"
 
-	"github.com/gin-contrib/sessions"
 	"github.com/gin-gonic/gin"
 	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
 
 	"github.com/choiexe1/hongik-academy/internal/db/sqlc"
+	"github.com/choiexe1/hongik-academy/internal/spreadsheet"
 )
 
 var nonDigitRegex = regexp.MustCompile(`[^0-9]`)
@@ -20,23 +23,123 @@ func sanitizePhone(phone string) string {
 	return nonDigitRegex.ReplaceAllString(phone, "")
 }
 
+// studentStatuses lists every student status in lifecycle order
+var studentStatuses = []string{"enrolled", "on_leave", "graduated", "withdrawn"}
+
+func isValidStudentStatus(status string) bool {
+	for _, s := range studentStatuses {
+		if s == status {
+			return true
+		}
+	}
+	return false
+}
+
+// studentStatusFilter turns the status query param into the statuses passed to
+// ListStudents/CountStudents. An empty value shows current students (enrolled and on leave),
+// "all" shows every status, and an unknown value falls back to the default.
+func studentStatusFilter(status string) (string, []string) {
+	switch {
+	case status == "all":
+		return status, nil
+	case isValidStudentStatus(status):
+		return status, []string{status}
+	default:
+		return "", []string{"enrolled", "on_leave"}
+	}
+}
+
+// parseOptionalDate parses a YYYY-MM-DD form value; an empty or malformed value is NULL
+func parseOptionalDate(value string) pgtype.Date {
+	t, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		return pgtype.Date{Valid: false}
+	}
+	return pgtype.Date{Time: t, Valid: true}
+}
+
+// studentStatusErrors maps the ?error= codes of the status change redirect to messages
+var studentStatusErrors = map[string]string{
+	"status_invalid":   "변경할 상태를 선택해주세요.",
+	"status_unchanged": "현재와 같은 상태로는 변경할 수 없습니다.",
+	"status_date":      "변경일이 올바르지 않습니다.",
+	"status_failed":    "상태 변경에 실패했습니다.",
+}
+
+// studentFilter holds the search, gender, class_id and status params shared by the student
+// list and its export, both as given and as query params
+type studentFilter struct {
+	search, gender, classID, status string
+
+	searchParam pgtype.Text
+	genderParam pgtype.Text
+	classParam  pgtype.Int4
+	statuses    []string
+}
+
+func parseStudentFilter(c *gin.Context) studentFilter {
+	f := studentFilter{
+		// 검색어
+		search: c.Query("search"),
+		// 성별 필터
+		gender: c.Query("gender"),
+		// 반 필터
+		classID: c.Query("class_id"),
+	}
+	// 상태 필터 (기본값: 재원 + 휴원)
+	f.status, f.statuses = studentStatusFilter(c.Query("status"))
+
+	if f.search != "" {
+		f.searchParam = pgtype.Text{String: f.search, Valid: true}
+	}
+	if f.gender == "M" || f.gender == "F" {
+		f.genderParam = pgtype.Text{String: f.gender, Valid: true}
+	}
+	if id, err := strconv.ParseInt(f.classID, 10, 32); err == nil {
+		f.classParam = pgtype.Int4{Int32: int32(id), Valid: true}
+	} else {
+		f.classID = ""
+	}
+	return f
+}
+
 type StudentHandler struct {
+	pool    *pgxpool.Pool
 	queries *sqlc.Queries
 }
 
-func NewStudentHandler(queries *sqlc.Queries) *StudentHandler {
-	return &StudentHandler{queries: queries}
+func NewStudentHandler(pool *pgxpool.Pool, queries *sqlc.Queries) *StudentHandler {
+	return &StudentHandler{pool: pool, queries: queries}
+}
+
+// createStudent registers a student together with the enrollment entry that starts its
+// status history, so a student never exists without one
+func createStudent(ctx context.Context, pool *pgxpool.Pool, queries *sqlc.Queries, params sqlc.CreateStudentParams, userID int32) (sqlc.Student, error) {
+	tx, err := pool.Begin(ctx)
+	if err != nil {
+		return sqlc.Student{}, err
+	}
+	defer tx.Rollback(ctx)
+	qtx := queries.WithTx(tx)
+
+	student, err := qtx.CreateStudent(ctx, params)
+	if err != nil {
+		return sqlc.Student{}, err
+	}
+	// 입학 기록을 상태 이력의 첫 항목으로 남김
+	if err := qtx.CreateStudentStatusHistory(ctx, sqlc.CreateStudentStatusHistoryParams{
+		StudentID: student.ID,
+		ToStatus:  student.Status,
+		ChangedOn: student.EnrolledOn,
+		ChangedBy: pgtype.Int4{Int32: userID, Valid: userID != 0},
+	}); err != nil {
+		return sqlc.Student{}, err
+	}
+	return student, tx.Commit(ctx)
 }
 
 func (h *StudentHandler) ListStudents(c *gin.Context) {
-	session := sessions.Default(c)
-	username := session.Get("username")
-	role := session.Get("role")
-
-	// 검색어
-	search := c.Query("search")
-	// 성별 필터
-	gender := c.Query("gender")
+	f := parseStudentFilter(c)
 	// 페이지 (기본값 1)
 	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
 	if page < 1 {
@@ -50,20 +153,12 @@ func (h *StudentHandler) ListStudents(c *gin.Context) {
 
 	offset := (page - 1) * perPage
 
-	// 필터 파라미터 설정
-	searchParam := pgtype.Text{Valid: false}
-	if search != "" {
-		searchParam = pgtype.Text{String: search, Valid: true}
-	}
-	genderParam := pgtype.Text{Valid: false}
-	if gender != "" && (gender == "M" || gender == "F") {
-		genderParam = pgtype.Text{String: gender, Valid: true}
-	}
-
 	// 전체 개수 조회
 	totalCount, err := h.queries.CountStudents(c.Request.Context(), sqlc.CountStudentsParams{
-		Search: searchParam,
-		Gender: genderParam,
+		Search:   f.searchParam,
+		Gender:   f.genderParam,
+		ClassID:  f.classParam,
+		Statuses: f.statuses,
 	})
 	if err != nil {
 		c.HTML(http.StatusInternalServerError, "error.html", gin.H{
@@ -74,10 +169,12 @@ func (h *StudentHandler) ListStudents(c *gin.Context) {
 
 	// 학생 목록 조회
 	students, err := h.queries.ListStudents(c.Request.Context(), sqlc.ListStudentsParams{
-		Limit:  int32(perPage),
-		Offset: int32(offset),
-		Search: searchParam,
-		Gender: genderParam,
+		Limit:    int32(perPage),
+		Offset:   int32(offset),
+		Search:   f.searchParam,
+		Gender:   f.genderParam,
+		ClassID:  f.classParam,
+		Statuses: f.statuses,
 	})
 	if err != nil {
 		c.HTML(http.StatusInternalServerError, "error.html", gin.H{
@@ -86,33 +183,58 @@ func (h *StudentHandler) ListStudents(c *gin.Context) {
 		return
 	}
 
+	// 반 필터 선택지
+	classes, err := h.queries.ListClasses(c.Request.Context())
+	if err != nil {
+		c.HTML(http.StatusInternalServerError, "error.html", gin.H{
+			"error": "반 목록을 불러오는데 실패했습니다.",
+		})
+		return
+	}
+
 	totalPages := int(math.Ceil(float64(totalCount) / float64(perPage)))
 
-	c.HTML(http.StatusOK, "students.html", gin.H{
+	renderPage(c, http.StatusOK, "students.html", gin.H{
 		"students":    students,
-		"search":      search,
-		"gender":      gender,
+		"classes":     classes,
+		"search":      f.search,
+		"gender":      f.gender,
+		"classID":     f.classID,
+		"status":      f.status,
 		"page":        page,
 		"perPage":     perPage,
 		"totalCount":  totalCount,
 		"totalPages":  totalPages,
-		"username":    username,
-		"role":        role,
 		"currentPage": "students",
 	})
 }
 
-func (h *StudentHandler) ShowCreateForm(c *gin.Context) {
-	session := sessions.Default(c)
-	username := session.Get("username")
-	role := session.Get("role")
+// ExportStudents downloads every student matching the list filters as CSV or XLSX (format=xlsx)
+func (h *StudentHandler) ExportStudents(c *gin.Context) {
+	f := parseStudentFilter(c)
 
-	c.HTML(http.StatusOK, "student_form.html", gin.H{
+	students, err := h.queries.ExportStudents(c.Request.Context(), sqlc.ExportStudentsParams{
+		Search:   f.searchParam,
+		Gender:   f.genderParam,
+		ClassID:  f.classParam,
+		Statuses: f.statuses,
+	})
+	if err != nil {
+		c.HTML(http.StatusInternalServerError, "error.html", gin.H{
+			"error": "원생 목록을 불러오는데 실패했습니다.",
+		})
+		return
+	}
+
+	filename := "원생목록_" + time.Now().Format("20060102")
+	sendSpreadsheet(c, spreadsheet.ParseFormat(c.Query("format")), filename, "원생", studentExportHeader, studentExportRows(students))
+}
+
+func (h *StudentHandler) ShowCreateForm(c *gin.Context) {
+	renderPage(c, http.StatusOK, "student_form.html", gin.H{
 		"title":       "원생 등록",
 		"action":      "/students",
 		"student":     nil,
-		"username":    username,
-		"role":        role,
 		"currentPage": "students",
 	})
 }
@@ -123,9 +245,10 @@ func (h *StudentHandler) CreateStudent(c *gin.Context) {
 	phone := sanitizePhone(c.PostForm("phone"))
 	parentPhone := sanitizePhone(c.PostForm("parent_phone"))
 	remarks := c.PostForm("remarks")
+	enrolledOn := parseOptionalDate(c.PostForm("enrolled_on"))
 
 	if name == "" {
-		c.HTML(http.StatusBadRequest, "student_form.html", gin.H{
+		renderPage(c, http.StatusBadRequest, "student_form.html", gin.H{
 			"title":  "원생 등록",
 			"action": "/students",
 			"error":  "이름을 입력해주세요.",
@@ -134,7 +257,7 @@ func (h *StudentHandler) CreateStudent(c *gin.Context) {
 	}
 
 	if gender != "M" && gender != "F" {
-		c.HTML(http.StatusBadRequest, "student_form.html", gin.H{
+		renderPage(c, http.StatusBadRequest, "student_form.html", gin.H{
 			"title":  "원생 등록",
 			"action": "/students",
 			"error":  "성별을 선택해주세요.",
@@ -142,16 +265,18 @@ func (h *StudentHandler) CreateStudent(c *gin.Context) {
 		return
 	}
 
-	_, err := h.queries.CreateStudent(c.Request.Context(), sqlc.CreateStudentParams{
+	userID := sessionUserID(c)
+	student, err := createStudent(c.Request.Context(), h.pool, h.queries, sqlc.CreateStudentParams{
 		Name:        name,
 		Gender:      gender,
 		Phone:       pgtype.Text{String: phone, Valid: phone != ""},
 		ParentPhone: pgtype.Text{String: parentPhone, Valid: parentPhone != ""},
 		Remarks:     pgtype.Text{String: remarks, Valid: remarks != ""},
-	})
+		EnrolledOn:  enrolledOn,
+	}, userID)
 
 	if err != nil {
-		c.HTML(http.StatusInternalServerError, "student_form.html", gin.H{
+		renderPage(c, http.StatusInternalServerError, "student_form.html", gin.H{
 			"title":  "원생 등록",
 			"action": "/students",
 			"error":  "원생 등록에 실패했습니다.",
@@ -159,14 +284,12 @@ func (h *StudentHandler) CreateStudent(c *gin.Context) {
 		return
 	}
 
+	recordAudit(c.Request.Context(), h.queries, userID, auditActionCreate, auditEntityStudent, student.ID, nil, student)
+
 	c.Redirect(http.StatusFound, "/students")
 }
 
 func (h *StudentHandler) ShowEditForm(c *gin.Context) {
-	session := sessions.Default(c)
-	username := session.Get("username")
-	role := session.Get("role")
-
 	id, err := strconv.ParseInt(c.Param("id"), 10, 32)
 	if err != nil {
 		c.Redirect(http.StatusFound, "/students")
@@ -179,13 +302,32 @@ func (h *StudentHandler) ShowEditForm(c *gin.Context) {
 		return
 	}
 
-	c.HTML(http.StatusOK, "student_form.html", gin.H{
-		"title":       "원생 수정",
-		"action":      "/students/" + c.Param("id"),
-		"student":     student,
-		"username":    username,
-		"role":        role,
-		"currentPage": "students",
+	balance, err := h.queries.GetStudentTuitionBalance(c.Request.Context(), student.ID)
+	if err != nil {
+		c.HTML(http.StatusInternalServerError, "error.html", gin.H{
+			"error": "수강료 잔액을 불러오는데 실패했습니다.",
+		})
+		return
+	}
+
+	statusHistory, err := h.queries.ListStudentStatusHistory(c.Request.Context(), student.ID)
+	if err != nil {
+		c.HTML(http.StatusInternalServerError, "error.html", gin.H{
+			"error": "상태 변경 이력을 불러오는데 실패했습니다.",
+		})
+		return
+	}
+
+	renderPage(c, http.StatusOK, "student_form.html", gin.H{
+		"title":         "원생 수정",
+		"action":        "/students/" + c.Param("id"),
+		"student":       student,
+		"outstanding":   balance.TotalBilled - balance.TotalPaid,
+		"statuses":      studentStatuses,
+		"statusHistory": statusHistory,
+		"statusError":   studentStatusErrors[c.Query("error")],
+		"today":         time.Now().Format("2006-01-02"),
+		"currentPage":   "students",
 	})
 }
 
@@ -201,9 +343,10 @@ func (h *StudentHandler) UpdateStudent(c *gin.Context) {
 	phone := sanitizePhone(c.PostForm("phone"))
 	parentPhone := sanitizePhone(c.PostForm("parent_phone"))
 	remarks := c.PostForm("remarks")
+	enrolledOn := parseOptionalDate(c.PostForm("enrolled_on"))
 
 	if name == "" {
-		c.HTML(http.StatusBadRequest, "student_form.html", gin.H{
+		renderPage(c, http.StatusBadRequest, "student_form.html", gin.H{
 			"title":  "원생 수정",
 			"action": "/students/" + c.Param("id"),
 			"error":  "이름을 입력해주세요.",
@@ -212,7 +355,7 @@ func (h *StudentHandler) UpdateStudent(c *gin.Context) {
 	}
 
 	if gender != "M" && gender != "F" {
-		c.HTML(http.StatusBadRequest, "student_form.html", gin.H{
+		renderPage(c, http.StatusBadRequest, "student_form.html", gin.H{
 			"title":  "원생 수정",
 			"action": "/students/" + c.Param("id"),
 			"error":  "성별을 선택해주세요.",
@@ -220,17 +363,24 @@ func (h *StudentHandler) UpdateStudent(c *gin.Context) {
 		return
 	}
 
-	_, err = h.queries.UpdateStudent(c.Request.Context(), sqlc.UpdateStudentParams{
+	before, err := h.queries.GetStudentByID(c.Request.Context(), int32(id))
+	if err != nil {
+		c.Redirect(http.StatusFound, "/students")
+		return
+	}
+
+	student, err := h.queries.UpdateStudent(c.Request.Context(), sqlc.UpdateStudentParams{
 		ID:          int32(id),
 		Name:        name,
 		Gender:      gender,
 		Phone:       pgtype.Text{String: phone, Valid: phone != ""},
 		ParentPhone: pgtype.Text{String: parentPhone, Valid: parentPhone != ""},
 		Remarks:     pgtype.Text{String: remarks, Valid: remarks != ""},
+		EnrolledOn:  enrolledOn,
 	})
 
 	if err != nil {
-		c.HTML(http.StatusInternalServerError, "student_form.html", gin.H{
+		renderPage(c, http.StatusInternalServerError, "student_form.html", gin.H{
 			"title":  "원생 수정",
 			"action": "/students/" + c.Param("id"),
 			"error":  "원생 수정에 실패했습니다.",
@@ -238,6 +388,8 @@ func (h *StudentHandler) UpdateStudent(c *gin.Context) {
 		return
 	}
 
+	recordAudit(c.Request.Context(), h.queries, sessionUserID(c), auditActionUpdate, auditEntityStudent, student.ID, before, student)
+
 	c.Redirect(http.StatusFound, "/students")
 }
 
@@ -248,7 +400,97 @@ func (h *StudentHandler) DeleteStudent(c *gin.Context) {
 		return
 	}
 
-	h.queries.DeleteStudent(c.Request.Context(), int32(id))
+	before, err := h.queries.GetStudentByID(c.Request.Context(), int32(id))
+	if err != nil {
+		c.Redirect(http.StatusFound, "/students")
+		return
+	}
+
+	if err := h.queries.DeleteStudent(c.Request.Context(), int32(id)); err == nil {
+		recordAudit(c.Request.Context(), h.queries, sessionUserID(c), auditActionDelete, auditEntityStudent, before.ID, before, nil)
+	}
 
 	c.Redirect(http.StatusFound, "/students")
 }
+
+// ChangeStatus moves a student to another status and records the change in the status history.
+// The date is the leave/graduation/withdrawal date, or the return date when going back to enrolled.
+func (h *StudentHandler) ChangeStatus(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 32)
+	if err != nil {
+		c.Redirect(http.StatusFound, "/students")
+		return
+	}
+	editURL := "/students/" + c.Param("id") + "/edit"
+
+	before, err := h.queries.GetStudentByID(c.Request.Context(), int32(id))
+	if err != nil {
+		c.Redirect(http.StatusFound, "/students")
+		return
+	}
+
+	status := c.PostForm("status")
+	if !isValidStudentStatus(status) {
+		c.Redirect(http.StatusFound, editURL+"?error=status_invalid")
+		return
+	}
+	if status == before.Status {
+		c.Redirect(http.StatusFound, editURL+"?error=status_unchanged")
+		return
+	}
+
+	changedOn := parseOptionalDate(c.PostForm("changed_on"))
+	if !changedOn.Valid {
+		c.Redirect(http.StatusFound, editURL+"?error=status_date")
+		return
+	}
+	reason := c.PostForm("reason")
+	reasonParam := pgtype.Text{String: reason, Valid: reason != ""}
+
+	// 재원으로 돌아오면 휴원/퇴원일은 비움
+	leftOn := changedOn
+	if status == "enrolled" {
+		leftOn = pgtype.Date{Valid: false}
+	}
+
+	// 상태와 이력이 어긋나지 않도록 함께 저장
+	ctx := c.Request.Context()
+	userID := sessionUserID(c)
+	student, err := func() (sqlc.Student, error) {
+		tx, err := h.pool.Begin(ctx)
+		if err != nil {
+			return sqlc.Student{}, err
+		}
+		defer tx.Rollback(ctx)
+		qtx := h.queries.WithTx(tx)
+
+		student, err := qtx.UpdateStudentStatus(ctx, sqlc.UpdateStudentStatusParams{
+			ID:           before.ID,
+			Status:       status,
+			LeftOn:       leftOn,
+			StatusReason: reasonParam,
+		})
+		if err != nil {
+			return sqlc.Student{}, err
+		}
+		if err := qtx.CreateStudentStatusHistory(ctx, sqlc.CreateStudentStatusHistoryParams{
+			StudentID:  student.ID,
+			FromStatus: pgtype.Text{String: before.Status, Valid: true},
+			ToStatus:   student.Status,
+			ChangedOn:  changedOn,
+			Reason:     reasonParam,
+			ChangedBy:  pgtype.Int4{Int32: userID, Valid: userID != 0},
+		}); err != nil {
+			return sqlc.Student{}, err
+		}
+		return student, tx.Commit(ctx)
+	}()
+	if err != nil {
+		c.Redirect(http.StatusFound, editURL+"?error=status_failed")
+		return
+	}
+
+	recordAudit(ctx, h.queries, userID, auditActionUpdate, auditEntityStudent, student.ID, before, student)
+
+	c.Redirect(http.StatusFound, editURL)
+}
@@ -0,0 +1,405 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/choiexe1/hongik-academy/internal/db/sqlc"
+	"github.com/choiexe1/hongik-academy/internal/spreadsheet"
+)
+
+const (
+	// studentImportMaxFileSize limits uploaded spreadsheets to 5MB
+	studentImportMaxFileSize = 5 << 20
+	// studentImportMaxRows limits how many students one file may register
+	studentImportMaxRows = 1000
+)
+
+// studentImportColumns maps accepted header names to the field they fill
+var studentImportColumns = map[string]string{
+	"이름":           "name",
+	"name":         "name",
+	"성별":           "gender",
+	"gender":       "gender",
+	"연락처":          "phone",
+	"전화번호":         "phone",
+	"phone":        "phone",
+	"학부모 연락처":      "parent_phone",
+	"학부모연락처":       "parent_phone",
+	"parent_phone": "parent_phone",
+	"특이사항":         "remarks",
+	"remarks":      "remarks",
+	"입학일":          "enrolled_on",
+	"enrolled_on":  "enrolled_on",
+}
+
+// studentImportDateLayouts are the date formats accepted in the 입학일 column
+var studentImportDateLayouts = []string{"2006-01-02", "2006.01.02", "2006/01/02", "20060102", "2006. 1. 2"}
+
+// studentImportRow is one parsed spreadsheet row. The raw values are carried from the
+// preview page to the confirm step as JSON and validated again before inserting.
+type studentImportRow struct {
+	Line        int    `json:"line"`
+	Name        string `json:"name"`
+	Gender      string `json:"gender"`
+	Phone       string `json:"phone"`
+	ParentPhone string `json:"parent_phone"`
+	Remarks     string `json:"remarks"`
+	EnrolledOn  string `json:"enrolled_on"`
+
+	Errors      []string `json:"-"`
+	DuplicateOf string   `json:"-"`
+}
+
+func (r studentImportRow) Valid() bool {
+	return len(r.Errors) == 0
+}
+
+// StudentImportHandler registers students in bulk from CSV and XLSX files. It needs the pool
+// so the whole import runs in a single transaction.
+type StudentImportHandler struct {
+	pool    *pgxpool.Pool
+	queries *sqlc.Queries
+}
+
+func NewStudentImportHandler(pool *pgxpool.Pool, queries *sqlc.Queries) *StudentImportHandler {
+	return &StudentImportHandler{pool: pool, queries: queries}
+}
+
+func (h *StudentImportHandler) ShowImportPage(c *gin.Context) {
+	imported, _ := strconv.Atoi(c.Query("imported"))
+	h.render(c, http.StatusOK, gin.H{"imported": imported})
+}
+
+// PreviewImport parses the uploaded file and shows every row with its validation errors and
+// likely duplicates; nothing is stored yet
+func (h *StudentImportHandler) PreviewImport(c *gin.Context) {
+	file, err := c.FormFile("file")
+	if err != nil {
+		h.render(c, http.StatusBadRequest, gin.H{"error": "업로드할 파일을 선택해주세요."})
+		return
+	}
+	if file.Size > studentImportMaxFileSize {
+		h.render(c, http.StatusBadRequest, gin.H{"error": "파일 크기는 5MB 이하여야 합니다."})
+		return
+	}
+
+	f, err := file.Open()
+	if err != nil {
+		h.render(c, http.StatusBadRequest, gin.H{"error": "파일을 열 수 없습니다."})
+		return
+	}
+	defer f.Close()
+
+	records, err := spreadsheet.ReadRows(file.Filename, f)
+	if err == spreadsheet.ErrUnsupportedFormat {
+		h.render(c, http.StatusBadRequest, gin.H{"error": "CSV 또는 XLSX 파일만 업로드할 수 있습니다."})
+		return
+	}
+	if err != nil {
+		h.render(c, http.StatusBadRequest, gin.H{"error": "파일을 읽는 중 오류가 발생했습니다. 파일 형식을 확인해주세요."})
+		return
+	}
+
+	rows, errMsg := parseStudentImportRows(records)
+	if errMsg != "" {
+		h.render(c, http.StatusBadRequest, gin.H{"error": errMsg})
+		return
+	}
+
+	if err := h.checkRows(c, rows); err != nil {
+		h.render(c, http.StatusInternalServerError, gin.H{"error": "기존 원생과의 중복 여부를 확인하는데 실패했습니다."})
+		return
+	}
+
+	rowsJSON, err := json.Marshal(rows)
+	if err != nil {
+		h.render(c, http.StatusInternalServerError, gin.H{"error": "미리보기를 만드는데 실패했습니다."})
+		return
+	}
+
+	validCount, duplicateCount := 0, 0
+	for _, row := range rows {
+		if row.Valid() {
+			validCount++
+			if row.DuplicateOf != "" {
+				duplicateCount++
+			}
+		}
+	}
+
+	h.render(c, http.StatusOK, gin.H{
+		"filename":       file.Filename,
+		"rows":           rows,
+		"rowsJSON":       string(rowsJSON),
+		"validCount":     validCount,
+		"invalidCount":   len(rows) - validCount,
+		"duplicateCount": duplicateCount,
+	})
+}
+
+// ConfirmImport inserts every valid row of the preview in one transaction. Likely duplicates
+// are skipped unless include_duplicates is checked.
+func (h *StudentImportHandler) ConfirmImport(c *gin.Context) {
+	var rows []studentImportRow
+	if err := json.Unmarshal([]byte(c.PostForm("rows")), &rows); err != nil || len(rows) == 0 {
+		h.render(c, http.StatusBadRequest, gin.H{"error": "가져올 데이터가 없습니다. 파일을 다시 업로드해주세요."})
+		return
+	}
+	if len(rows) > studentImportMaxRows {
+		h.render(c, http.StatusBadRequest, gin.H{"error": fmt.Sprintf("한 번에 최대 %d명까지 등록할 수 있습니다.", studentImportMaxRows)})
+		return
+	}
+	includeDuplicates := c.PostForm("include_duplicates") == "on"
+
+	// 미리보기 이후 다른 사용자가 등록했을 수 있으므로 다시 검사
+	for i := range rows {
+		rows[i].Errors = validateStudentImportRow(&rows[i])
+	}
+	if err := h.checkRows(c, rows); err != nil {
+		h.render(c, http.StatusInternalServerError, gin.H{"error": "기존 원생과의 중복 여부를 확인하는데 실패했습니다."})
+		return
+	}
+
+	var toInsert []studentImportRow
+	for _, row := range rows {
+		if !row.Valid() || (row.DuplicateOf != "" && !includeDuplicates) {
+			continue
+		}
+		toInsert = append(toInsert, row)
+	}
+	if len(toInsert) == 0 {
+		h.render(c, http.StatusBadRequest, gin.H{"error": "등록할 수 있는 원생이 없습니다."})
+		return
+	}
+
+	ctx := c.Request.Context()
+	tx, err := h.pool.Begin(ctx)
+	if err != nil {
+		h.render(c, http.StatusInternalServerError, gin.H{"error": "원생 일괄 등록에 실패했습니다."})
+		return
+	}
+	defer tx.Rollback(ctx)
+	qtx := h.queries.WithTx(tx)
+
+	userID := sessionUserID(c)
+	created := make([]sqlc.Student, 0, len(toInsert))
+	for _, row := range toInsert {
+		student, err := qtx.CreateStudent(ctx, sqlc.CreateStudentParams{
+			Name:        row.Name,
+			Gender:      row.Gender,
+			Phone:       pgtype.Text{String: row.Phone, Valid: row.Phone != ""},
+			ParentPhone: pgtype.Text{String: row.ParentPhone, Valid: row.ParentPhone != ""},
+			Remarks:     pgtype.Text{String: row.Remarks, Valid: row.Remarks != ""},
+			EnrolledOn:  parseOptionalDate(row.EnrolledOn),
+		})
+		if err != nil {
+			h.render(c, http.StatusInternalServerError, gin.H{
+				"error": fmt.Sprintf("%d행 등록 중 오류가 발생해 전체 등록을 취소했습니다.", row.Line),
+			})
+			return
+		}
+
+		err = qtx.CreateStudentStatusHistory(ctx, sqlc.CreateStudentStatusHistoryParams{
+			StudentID: student.ID,
+			ToStatus:  student.Status,
+			ChangedOn: student.EnrolledOn,
+			Reason:    pgtype.Text{String: "일괄 등록", Valid: true},
+			ChangedBy: pgtype.Int4{Int32: userID, Valid: userID != 0},
+		})
+		if err != nil {
+			h.render(c, http.StatusInternalServerError, gin.H{
+				"error": fmt.Sprintf("%d행 등록 중 오류가 발생해 전체 등록을 취소했습니다.", row.Line),
+			})
+			return
+		}
+
+		created = append(created, student)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		h.render(c, http.StatusInternalServerError, gin.H{"error": "원생 일괄 등록에 실패했습니다."})
+		return
+	}
+
+	// 감사 로그 기록 실패가 등록을 취소하지 않도록 커밋한 뒤에 기록
+	for _, student := range created {
+		recordAudit(ctx, h.queries, userID, auditActionCreate, auditEntityStudent, student.ID, nil, student)
+	}
+
+	c.Redirect(http.StatusFound, "/students/import?imported="+strconv.Itoa(len(toInsert)))
+}
+
+// checkRows marks rows that match an existing student or an earlier row of the same file
+// by name and parent phone
+func (h *StudentImportHandler) checkRows(c *gin.Context, rows []studentImportRow) error {
+	names := make([]string, 0, len(rows))
+	for _, row := range rows {
+		if row.Name != "" {
+			names = append(names, row.Name)
+		}
+	}
+
+	existing, err := h.queries.ListStudentsByNames(c.Request.Context(), names)
+	if err != nil {
+		return err
+	}
+
+	seen := make(map[string]string)
+	for _, s := range existing {
+		key := studentDuplicateKey(s.Name, s.ParentPhone.String)
+		if _, ok := seen[key]; !ok {
+			seen[key] = fmt.Sprintf("기존 원생 #%d", s.ID)
+		}
+	}
+
+	for i := range rows {
+		rows[i].DuplicateOf = ""
+		if !rows[i].Valid() {
+			continue
+		}
+		key := studentDuplicateKey(rows[i].Name, rows[i].ParentPhone)
+		if dup, ok := seen[key]; ok {
+			rows[i].DuplicateOf = dup
+			continue
+		}
+		seen[key] = fmt.Sprintf("%d행", rows[i].Line)
+	}
+	return nil
+}
+
+func (h *StudentImportHandler) render(c *gin.Context, status int, data gin.H) {
+	data["currentPage"] = "students"
+	data["maxRows"] = studentImportMaxRows
+
+	renderPage(c, status, "student_import.html", data)
+}
+
+// parseStudentImportRows maps the header row to fields and validates every following row.
+// Blank lines are skipped; line numbers match the spreadsheet so staff can find the row.
+func parseStudentImportRows(records [][]string) ([]studentImportRow, string) {
+	if len(records) == 0 {
+		return nil, "파일에 데이터가 없습니다."
+	}
+
+	columns := make(map[string]int)
+	for i, header := range records[0] {
+		if field, ok := studentImportColumns[strings.ToLower(strings.TrimSpace(header))]; ok {
+			if _, dup := columns[field]; !dup {
+				columns[field] = i
+			}
+		}
+	}
+	if _, ok := columns["name"]; !ok {
+		return nil, "첫 행에 '이름' 열이 있어야 합니다."
+	}
+
+	var rows []studentImportRow
+	for i, record := range records[1:] {
+		for j := range record {
+			record[j] = strings.TrimSpace(record[j])
+		}
+		value := func(field string) string {
+			idx, ok := columns[field]
+			if !ok || idx >= len(record) {
+				return ""
+			}
+			return record[idx]
+		}
+
+		row := studentImportRow{
+			Line:        i + 2,
+			Name:        value("name"),
+			Gender:      value("gender"),
+			Phone:       value("phone"),
+			ParentPhone: value("parent_phone"),
+			Remarks:     value("remarks"),
+			EnrolledOn:  value("enrolled_on"),
+		}
+		if strings.Join(record, "") == "" {
+			continue
+		}
+		row.Errors = validateStudentImportRow(&row)
+		rows = append(rows, row)
+	}
+
+	if len(rows) == 0 {
+		return nil, "가져올 원생 데이터가 없습니다."
+	}
+	if len(rows) > studentImportMaxRows {
+		return nil, fmt.Sprintf("한 번에 최대 %d명까지 등록할 수 있습니다.", studentImportMaxRows)
+	}
+	return rows, ""
+}
+
+// validateStudentImportRow normalizes gender, phones and the enrollment date in place and
+// returns the problems that keep the row from being imported
+func validateStudentImportRow(row *studentImportRow) []string {
+	var errs []string
+
+	if row.Name == "" {
+		errs = append(errs, "이름 누락")
+	}
+
+	switch strings.ToUpper(row.Gender) {
+	case "M", "남", "남자":
+		row.Gender = "M"
+	case "F", "여", "여자":
+		row.Gender = "F"
+	default:
+		errs = append(errs, "성별 오류")
+	}
+
+	var ok bool
+	if row.Phone, ok = sanitizeImportPhone(row.Phone); !ok {
+		errs = append(errs, "연락처 형식 오류")
+	}
+	if row.ParentPhone, ok = sanitizeImportPhone(row.ParentPhone); !ok {
+		errs = append(errs, "학부모 연락처 형식 오류")
+	}
+
+	if row.EnrolledOn != "" {
+		enrolledOn, ok := parseImportDate(row.EnrolledOn)
+		if ok {
+			row.EnrolledOn = enrolledOn
+		} else {
+			errs = append(errs, "입학일 형식 오류")
+		}
+	}
+
+	return errs
+}
+
+// sanitizeImportPhone strips a phone number to digits; a non-empty value must leave a
+// 9 to 11 digit number starting with 0
+func sanitizeImportPhone(phone string) (string, bool) {
+	if phone == "" {
+		return "", true
+	}
+	digits := sanitizePhone(phone)
+	if len(digits) < 9 || len(digits) > 11 || digits[0] != '0' {
+		return phone, false
+	}
+	return digits, true
+}
+
+func parseImportDate(value string) (string, bool) {
+	for _, layout := range studentImportDateLayouts {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t.Format("2006-01-02"), true
+		}
+	}
+	return "", false
+}
+
+func studentDuplicateKey(name, parentPhone string) string {
+	return name + "\x00" + parentPhone
+}
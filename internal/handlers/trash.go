@@ -0,0 +1,153 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/choiexe1/hongik-academy/internal/db/sqlc"
+	"github.com/choiexe1/hongik-academy/internal/storage"
+)
+
+// TrashHandler lists soft-deleted students and evaluations so they can be restored or
+// permanently purged. Purging also removes the evaluation image files from storage.
+type TrashHandler struct {
+	queries *sqlc.Queries
+	store   storage.Storage
+}
+
+func NewTrashHandler(queries *sqlc.Queries, store storage.Storage) *TrashHandler {
+	return &TrashHandler{queries: queries, store: store}
+}
+
+func (h *TrashHandler) ShowTrash(c *gin.Context) {
+	students, err := h.queries.ListDeletedStudents(c.Request.Context())
+	if err != nil {
+		c.HTML(http.StatusInternalServerError, "error.html", gin.H{
+			"error": "삭제된 원생 목록을 불러오는데 실패했습니다.",
+		})
+		return
+	}
+
+	evaluations, err := h.queries.ListDeletedEvaluations(c.Request.Context())
+	if err != nil {
+		c.HTML(http.StatusInternalServerError, "error.html", gin.H{
+			"error": "삭제된 평가표 목록을 불러오는데 실패했습니다.",
+		})
+		return
+	}
+
+	renderPage(c, http.StatusOK, "trash.html", gin.H{
+		"students":    students,
+		"evaluations": evaluations,
+		"currentPage": "users",
+	})
+}
+
+func (h *TrashHandler) RestoreStudent(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 32)
+	if err != nil {
+		c.Redirect(http.StatusFound, "/admin/trash")
+		return
+	}
+
+	before, err := h.queries.GetDeletedStudentByID(c.Request.Context(), int32(id))
+	if err != nil {
+		c.Redirect(http.StatusFound, "/admin/trash")
+		return
+	}
+
+	student, err := h.queries.RestoreStudent(c.Request.Context(), before.ID)
+	if err == nil {
+		recordAudit(c.Request.Context(), h.queries, sessionUserID(c), auditActionRestore, auditEntityStudent, student.ID, before, student)
+	}
+
+	c.Redirect(http.StatusFound, "/admin/trash")
+}
+
+// PurgeStudent permanently deletes a student; evaluations, attendance and tuition records
+// are removed with it by the foreign key cascades
+func (h *TrashHandler) PurgeStudent(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 32)
+	if err != nil {
+		c.Redirect(http.StatusFound, "/admin/trash")
+		return
+	}
+
+	before, err := h.queries.GetDeletedStudentByID(c.Request.Context(), int32(id))
+	if err != nil {
+		c.Redirect(http.StatusFound, "/admin/trash")
+		return
+	}
+
+	// 행이 지워지면 파일 위치를 알 수 없으므로 미리 조회
+	imageKeys, err := h.queries.ListEvaluationImageKeysByStudent(c.Request.Context(), before.ID)
+	if err != nil {
+		c.HTML(http.StatusInternalServerError, "error.html", gin.H{
+			"error": "첨부 이미지를 확인하는데 실패했습니다.",
+		})
+		return
+	}
+
+	if err := h.queries.PurgeStudent(c.Request.Context(), before.ID); err == nil {
+		recordAudit(c.Request.Context(), h.queries, sessionUserID(c), auditActionPurge, auditEntityStudent, before.ID, before, nil)
+		for _, k := range imageKeys {
+			deleteStoredFiles(c.Request.Context(), h.store, k.StorageKey, k.ThumbnailKey)
+		}
+	}
+
+	c.Redirect(http.StatusFound, "/admin/trash")
+}
+
+func (h *TrashHandler) RestoreEvaluation(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 32)
+	if err != nil {
+		c.Redirect(http.StatusFound, "/admin/trash")
+		return
+	}
+
+	before, err := h.queries.GetDeletedEvaluationByID(c.Request.Context(), int32(id))
+	if err != nil {
+		c.Redirect(http.StatusFound, "/admin/trash")
+		return
+	}
+
+	evaluation, err := h.queries.RestoreEvaluation(c.Request.Context(), before.ID)
+	if err == nil {
+		recordAudit(c.Request.Context(), h.queries, sessionUserID(c), auditActionRestore, auditEntityEvaluation, evaluation.ID, before, evaluation)
+	}
+
+	c.Redirect(http.StatusFound, "/admin/trash")
+}
+
+func (h *TrashHandler) PurgeEvaluation(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 32)
+	if err != nil {
+		c.Redirect(http.StatusFound, "/admin/trash")
+		return
+	}
+
+	before, err := h.queries.GetDeletedEvaluationByID(c.Request.Context(), int32(id))
+	if err != nil {
+		c.Redirect(http.StatusFound, "/admin/trash")
+		return
+	}
+
+	images, err := h.queries.ListEvaluationImagesByEvaluation(c.Request.Context(), before.ID)
+	if err != nil {
+		c.HTML(http.StatusInternalServerError, "error.html", gin.H{
+			"error": "첨부 이미지를 확인하는데 실패했습니다.",
+		})
+		return
+	}
+
+	if err := h.queries.PurgeEvaluation(c.Request.Context(), before.ID); err == nil {
+		recordAudit(c.Request.Context(), h.queries, sessionUserID(c), auditActionPurge, auditEntityEvaluation, before.ID, before, nil)
+		for _, img := range images {
+			deleteStoredFiles(c.Request.Context(), h.store, img.StorageKey, img.ThumbnailKey)
+		}
+	}
+
+	c.Redirect(http.StatusFound, "/admin/trash")
+}
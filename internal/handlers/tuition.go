@@ -0,0 +1,440 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-contrib/sessions"
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/choiexe1/hongik-academy/internal/db/sqlc"
+)
+
+// paymentMethods lists the valid payment methods in display order
+var paymentMethods = []string{"cash", "card", "transfer"}
+
+func isValidPaymentMethod(method string) bool {
+	for _, m := range paymentMethods {
+		if m == method {
+			return true
+		}
+	}
+	return false
+}
+
+// parseAmount parses a won amount, allowing thousands separators (e.g. "150,000")
+func parseAmount(value string) (int64, error) {
+	return strconv.ParseInt(strings.ReplaceAll(strings.TrimSpace(value), ",", ""), 10, 64)
+}
+
+// parseBillingMonth parses a YYYY-MM value into the first day of that month
+func parseBillingMonth(value string) (time.Time, error) {
+	return time.Parse("2006-01", value)
+}
+
+// tuitionLedgerErrors maps the ?error= code used by ledger redirects to a message
+var tuitionLedgerErrors = map[string]string{
+	"invoice":  "청구 월과 금액을 올바르게 입력해주세요.",
+	"payment":  "납부 청구서, 금액, 결제 수단, 납부일을 올바르게 입력해주세요.",
+	"failed":   "수강료 정보 저장에 실패했습니다.",
+	"notfound": "청구서를 찾을 수 없습니다.",
+}
+
+type TuitionHandler struct {
+	queries *sqlc.Queries
+}
+
+func NewTuitionHandler(queries *sqlc.Queries) *TuitionHandler {
+	return &TuitionHandler{queries: queries}
+}
+
+// ListUnpaid renders the academy-wide list of invoices that are not fully paid for a month
+func (h *TuitionHandler) ListUnpaid(c *gin.Context) {
+	thisMonth := time.Now().Format("2006-01")
+	monthStr := c.DefaultQuery("month", thisMonth)
+	month, err := parseBillingMonth(monthStr)
+	if err != nil {
+		month, _ = parseBillingMonth(thisMonth)
+		monthStr = thisMonth
+	}
+
+	invoices, err := h.queries.ListUnpaidInvoices(c.Request.Context(), pgtype.Date{Time: month, Valid: true})
+	if err != nil {
+		c.HTML(http.StatusInternalServerError, "error.html", gin.H{
+			"error": "미납 목록을 불러오는데 실패했습니다.",
+		})
+		return
+	}
+
+	var totalOutstanding int64
+	for _, invoice := range invoices {
+		totalOutstanding += invoice.OutstandingAmount
+	}
+
+	renderPage(c, http.StatusOK, "tuition_unpaid.html", gin.H{
+		"invoices":         invoices,
+		"totalOutstanding": totalOutstanding,
+		"month":            monthStr,
+		"prevMonth":        month.AddDate(0, -1, 0).Format("2006-01"),
+		"nextMonth":        month.AddDate(0, 1, 0).Format("2006-01"),
+		"currentPage":      "tuition",
+	})
+}
+
+func (h *TuitionHandler) ListPlans(c *gin.Context) {
+	h.renderPlans(c, http.StatusOK, "")
+}
+
+func (h *TuitionHandler) CreatePlan(c *gin.Context) {
+	params, errMsg := parsePlanForm(c)
+	if errMsg != "" {
+		h.renderPlans(c, http.StatusBadRequest, errMsg)
+		return
+	}
+
+	_, err := h.queries.CreateTuitionPlan(c.Request.Context(), params)
+	if err != nil {
+		h.renderPlans(c, http.StatusInternalServerError, "수강료 플랜 등록에 실패했습니다.")
+		return
+	}
+
+	c.Redirect(http.StatusFound, "/tuition/plans")
+}
+
+func (h *TuitionHandler) ShowEditPlanForm(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 32)
+	if err != nil {
+		c.Redirect(http.StatusFound, "/tuition/plans")
+		return
+	}
+
+	plan, err := h.queries.GetTuitionPlanByID(c.Request.Context(), int32(id))
+	if err != nil {
+		c.Redirect(http.StatusFound, "/tuition/plans")
+		return
+	}
+
+	renderPage(c, http.StatusOK, "tuition_plan_form.html", gin.H{
+		"plan":        plan,
+		"action":      "/tuition/plans/" + c.Param("id"),
+		"currentPage": "tuition",
+	})
+}
+
+func (h *TuitionHandler) UpdatePlan(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 32)
+	if err != nil {
+		c.Redirect(http.StatusFound, "/tuition/plans")
+		return
+	}
+
+	plan, err := h.queries.GetTuitionPlanByID(c.Request.Context(), int32(id))
+	if err != nil {
+		c.Redirect(http.StatusFound, "/tuition/plans")
+		return
+	}
+
+	params, errMsg := parsePlanForm(c)
+	if errMsg == "" {
+		_, err = h.queries.UpdateTuitionPlan(c.Request.Context(), sqlc.UpdateTuitionPlanParams{
+			ID:          int32(id),
+			Name:        params.Name,
+			MonthlyFee:  params.MonthlyFee,
+			Description: params.Description,
+		})
+		if err != nil {
+			errMsg = "수강료 플랜 수정에 실패했습니다."
+		}
+	}
+
+	if errMsg != "" {
+		renderPage(c, http.StatusBadRequest, "tuition_plan_form.html", gin.H{
+			"plan":        plan,
+			"action":      "/tuition/plans/" + c.Param("id"),
+			"error":       errMsg,
+			"currentPage": "tuition",
+		})
+		return
+	}
+
+	c.Redirect(http.StatusFound, "/tuition/plans")
+}
+
+// DeletePlan removes a plan; invoices issued from it keep their amount and lose the plan link
+func (h *TuitionHandler) DeletePlan(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 32)
+	if err != nil {
+		c.Redirect(http.StatusFound, "/tuition/plans")
+		return
+	}
+
+	h.queries.DeleteTuitionPlan(c.Request.Context(), int32(id))
+
+	c.Redirect(http.StatusFound, "/tuition/plans")
+}
+
+// ShowStudentLedger renders a student's invoices, payments and outstanding balance
+func (h *TuitionHandler) ShowStudentLedger(c *gin.Context) {
+	studentID, err := strconv.ParseInt(c.Param("id"), 10, 32)
+	if err != nil {
+		c.Redirect(http.StatusFound, "/students")
+		return
+	}
+
+	student, err := h.queries.GetStudentByID(c.Request.Context(), int32(studentID))
+	if err != nil {
+		c.HTML(http.StatusNotFound, "error.html", gin.H{
+			"error": "원생을 찾을 수 없습니다.",
+		})
+		return
+	}
+
+	balance, err := h.queries.GetStudentTuitionBalance(c.Request.Context(), student.ID)
+	if err != nil {
+		c.HTML(http.StatusInternalServerError, "error.html", gin.H{
+			"error": "수강료 잔액을 불러오는데 실패했습니다.",
+		})
+		return
+	}
+
+	invoices, err := h.queries.ListTuitionInvoicesByStudent(c.Request.Context(), student.ID)
+	if err != nil {
+		c.HTML(http.StatusInternalServerError, "error.html", gin.H{
+			"error": "청구 내역을 불러오는데 실패했습니다.",
+		})
+		return
+	}
+
+	payments, err := h.queries.ListTuitionPaymentsByStudent(c.Request.Context(), student.ID)
+	if err != nil {
+		c.HTML(http.StatusInternalServerError, "error.html", gin.H{
+			"error": "납부 내역을 불러오는데 실패했습니다.",
+		})
+		return
+	}
+
+	plans, err := h.queries.ListTuitionPlans(c.Request.Context())
+	if err != nil {
+		c.HTML(http.StatusInternalServerError, "error.html", gin.H{
+			"error": "수강료 플랜을 불러오는데 실패했습니다.",
+		})
+		return
+	}
+
+	renderPage(c, http.StatusOK, "student_tuition.html", gin.H{
+		"student":     student,
+		"balance":     balance,
+		"outstanding": balance.TotalBilled - balance.TotalPaid,
+		"invoices":    invoices,
+		"payments":    payments,
+		"plans":       plans,
+		"thisMonth":   time.Now().Format("2006-01"),
+		"today":       time.Now().Format("2006-01-02"),
+		"error":       tuitionLedgerErrors[c.Query("error")],
+		"currentPage": "students",
+	})
+}
+
+// CreateInvoice issues a monthly invoice. When no amount is given the plan's monthly fee is used.
+func (h *TuitionHandler) CreateInvoice(c *gin.Context) {
+	studentID, err := strconv.ParseInt(c.Param("id"), 10, 32)
+	if err != nil {
+		c.Redirect(http.StatusFound, "/students")
+		return
+	}
+	ledgerURL := "/students/" + c.Param("id") + "/tuition"
+
+	month, err := parseBillingMonth(c.PostForm("billing_month"))
+	if err != nil {
+		c.Redirect(http.StatusFound, ledgerURL+"?error=invoice")
+		return
+	}
+
+	planParam := pgtype.Int4{Valid: false}
+	var planFee int64 = -1
+	if planID, err := strconv.ParseInt(c.PostForm("plan_id"), 10, 32); err == nil {
+		plan, err := h.queries.GetTuitionPlanByID(c.Request.Context(), int32(planID))
+		if err != nil {
+			c.Redirect(http.StatusFound, ledgerURL+"?error=invoice")
+			return
+		}
+		planParam = pgtype.Int4{Int32: plan.ID, Valid: true}
+		planFee = plan.MonthlyFee
+	}
+
+	amount := planFee
+	if amountStr := c.PostForm("amount"); amountStr != "" {
+		amount, err = parseAmount(amountStr)
+		if err != nil {
+			amount = -1
+		}
+	}
+	if amount < 0 {
+		c.Redirect(http.StatusFound, ledgerURL+"?error=invoice")
+		return
+	}
+
+	memo := c.PostForm("memo")
+
+	_, err = h.queries.CreateTuitionInvoice(c.Request.Context(), sqlc.CreateTuitionInvoiceParams{
+		StudentID:    int32(studentID),
+		PlanID:       planParam,
+		BillingMonth: pgtype.Date{Time: month, Valid: true},
+		Amount:       amount,
+		Memo:         pgtype.Text{String: memo, Valid: memo != ""},
+	})
+	if err != nil {
+		c.Redirect(http.StatusFound, ledgerURL+"?error=failed")
+		return
+	}
+
+	c.Redirect(http.StatusFound, ledgerURL)
+}
+
+// DeleteInvoice removes an invoice together with the payments recorded against it
+func (h *TuitionHandler) DeleteInvoice(c *gin.Context) {
+	studentID, err := strconv.ParseInt(c.Param("id"), 10, 32)
+	if err != nil {
+		c.Redirect(http.StatusFound, "/students")
+		return
+	}
+
+	invoiceID, err := strconv.ParseInt(c.Param("invoice_id"), 10, 32)
+	if err != nil {
+		c.Redirect(http.StatusFound, "/students/"+c.Param("id")+"/tuition")
+		return
+	}
+
+	h.queries.DeleteTuitionInvoice(c.Request.Context(), sqlc.DeleteTuitionInvoiceParams{
+		ID:        int32(invoiceID),
+		StudentID: int32(studentID),
+	})
+
+	c.Redirect(http.StatusFound, "/students/"+c.Param("id")+"/tuition")
+}
+
+// RecordPayment records a payment against one of the student's invoices
+func (h *TuitionHandler) RecordPayment(c *gin.Context) {
+	session := sessions.Default(c)
+	userID := session.Get("user_id").(int32)
+
+	studentID, err := strconv.ParseInt(c.Param("id"), 10, 32)
+	if err != nil {
+		c.Redirect(http.StatusFound, "/students")
+		return
+	}
+	ledgerURL := "/students/" + c.Param("id") + "/tuition"
+
+	invoiceID, err := strconv.ParseInt(c.PostForm("invoice_id"), 10, 32)
+	if err != nil {
+		c.Redirect(http.StatusFound, ledgerURL+"?error=payment")
+		return
+	}
+
+	amount, err := parseAmount(c.PostForm("amount"))
+	if err != nil || amount <= 0 {
+		c.Redirect(http.StatusFound, ledgerURL+"?error=payment")
+		return
+	}
+
+	method := c.PostForm("method")
+	if !isValidPaymentMethod(method) {
+		c.Redirect(http.StatusFound, ledgerURL+"?error=payment")
+		return
+	}
+
+	paidOn, err := time.Parse("2006-01-02", c.PostForm("paid_on"))
+	if err != nil {
+		c.Redirect(http.StatusFound, ledgerURL+"?error=payment")
+		return
+	}
+
+	// 다른 원생의 청구서에 납부가 기록되지 않도록 소유 여부 확인
+	invoice, err := h.queries.GetTuitionInvoice(c.Request.Context(), sqlc.GetTuitionInvoiceParams{
+		ID:        int32(invoiceID),
+		StudentID: int32(studentID),
+	})
+	if err != nil {
+		c.Redirect(http.StatusFound, ledgerURL+"?error=notfound")
+		return
+	}
+
+	memo := c.PostForm("memo")
+
+	_, err = h.queries.CreateTuitionPayment(c.Request.Context(), sqlc.CreateTuitionPaymentParams{
+		InvoiceID:  invoice.ID,
+		Amount:     amount,
+		Method:     method,
+		PaidOn:     pgtype.Date{Time: paidOn, Valid: true},
+		Memo:       pgtype.Text{String: memo, Valid: memo != ""},
+		RecordedBy: pgtype.Int4{Int32: userID, Valid: true},
+	})
+	if err != nil {
+		c.Redirect(http.StatusFound, ledgerURL+"?error=failed")
+		return
+	}
+
+	c.Redirect(http.StatusFound, ledgerURL)
+}
+
+func (h *TuitionHandler) DeletePayment(c *gin.Context) {
+	studentID, err := strconv.ParseInt(c.Param("id"), 10, 32)
+	if err != nil {
+		c.Redirect(http.StatusFound, "/students")
+		return
+	}
+
+	paymentID, err := strconv.ParseInt(c.Param("payment_id"), 10, 32)
+	if err != nil {
+		c.Redirect(http.StatusFound, "/students/"+c.Param("id")+"/tuition")
+		return
+	}
+
+	h.queries.DeleteTuitionPayment(c.Request.Context(), sqlc.DeleteTuitionPaymentParams{
+		ID:        int32(paymentID),
+		StudentID: int32(studentID),
+	})
+
+	c.Redirect(http.StatusFound, "/students/"+c.Param("id")+"/tuition")
+}
+
+func (h *TuitionHandler) renderPlans(c *gin.Context, status int, errMsg string) {
+	plans, err := h.queries.ListTuitionPlans(c.Request.Context())
+	if err != nil {
+		c.HTML(http.StatusInternalServerError, "error.html", gin.H{
+			"error": "수강료 플랜을 불러오는데 실패했습니다.",
+		})
+		return
+	}
+
+	renderPage(c, status, "tuition_plans.html", gin.H{
+		"plans":       plans,
+		"error":       errMsg,
+		"currentPage": "tuition",
+	})
+}
+
+func parsePlanForm(c *gin.Context) (sqlc.CreateTuitionPlanParams, string) {
+	name := c.PostForm("name")
+	description := c.PostForm("description")
+
+	params := sqlc.CreateTuitionPlanParams{
+		Name:        name,
+		Description: pgtype.Text{String: description, Valid: description != ""},
+	}
+
+	if name == "" {
+		return params, "플랜 이름을 입력해주세요."
+	}
+
+	fee, err := parseAmount(c.PostForm("monthly_fee"))
+	if err != nil || fee < 0 {
+		return params, "월 수강료를 올바르게 입력해주세요."
+	}
+	params.MonthlyFee = fee
+
+	return params, ""
+}
@@ -0,0 +1,333 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"html/template"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/choiexe1/hongik-academy/internal/db/sqlc"
+	"github.com/choiexe1/hongik-academy/internal/middleware"
+	"github.com/choiexe1/hongik-academy/internal/twofactor"
+)
+
+// ShowTwoFactor shows the 2FA status, or the QR code to enroll when it is off
+func (h *AccountHandler) ShowTwoFactor(c *gin.Context) {
+	h.renderTwoFactor(c, http.StatusOK, gin.H{})
+}
+
+// EnableTwoFactor turns on 2FA once the user proves the app was set up by entering a code,
+// and shows the first set of recovery codes
+func (h *AccountHandler) EnableTwoFactor(c *gin.Context) {
+	ctx := c.Request.Context()
+	userID := sessionUserID(c)
+
+	tf, err := h.queries.GetUserTwoFactor(ctx, userID)
+	if err != nil || tf.TotpEnabledAt.Valid || !tf.TotpSecret.Valid {
+		c.Redirect(http.StatusFound, middleware.TwoFactorSetupPath)
+		return
+	}
+
+	step, ok := twofactor.Verify(tf.TotpSecret.String, c.PostForm("code"), 0)
+	if !ok {
+		h.renderTwoFactor(c, http.StatusBadRequest, gin.H{
+			"error": "인증 코드가 올바르지 않습니다. 앱에 표시된 6자리 코드를 입력해주세요.",
+		})
+		return
+	}
+
+	codes, err := func() ([]string, error) {
+		tx, err := h.pool.Begin(ctx)
+		if err != nil {
+			return nil, err
+		}
+		defer tx.Rollback(ctx)
+		qtx := h.queries.WithTx(tx)
+
+		n, err := qtx.EnableUserTOTP(ctx, sqlc.EnableUserTOTPParams{ID: userID, TotpLastStep: step})
+		if err != nil {
+			return nil, err
+		}
+		if n == 0 {
+			return nil, errTwoFactorChanged
+		}
+		codes, err := replaceRecoveryCodes(ctx, qtx, userID)
+		if err != nil {
+			return nil, err
+		}
+		return codes, tx.Commit(ctx)
+	}()
+	if err != nil {
+		h.renderTwoFactor(c, http.StatusInternalServerError, gin.H{
+			"error": "2단계 인증 설정에 실패했습니다. 다시 시도해주세요.",
+		})
+		return
+	}
+
+	h.renderTwoFactor(c, http.StatusOK, gin.H{
+		"success":       "2단계 인증이 설정되었습니다. 아래 복구 코드를 안전한 곳에 보관해주세요.",
+		"recoveryCodes": codes,
+	})
+}
+
+// RegenerateRecoveryCodes replaces every recovery code, used or not, with a new set
+func (h *AccountHandler) RegenerateRecoveryCodes(c *gin.Context) {
+	ctx := c.Request.Context()
+	userID := sessionUserID(c)
+
+	if !h.checkCurrentCode(c) {
+		return
+	}
+
+	codes, err := func() ([]string, error) {
+		tx, err := h.pool.Begin(ctx)
+		if err != nil {
+			return nil, err
+		}
+		defer tx.Rollback(ctx)
+
+		codes, err := replaceRecoveryCodes(ctx, h.queries.WithTx(tx), userID)
+		if err != nil {
+			return nil, err
+		}
+		return codes, tx.Commit(ctx)
+	}()
+	if err != nil {
+		h.renderTwoFactor(c, http.StatusInternalServerError, gin.H{
+			"error": "복구 코드 발급에 실패했습니다.",
+		})
+		return
+	}
+
+	h.renderTwoFactor(c, http.StatusOK, gin.H{
+		"success":       "복구 코드를 새로 발급했습니다. 이전 복구 코드는 더 이상 사용할 수 없습니다.",
+		"recoveryCodes": codes,
+	})
+}
+
+// DisableTwoFactor turns 2FA off, unless the user's role requires it
+func (h *AccountHandler) DisableTwoFactor(c *gin.Context) {
+	ctx := c.Request.Context()
+	userID := sessionUserID(c)
+
+	if c.GetBool(middleware.RequireTwoFactorKey) {
+		h.renderTwoFactor(c, http.StatusForbidden, gin.H{
+			"error": "현재 역할은 2단계 인증이 필수라서 끌 수 없습니다.",
+		})
+		return
+	}
+	if !h.checkCurrentCode(c) {
+		return
+	}
+
+	err := func() error {
+		tx, err := h.pool.Begin(ctx)
+		if err != nil {
+			return err
+		}
+		defer tx.Rollback(ctx)
+		qtx := h.queries.WithTx(tx)
+
+		if err := qtx.DeleteRecoveryCodes(ctx, userID); err != nil {
+			return err
+		}
+		if err := qtx.DisableUserTOTP(ctx, userID); err != nil {
+			return err
+		}
+		return tx.Commit(ctx)
+	}()
+	if err != nil {
+		h.renderTwoFactor(c, http.StatusInternalServerError, gin.H{
+			"error": "2단계 인증 해제에 실패했습니다.",
+		})
+		return
+	}
+
+	c.Redirect(http.StatusFound, middleware.TwoFactorSetupPath)
+}
+
+// errTwoFactorChanged means 2FA was enabled or reset by another request in the meantime
+var errTwoFactorChanged = errors.New("two-factor state changed")
+
+// checkCurrentCode confirms a settings change on an enabled 2FA with an authenticator or
+// recovery code, rendering the page with an error when it does not match. Wrong codes count
+// toward the login lock like wrong codes at login.
+func (h *AccountHandler) checkCurrentCode(c *gin.Context) bool {
+	ctx := c.Request.Context()
+	userID := sessionUserID(c)
+
+	tf, err := h.queries.GetUserTwoFactor(ctx, userID)
+	if err != nil || !tf.TotpEnabledAt.Valid {
+		c.Redirect(http.StatusFound, middleware.TwoFactorSetupPath)
+		return false
+	}
+	user, err := h.queries.GetUserByID(ctx, userID)
+	if err != nil {
+		h.renderTwoFactor(c, http.StatusInternalServerError, gin.H{
+			"error": "인증 코드를 확인하는데 실패했습니다.",
+		})
+		return false
+	}
+
+	ok, lockedMsg, err := h.limitedCheck(ctx, user, func() (bool, error) {
+		ok, _, err := verifyTwoFactorCode(ctx, h.queries, userID, tf, strings.TrimSpace(c.PostForm("code")))
+		return ok, err
+	})
+	if err != nil {
+		h.renderTwoFactor(c, http.StatusInternalServerError, gin.H{
+			"error": "인증 코드를 확인하는데 실패했습니다.",
+		})
+		return false
+	}
+	if lockedMsg != "" {
+		h.renderTwoFactor(c, http.StatusForbidden, gin.H{
+			"error": lockedMsg,
+		})
+		return false
+	}
+	if !ok {
+		h.renderTwoFactor(c, http.StatusBadRequest, gin.H{
+			"error": "인증 코드가 올바르지 않습니다.",
+		})
+		return false
+	}
+	return true
+}
+
+// renderTwoFactor renders account_2fa.html. While 2FA is off it keeps one pending secret
+// per user, so reloading the page does not invalidate a QR code that was already scanned.
+func (h *AccountHandler) renderTwoFactor(c *gin.Context, status int, data gin.H) {
+	ctx := c.Request.Context()
+	userID := sessionUserID(c)
+
+	user, err := h.queries.GetUserByID(ctx, userID)
+	if err != nil {
+		c.HTML(http.StatusInternalServerError, "error.html", gin.H{
+			"error": "사용자 정보를 불러오는데 실패했습니다.",
+		})
+		return
+	}
+	tf, err := h.queries.GetUserTwoFactor(ctx, userID)
+	if err != nil {
+		c.HTML(http.StatusInternalServerError, "error.html", gin.H{
+			"error": "2단계 인증 정보를 불러오는데 실패했습니다.",
+		})
+		return
+	}
+
+	required := c.GetBool(middleware.RequireTwoFactorKey)
+	data["enabled"] = tf.TotpEnabledAt.Valid
+	data["enabledAt"] = tf.TotpEnabledAt
+	data["required"] = required
+	data["recoveryUsed"] = c.Query("recovery") == "used"
+	data["currentPage"] = "account"
+
+	if tf.TotpEnabledAt.Valid {
+		remaining, err := h.queries.CountUnusedRecoveryCodes(ctx, userID)
+		if err != nil {
+			c.HTML(http.StatusInternalServerError, "error.html", gin.H{
+				"error": "2단계 인증 정보를 불러오는데 실패했습니다.",
+			})
+			return
+		}
+		data["remainingCodes"] = remaining
+		renderPage(c, status, "account_2fa.html", data)
+		return
+	}
+
+	// 등록 진행 중인 비밀키가 없으면 새로 발급
+	var secret, url string
+	if tf.TotpSecret.Valid {
+		secret = tf.TotpSecret.String
+		url, err = twofactor.KeyURL(user.Username, secret)
+	} else {
+		var key *twofactor.Key
+		key, err = twofactor.NewKey(user.Username)
+		if err == nil {
+			secret, url = key.Secret, key.URL
+			err = h.queries.SetUserTOTPSecret(ctx, sqlc.SetUserTOTPSecretParams{
+				ID:         userID,
+				TotpSecret: pgtype.Text{String: secret, Valid: true},
+			})
+		}
+	}
+	var qrCode string
+	if err == nil {
+		qrCode, err = twofactor.QRCode(url)
+	}
+	if err != nil {
+		c.HTML(http.StatusInternalServerError, "error.html", gin.H{
+			"error": "2단계 인증 키를 만드는데 실패했습니다.",
+		})
+		return
+	}
+
+	data["qrCode"] = template.URL(qrCode)
+	data["secret"] = groupSecret(secret)
+	renderPage(c, status, "account_2fa.html", data)
+}
+
+// groupSecret splits the manual entry key into blocks of four characters
+func groupSecret(secret string) string {
+	var b strings.Builder
+	for i, r := range secret {
+		if i > 0 && i%4 == 0 {
+			b.WriteByte(' ')
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// verifyTwoFactorCode checks a code from the login step or the 2FA settings page. Six digits
+// are checked as an authenticator code and anything else as a recovery code, which is used
+// up when it matches. recovery reports which kind of code was accepted.
+func verifyTwoFactorCode(ctx context.Context, queries *sqlc.Queries, userID int32, tf sqlc.GetUserTwoFactorRow, code string) (ok, recovery bool, err error) {
+	if !tf.TotpSecret.Valid {
+		return false, false, nil
+	}
+
+	if twofactor.IsTOTPCode(code) {
+		step, matched := twofactor.Verify(tf.TotpSecret.String, code, tf.TotpLastStep)
+		if !matched {
+			return false, false, nil
+		}
+		// 같은 코드가 두 번 쓰이지 않도록 사용한 시간 단계를 기록
+		n, err := queries.UpdateUserTOTPStep(ctx, sqlc.UpdateUserTOTPStepParams{
+			ID:           userID,
+			TotpLastStep: step,
+		})
+		return n == 1, false, err
+	}
+
+	n, err := queries.UseRecoveryCode(ctx, sqlc.UseRecoveryCodeParams{
+		UserID:   userID,
+		CodeHash: twofactor.HashRecoveryCode(code),
+	})
+	return n == 1, true, err
+}
+
+// replaceRecoveryCodes discards the user's recovery codes and stores a new set, returning
+// the plain codes to show once. Call it inside a transaction.
+func replaceRecoveryCodes(ctx context.Context, queries *sqlc.Queries, userID int32) ([]string, error) {
+	codes, err := twofactor.GenerateRecoveryCodes()
+	if err != nil {
+		return nil, err
+	}
+	if err := queries.DeleteRecoveryCodes(ctx, userID); err != nil {
+		return nil, err
+	}
+	for _, code := range codes {
+		if err := queries.CreateRecoveryCode(ctx, sqlc.CreateRecoveryCodeParams{
+			UserID:   userID,
+			CodeHash: twofactor.HashRecoveryCode(code),
+		}); err != nil {
+			return nil, err
+		}
+	}
+	return codes, nil
+}
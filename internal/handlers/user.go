@@ -1,28 +1,83 @@
 package handlers
 
 import (
+	"context"
 	"net/http"
 	"strconv"
+	"time"
 
-	"github.com/gin-contrib/sessions"
 	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
 	"golang.org/x/crypto/bcrypt"
 
 	"github.com/choiexe1/hongik-academy/internal/db/sqlc"
+	"github.com/choiexe1/hongik-academy/internal/passwordpolicy"
+	"github.com/choiexe1/hongik-academy/internal/permission"
 )
 
+// canManageUser reports whether the current user may edit the target account and its API
+// tokens. Everyone may manage their own account; others need users.manage, and super admin
+// accounts are managed by super admins only.
+func canManageUser(currentUserID int32, currentRole string, perms permission.Set, targetID int32, targetRole string) bool {
+	if currentUserID == targetID {
+		return true
+	}
+	if !perms.Has(permission.UsersManage) {
+		return false
+	}
+	return targetRole != permission.SuperAdminRole || currentRole == permission.SuperAdminRole
+}
+
+// canDeleteUser is canManageUser for deletion, which is never allowed on oneself
+func canDeleteUser(currentUserID int32, currentRole string, perms permission.Set, targetID int32, targetRole string) bool {
+	return currentUserID != targetID && canManageUser(currentUserID, currentRole, perms, targetID, targetRole)
+}
+
+// recentLoginAttempts is how many login attempts the user edit page shows
+const recentLoginAttempts = 10
+
 type UserHandler struct {
+	pool    *pgxpool.Pool
 	queries *sqlc.Queries
 }
 
-func NewUserHandler(queries *sqlc.Queries) *UserHandler {
-	return &UserHandler{queries: queries}
+func NewUserHandler(pool *pgxpool.Pool, queries *sqlc.Queries) *UserHandler {
+	return &UserHandler{pool: pool, queries: queries}
+}
+
+// setUserPassword stores a password an administrator chose for another user. The user must
+// change it at the next login, and every device they are logged in on is logged out.
+func setUserPassword(ctx context.Context, pool *pgxpool.Pool, queries *sqlc.Queries, userID int32, password string) error {
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return err
+	}
+
+	tx, err := pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+	qtx := queries.WithTx(tx)
+
+	if err := qtx.UpdateUserPassword(ctx, sqlc.UpdateUserPasswordParams{
+		ID:                 userID,
+		PasswordHash:       string(hashedPassword),
+		MustChangePassword: true,
+	}); err != nil {
+		return err
+	}
+	if err := qtx.DeleteAllUserSessions(ctx, userID); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
 }
 
 func (h *UserHandler) ListUsers(c *gin.Context) {
-	session := sessions.Default(c)
-	currentUserID := session.Get("user_id").(int32)
-	currentRole := session.Get("role").(string)
+	currentUserID := sessionUserID(c)
+	currentRole := c.GetString("role")
+	perms := permission.FromContext(c)
 
 	users, err := h.queries.ListUsers(c.Request.Context())
 	if err != nil {
@@ -31,82 +86,89 @@ func (h *UserHandler) ListUsers(c *gin.Context) {
 		})
 		return
 	}
+	roleNames, err := roleDisplayNames(c.Request.Context(), h.queries)
+	if err != nil {
+		c.HTML(http.StatusInternalServerError, "error.html", gin.H{
+			"error": "역할 목록을 불러오는데 실패했습니다.",
+		})
+		return
+	}
 
-	username := session.Get("username")
-
-	c.HTML(http.StatusOK, "users.html", gin.H{
-		"users":         users,
-		"currentUserID": currentUserID,
-		"currentRole":   currentRole,
-		"username":      username,
-		"role":          currentRole,
-		"currentPage":   "users",
-	})
-}
-
-func (h *UserHandler) ShowCreateForm(c *gin.Context) {
-	session := sessions.Default(c)
-	currentRole := session.Get("role").(string)
-	username := session.Get("username")
-
-	// 최고관리자만 관리자 등록 가능
-	if currentRole != "super_admin" {
-		c.HTML(http.StatusForbidden, "error.html", gin.H{
-			"error": "관리자를 등록할 권한이 없습니다.",
+	lockedIDs, err := h.queries.ListLockedUserIDs(c.Request.Context())
+	if err != nil {
+		c.HTML(http.StatusInternalServerError, "error.html", gin.H{
+			"error": "사용자 목록을 불러오는데 실패했습니다.",
 		})
 		return
 	}
+	lockedUsers := make(map[int32]bool, len(lockedIDs))
+	for _, id := range lockedIDs {
+		lockedUsers[id] = true
+	}
+
+	// 수정/삭제 버튼은 권한이 있는 계정에만 표시
+	manageable := make(map[int32]bool, len(users))
+	deletable := make(map[int32]bool, len(users))
+	for _, u := range users {
+		manageable[u.ID] = canManageUser(currentUserID, currentRole, perms, u.ID, u.Role)
+		deletable[u.ID] = canDeleteUser(currentUserID, currentRole, perms, u.ID, u.Role)
+	}
 
-	c.HTML(http.StatusOK, "user_form.html", gin.H{
-		"title":       "관리자 등록",
-		"action":      "/admin/users",
-		"user":        nil,
-		"currentRole": currentRole,
-		"username":    username,
-		"role":        currentRole,
+	renderPage(c, http.StatusOK, "users.html", gin.H{
+		"users":       users,
+		"roleNames":   roleNames,
+		"manageable":  manageable,
+		"deletable":   deletable,
+		"lockedUsers": lockedUsers,
 		"currentPage": "users",
 	})
 }
 
-func (h *UserHandler) CreateUser(c *gin.Context) {
-	session := sessions.Default(c)
-	currentRole := session.Get("role").(string)
-
-	// 최고관리자만 관리자 등록 가능
-	if currentRole != "super_admin" {
-		c.HTML(http.StatusForbidden, "error.html", gin.H{
-			"error": "관리자를 등록할 권한이 없습니다.",
-		})
-		return
-	}
+func (h *UserHandler) ShowCreateForm(c *gin.Context) {
+	h.renderForm(c, http.StatusOK, gin.H{
+		"title":  "관리자 등록",
+		"action": "/admin/users",
+		"user":   nil,
+	})
+}
 
+func (h *UserHandler) CreateUser(c *gin.Context) {
 	username := c.PostForm("username")
 	name := c.PostForm("name")
 	password := c.PostForm("password")
 	role := c.PostForm("role")
 
-	if username == "" || name == "" || password == "" {
-		c.HTML(http.StatusBadRequest, "user_form.html", gin.H{
-			"title":       "관리자 등록",
-			"action":      "/admin/users",
-			"error":       "아이디, 이름, 비밀번호는 필수입니다.",
-			"currentRole": currentRole,
+	if username == "" || name == "" || password == "" || role == "" {
+		h.renderForm(c, http.StatusBadRequest, gin.H{
+			"title":  "관리자 등록",
+			"action": "/admin/users",
+			"error":  "아이디, 이름, 비밀번호, 역할은 필수입니다.",
 		})
 		return
 	}
 
-	// 최고관리자만 super_admin 역할 부여 가능
-	if role == "super_admin" && currentRole != "super_admin" {
-		role = "admin"
+	if errMsg := passwordpolicy.Check(password, username); errMsg != "" {
+		h.renderForm(c, http.StatusBadRequest, gin.H{
+			"title":  "관리자 등록",
+			"action": "/admin/users",
+			"error":  errMsg,
+		})
+		return
 	}
-	if role != "super_admin" && role != "admin" {
-		role = "admin"
+
+	if errMsg := checkAssignableRole(c.Request.Context(), h.queries, role, c.GetString("role"), permission.FromContext(c)); errMsg != "" {
+		h.renderForm(c, http.StatusForbidden, gin.H{
+			"title":  "관리자 등록",
+			"action": "/admin/users",
+			"error":  errMsg,
+		})
+		return
 	}
 
 	// 비밀번호 해시
 	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
 	if err != nil {
-		c.HTML(http.StatusInternalServerError, "user_form.html", gin.H{
+		h.renderForm(c, http.StatusInternalServerError, gin.H{
 			"title":  "관리자 등록",
 			"action": "/admin/users",
 			"error":  "비밀번호 처리 중 오류가 발생했습니다.",
@@ -114,7 +176,7 @@ func (h *UserHandler) CreateUser(c *gin.Context) {
 		return
 	}
 
-	_, err = h.queries.CreateUser(c.Request.Context(), sqlc.CreateUserParams{
+	user, err := h.queries.CreateUser(c.Request.Context(), sqlc.CreateUserParams{
 		Username:     username,
 		Name:         name,
 		PasswordHash: string(hashedPassword),
@@ -122,7 +184,7 @@ func (h *UserHandler) CreateUser(c *gin.Context) {
 	})
 
 	if err != nil {
-		c.HTML(http.StatusInternalServerError, "user_form.html", gin.H{
+		h.renderForm(c, http.StatusInternalServerError, gin.H{
 			"title":  "관리자 등록",
 			"action": "/admin/users",
 			"error":  "사용자 등록에 실패했습니다. 아이디가 중복되었을 수 있습니다.",
@@ -130,55 +192,123 @@ func (h *UserHandler) CreateUser(c *gin.Context) {
 		return
 	}
 
+	recordAudit(c.Request.Context(), h.queries, sessionUserID(c), auditActionCreate, auditEntityUser, user.ID, nil, auditUser{
+		ListUsersRow: sqlc.ListUsersRow{ID: user.ID, Username: user.Username, Name: user.Name, Role: user.Role, CreatedAt: user.CreatedAt},
+	})
+
 	c.Redirect(http.StatusFound, "/admin/users")
 }
 
 func (h *UserHandler) ShowEditForm(c *gin.Context) {
-	session := sessions.Default(c)
-	currentUserID := session.Get("user_id").(int32)
-	currentRole := session.Get("role").(string)
+	user, ok := h.loadManagedUser(c)
+	if !ok {
+		return
+	}
 
-	id, err := strconv.ParseInt(c.Param("id"), 10, 32)
-	if err != nil {
-		c.Redirect(http.StatusFound, "/admin/users")
+	h.renderForm(c, http.StatusOK, gin.H{
+		"title":  "관리자 수정",
+		"action": "/admin/users/" + c.Param("id"),
+		"user":   user,
+	})
+}
+
+func (h *UserHandler) UpdateUser(c *gin.Context) {
+	currentUserID := sessionUserID(c)
+
+	// 대상 사용자 조회
+	targetUser, ok := h.loadManagedUser(c)
+	if !ok {
 		return
 	}
 
-	// 일반 관리자는 자기 자신만 수정 가능
-	if currentRole != "super_admin" && currentUserID != int32(id) {
-		c.HTML(http.StatusForbidden, "error.html", gin.H{
-			"error": "다른 관리자를 수정할 권한이 없습니다.",
+	name := c.PostForm("name")
+	password := c.PostForm("password")
+	role := c.PostForm("role")
+
+	if name == "" {
+		h.renderForm(c, http.StatusBadRequest, gin.H{
+			"title":  "관리자 수정",
+			"action": "/admin/users/" + c.Param("id"),
+			"error":  "이름은 필수입니다.",
+			"user":   targetUser,
 		})
 		return
 	}
 
-	user, err := h.queries.GetUserByID(c.Request.Context(), int32(id))
-	if err != nil {
-		c.Redirect(http.StatusFound, "/admin/users")
+	// 본인 비밀번호는 현재 비밀번호를 확인하고 다른 세션을 끊는 /account/password에서만 변경
+	if password != "" && targetUser.ID == currentUserID {
+		h.renderForm(c, http.StatusBadRequest, gin.H{
+			"title":  "관리자 수정",
+			"action": "/admin/users/" + c.Param("id"),
+			"error":  "본인 비밀번호는 내 계정의 비밀번호 변경에서 바꿔주세요.",
+			"user":   targetUser,
+		})
 		return
 	}
 
-	// 자기 자신인지 확인 (역할 수정 방지용)
-	isSelf := currentUserID == int32(id)
+	if password != "" {
+		if errMsg := passwordpolicy.Check(password, targetUser.Username); errMsg != "" {
+			h.renderForm(c, http.StatusBadRequest, gin.H{
+				"title":  "관리자 수정",
+				"action": "/admin/users/" + c.Param("id"),
+				"error":  errMsg,
+				"user":   targetUser,
+			})
+			return
+		}
+	}
 
-	username := session.Get("username")
+	// 최고관리자와 자기 자신의 역할은 변경 불가 - 기존 역할 유지
+	if !canChangeUserRole(currentUserID, targetUser) || role == "" {
+		role = targetUser.Role
+	}
+	if role != targetUser.Role {
+		if errMsg := checkAssignableRole(c.Request.Context(), h.queries, role, c.GetString("role"), permission.FromContext(c)); errMsg != "" {
+			h.renderForm(c, http.StatusForbidden, gin.H{
+				"title":  "관리자 수정",
+				"action": "/admin/users/" + c.Param("id"),
+				"error":  errMsg,
+				"user":   targetUser,
+			})
+			return
+		}
+	}
 
-	c.HTML(http.StatusOK, "user_form.html", gin.H{
-		"title":       "관리자 수정",
-		"action":      "/admin/users/" + c.Param("id"),
-		"user":        user,
-		"currentRole": currentRole,
-		"isSelf":      isSelf,
-		"username":    username,
-		"role":        currentRole,
-		"currentPage": "users",
+	// 사용자 정보 업데이트
+	user, err := h.queries.UpdateUser(c.Request.Context(), sqlc.UpdateUserParams{
+		ID:   targetUser.ID,
+		Name: name,
+		Role: role,
 	})
+
+	if err != nil {
+		h.renderForm(c, http.StatusInternalServerError, gin.H{
+			"title":  "관리자 수정",
+			"action": "/admin/users/" + c.Param("id"),
+			"error":  "사용자 수정에 실패했습니다.",
+			"user":   targetUser,
+		})
+		return
+	}
+
+	// 비밀번호가 입력된 경우에만 업데이트. 다른 사람이 정해준 비밀번호는 다음 로그인 때 변경 요구
+	passwordChanged := false
+	if password != "" {
+		passwordChanged = setUserPassword(c.Request.Context(), h.pool, h.queries, targetUser.ID, password) == nil
+	}
+
+	recordAudit(c.Request.Context(), h.queries, currentUserID, auditActionUpdate, auditEntityUser, user.ID, auditUser{
+		ListUsersRow: sqlc.ListUsersRow{ID: targetUser.ID, Username: targetUser.Username, Name: targetUser.Name, Role: targetUser.Role, CreatedAt: targetUser.CreatedAt},
+	}, auditUser{
+		ListUsersRow:    sqlc.ListUsersRow{ID: user.ID, Username: user.Username, Name: user.Name, Role: user.Role, CreatedAt: user.CreatedAt},
+		PasswordChanged: passwordChanged,
+	})
+
+	c.Redirect(http.StatusFound, "/admin/users")
 }
 
-func (h *UserHandler) UpdateUser(c *gin.Context) {
-	session := sessions.Default(c)
-	currentUserID := session.Get("user_id").(int32)
-	currentRole := session.Get("role").(string)
+func (h *UserHandler) DeleteUser(c *gin.Context) {
+	currentUserID := sessionUserID(c)
 
 	id, err := strconv.ParseInt(c.Param("id"), 10, 32)
 	if err != nil {
@@ -186,115 +316,272 @@ func (h *UserHandler) UpdateUser(c *gin.Context) {
 		return
 	}
 
-	// 일반 관리자는 자기 자신만 수정 가능
-	if currentRole != "super_admin" && currentUserID != int32(id) {
+	// 자기 자신은 삭제 불가
+	if currentUserID == int32(id) {
 		c.HTML(http.StatusForbidden, "error.html", gin.H{
-			"error": "다른 관리자를 수정할 권한이 없습니다.",
+			"error": "자기 자신은 삭제할 수 없습니다.",
 		})
 		return
 	}
 
-	// 대상 사용자 조회
 	targetUser, err := h.queries.GetUserByID(c.Request.Context(), int32(id))
 	if err != nil {
 		c.Redirect(http.StatusFound, "/admin/users")
 		return
 	}
 
-	name := c.PostForm("name")
-	password := c.PostForm("password")
-	role := c.PostForm("role")
-	isSelf := currentUserID == int32(id)
+	// 최고관리자 계정은 최고관리자만 삭제 가능
+	if !canDeleteUser(currentUserID, c.GetString("role"), permission.FromContext(c), targetUser.ID, targetUser.Role) {
+		c.HTML(http.StatusForbidden, "error.html", gin.H{
+			"error": "최고관리자 계정은 최고관리자만 삭제할 수 있습니다.",
+		})
+		return
+	}
 
-	if name == "" {
-		c.HTML(http.StatusBadRequest, "user_form.html", gin.H{
-			"title":       "관리자 수정",
-			"action":      "/admin/users/" + c.Param("id"),
-			"error":       "이름은 필수입니다.",
-			"user":        targetUser,
-			"currentRole": currentRole,
-			"isSelf":      isSelf,
+	if err := h.queries.DeleteUser(c.Request.Context(), targetUser.ID); err == nil {
+		recordAudit(c.Request.Context(), h.queries, currentUserID, auditActionDelete, auditEntityUser, targetUser.ID, auditUser{
+			ListUsersRow: sqlc.ListUsersRow{ID: targetUser.ID, Username: targetUser.Username, Name: targetUser.Name, Role: targetUser.Role, CreatedAt: targetUser.CreatedAt},
+		}, nil)
+	}
+
+	c.Redirect(http.StatusFound, "/admin/users")
+}
+
+// UnlockUser clears a lockout from repeated failed logins so the user can sign in right away
+func (h *UserHandler) UnlockUser(c *gin.Context) {
+	user, ok := h.loadManagedUser(c)
+	if !ok {
+		return
+	}
+
+	if err := h.queries.ResetLoginFailures(c.Request.Context(), user.ID); err != nil {
+		c.HTML(http.StatusInternalServerError, "error.html", gin.H{
+			"error": "잠금 해제에 실패했습니다.",
 		})
 		return
 	}
 
-	// 최고관리자의 역할은 변경 불가
-	if targetUser.Role == "super_admin" {
-		role = "super_admin"
-	} else if isSelf {
-		// 자기 자신의 역할은 변경 불가 - 기존 역할 유지
-		role = targetUser.Role
-	} else {
-		// 최고관리자만 super_admin 역할 부여 가능
-		if role == "super_admin" && currentRole != "super_admin" {
-			role = "admin"
-		}
-		if role != "super_admin" && role != "admin" {
-			role = "admin"
-		}
+	recordAudit(c.Request.Context(), h.queries, sessionUserID(c), auditActionUnlock, auditEntityUser, user.ID,
+		auditLoginLock{FailedLoginCount: user.FailedLoginCount, LockedUntil: user.LockedUntil}, auditLoginLock{})
+
+	c.Redirect(http.StatusFound, "/admin/users/"+c.Param("id")+"/edit")
+}
+
+// ResetTwoFactor turns off another user's 2FA and discards their recovery codes, for a user
+// who lost their phone. Only a super admin may do this, and not on their own account.
+func (h *UserHandler) ResetTwoFactor(c *gin.Context) {
+	user, ok := h.loadManagedUser(c)
+	if !ok {
+		return
+	}
+	if !canResetCredentials(c.GetString("role"), sessionUserID(c), user.ID) {
+		c.HTML(http.StatusForbidden, "error.html", gin.H{
+			"error": "2단계 인증 초기화는 최고관리자만 다른 사용자에 대해 할 수 있습니다.",
+		})
+		return
 	}
 
-	// 사용자 정보 업데이트
-	_, err = h.queries.UpdateUser(c.Request.Context(), sqlc.UpdateUserParams{
-		ID:   int32(id),
-		Name: name,
-		Role: role,
-	})
+	ctx := c.Request.Context()
+	tf, err := h.queries.GetUserTwoFactor(ctx, user.ID)
+	if err != nil {
+		c.HTML(http.StatusInternalServerError, "error.html", gin.H{
+			"error": "2단계 인증 정보를 불러오는데 실패했습니다.",
+		})
+		return
+	}
+	remaining, _ := h.queries.CountUnusedRecoveryCodes(ctx, user.ID)
+
+	// 복구 코드를 먼저 지우고 비밀키를 초기화 (중간에 실패해도 복구 코드만 남지 않음)
+	if err := h.queries.DeleteRecoveryCodes(ctx, user.ID); err != nil {
+		c.HTML(http.StatusInternalServerError, "error.html", gin.H{
+			"error": "2단계 인증 초기화에 실패했습니다.",
+		})
+		return
+	}
+	if err := h.queries.DisableUserTOTP(ctx, user.ID); err != nil {
+		c.HTML(http.StatusInternalServerError, "error.html", gin.H{
+			"error": "2단계 인증 초기화에 실패했습니다.",
+		})
+		return
+	}
+
+	recordAudit(ctx, h.queries, sessionUserID(c), auditActionReset2FA, auditEntityUser, user.ID,
+		auditTwoFactor{Enabled: tf.TotpEnabledAt.Valid, EnabledAt: tf.TotpEnabledAt, UnusedRecoveryCodes: remaining}, auditTwoFactor{})
+
+	c.Redirect(http.StatusFound, "/admin/users/"+c.Param("id")+"/edit")
+}
+
+// ResetPassword replaces a user's password with a random temporary one that is shown once.
+// The user must change it at the next login, every device they are logged in on is logged
+// out and their API tokens are revoked.
+func (h *UserHandler) ResetPassword(c *gin.Context) {
+	user, ok := h.loadManagedUser(c)
+	if !ok {
+		return
+	}
+	if !canResetCredentials(c.GetString("role"), sessionUserID(c), user.ID) {
+		c.HTML(http.StatusForbidden, "error.html", gin.H{
+			"error": "비밀번호 초기화는 최고관리자만 다른 사용자에 대해 할 수 있습니다.",
+		})
+		return
+	}
 
+	temporary, err := passwordpolicy.Temporary(user.Username)
+	if err != nil {
+		c.HTML(http.StatusInternalServerError, "error.html", gin.H{
+			"error": "임시 비밀번호를 만드는데 실패했습니다.",
+		})
+		return
+	}
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(temporary), bcrypt.DefaultCost)
 	if err != nil {
-		c.HTML(http.StatusInternalServerError, "user_form.html", gin.H{
-			"title":       "관리자 수정",
-			"action":      "/admin/users/" + c.Param("id"),
-			"error":       "사용자 수정에 실패했습니다.",
-			"user":        targetUser,
-			"currentRole": currentRole,
-			"isSelf":      isSelf,
+		c.HTML(http.StatusInternalServerError, "error.html", gin.H{
+			"error": "비밀번호 처리 중 오류가 발생했습니다.",
 		})
 		return
 	}
 
-	// 비밀번호가 입력된 경우에만 업데이트
-	if password != "" {
-		hashedPassword, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
-		if err == nil {
-			h.queries.UpdateUserPassword(c.Request.Context(), sqlc.UpdateUserPasswordParams{
-				ID:           int32(id),
-				PasswordHash: string(hashedPassword),
-			})
+	ctx := c.Request.Context()
+	err = func() error {
+		tx, err := h.pool.Begin(ctx)
+		if err != nil {
+			return err
+		}
+		defer tx.Rollback(ctx)
+		qtx := h.queries.WithTx(tx)
+
+		if err := qtx.UpdateUserPassword(ctx, sqlc.UpdateUserPasswordParams{
+			ID:                 user.ID,
+			PasswordHash:       string(hashedPassword),
+			MustChangePassword: true,
+		}); err != nil {
+			return err
+		}
+		// 기존 로그인과 API 토큰은 모두 종료하고, 잠겨 있었다면 임시 비밀번호로 바로 로그인할 수 있게 함
+		if err := qtx.DeleteAllUserSessions(ctx, user.ID); err != nil {
+			return err
+		}
+		if err := qtx.RevokeAllUserApiTokens(ctx, user.ID); err != nil {
+			return err
 		}
+		if err := qtx.ResetLoginFailures(ctx, user.ID); err != nil {
+			return err
+		}
+		return tx.Commit(ctx)
+	}()
+	if err != nil {
+		c.HTML(http.StatusInternalServerError, "error.html", gin.H{
+			"error": "비밀번호 초기화에 실패했습니다.",
+		})
+		return
 	}
 
-	c.Redirect(http.StatusFound, "/admin/users")
+	snapshot := sqlc.ListUsersRow{ID: user.ID, Username: user.Username, Name: user.Name, Role: user.Role, CreatedAt: user.CreatedAt}
+	recordAudit(ctx, h.queries, sessionUserID(c), auditActionResetPassword, auditEntityUser, user.ID,
+		auditUser{ListUsersRow: snapshot}, auditUser{ListUsersRow: snapshot, PasswordChanged: true})
+
+	user.MustChangePassword = true
+	user.FailedLoginCount = 0
+	user.LockedUntil = pgtype.Timestamptz{}
+
+	// 임시 비밀번호는 저장하지 않으므로 리다이렉트하지 않고 이 응답에서 한 번만 표시
+	h.renderForm(c, http.StatusOK, gin.H{
+		"title":             "관리자 수정",
+		"action":            "/admin/users/" + c.Param("id"),
+		"user":              user,
+		"temporaryPassword": temporary,
+	})
 }
 
-func (h *UserHandler) DeleteUser(c *gin.Context) {
-	session := sessions.Default(c)
-	currentUserID := session.Get("user_id").(int32)
-	currentRole := session.Get("role").(string)
+// canResetCredentials reports whether the current user may reset target's password or 2FA:
+// super admins only, and never their own so the checks on the account pages cannot be
+// bypassed with a stolen session
+func canResetCredentials(currentRole string, currentUserID, targetID int32) bool {
+	return currentRole == permission.SuperAdminRole && currentUserID != targetID
+}
 
+// canChangeUserRole reports whether the role field of the target applies: nobody changes
+// their own role, and a super admin keeps that role
+func canChangeUserRole(currentUserID int32, target sqlc.GetUserByIDRow) bool {
+	return currentUserID != target.ID && target.Role != permission.SuperAdminRole
+}
+
+// loadManagedUser looks up :id and checks that the current user may manage that account
+func (h *UserHandler) loadManagedUser(c *gin.Context) (sqlc.GetUserByIDRow, bool) {
 	id, err := strconv.ParseInt(c.Param("id"), 10, 32)
 	if err != nil {
 		c.Redirect(http.StatusFound, "/admin/users")
-		return
+		return sqlc.GetUserByIDRow{}, false
 	}
 
-	// 자기 자신은 삭제 불가
-	if currentUserID == int32(id) {
+	user, err := h.queries.GetUserByID(c.Request.Context(), int32(id))
+	if err != nil {
+		c.Redirect(http.StatusFound, "/admin/users")
+		return sqlc.GetUserByIDRow{}, false
+	}
+
+	if !canManageUser(sessionUserID(c), c.GetString("role"), permission.FromContext(c), user.ID, user.Role) {
 		c.HTML(http.StatusForbidden, "error.html", gin.H{
-			"error": "자기 자신은 삭제할 수 없습니다.",
+			"error": "다른 관리자를 수정할 권한이 없습니다.",
 		})
-		return
+		return sqlc.GetUserByIDRow{}, false
 	}
+	return user, true
+}
 
-	// 최고관리자만 삭제 가능
-	if currentRole != "super_admin" {
-		c.HTML(http.StatusForbidden, "error.html", gin.H{
-			"error": "최고관리자만 관리자를 삭제할 수 있습니다.",
+// renderForm renders user_form.html with the roles the current user may grant. When editing,
+// roleLocked shows the current role read-only instead of the picker.
+func (h *UserHandler) renderForm(c *gin.Context, status int, data gin.H) {
+	roles, err := assignableRoles(c.Request.Context(), h.queries, c.GetString("role"), permission.FromContext(c))
+	if err != nil {
+		c.HTML(http.StatusInternalServerError, "error.html", gin.H{
+			"error": "역할 목록을 불러오는데 실패했습니다.",
+		})
+		return
+	}
+	roleNames, err := roleDisplayNames(c.Request.Context(), h.queries)
+	if err != nil {
+		c.HTML(http.StatusInternalServerError, "error.html", gin.H{
+			"error": "역할 목록을 불러오는데 실패했습니다.",
 		})
 		return
 	}
 
-	h.queries.DeleteUser(c.Request.Context(), int32(id))
+	if user, ok := data["user"].(sqlc.GetUserByIDRow); ok {
+		data["isSelf"] = user.ID == sessionUserID(c)
+		data["roleLocked"] = !canChangeUserRole(sessionUserID(c), user)
 
-	c.Redirect(http.StatusFound, "/admin/users")
+		// 로그인 잠금 상태와 최근 로그인 시도
+		attempts, err := h.queries.ListRecentLoginAttemptsByUser(c.Request.Context(), sqlc.ListRecentLoginAttemptsByUserParams{
+			UserID: pgtype.Int4{Int32: user.ID, Valid: true},
+			Limit:  recentLoginAttempts,
+		})
+		if err != nil {
+			c.HTML(http.StatusInternalServerError, "error.html", gin.H{
+				"error": "로그인 기록을 불러오는데 실패했습니다.",
+			})
+			return
+		}
+		data["loginAttempts"] = attempts
+		data["locked"] = user.LockedUntil.Valid && user.LockedUntil.Time.After(time.Now())
+		data["canUnlock"] = permission.FromContext(c).Has(permission.UsersManage)
+
+		tf, err := h.queries.GetUserTwoFactor(c.Request.Context(), user.ID)
+		if err != nil {
+			c.HTML(http.StatusInternalServerError, "error.html", gin.H{
+				"error": "2단계 인증 정보를 불러오는데 실패했습니다.",
+			})
+			return
+		}
+		data["twoFactorEnabledAt"] = tf.TotpEnabledAt
+		canReset := canResetCredentials(c.GetString("role"), sessionUserID(c), user.ID)
+		data["canResetTwoFactor"] = tf.TotpEnabledAt.Valid && canReset
+		data["canResetPassword"] = canReset
+	}
+	data["roles"] = roles
+	data["roleNames"] = roleNames
+	data["passwordPolicy"] = passwordpolicy.Summary
+	data["currentPage"] = "users"
+
+	renderPage(c, status, "user_form.html", data)
 }
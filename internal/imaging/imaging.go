@@ -0,0 +1,142 @@
+// Package imaging validates uploaded images and renders their thumbnails. Only formats
+// browsers can display are accepted, and the pixel count is checked from the header before
+// anything is decoded so a small file cannot expand into gigabytes of memory.
+package imaging
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"image/color"
+	_ "image/gif"
+	"image/jpeg"
+	_ "image/png"
+
+	"golang.org/x/image/draw"
+	_ "golang.org/x/image/webp"
+)
+
+// MaxPixels is the largest width*height accepted; 40MP covers scans and phone photos
+const MaxPixels = 40_000_000
+
+var (
+	// ErrUnsupported is returned for data that is not a JPEG, PNG, GIF or WebP image
+	ErrUnsupported = errors.New("imaging: unsupported image format")
+	// ErrTooLarge is returned for images with more than MaxPixels pixels
+	ErrTooLarge = errors.New("imaging: image dimensions too large")
+)
+
+var contentTypes = map[string]string{
+	"jpeg": "image/jpeg",
+	"png":  "image/png",
+	"gif":  "image/gif",
+	"webp": "image/webp",
+}
+
+// Info describes an image as it is displayed, i.e. with the EXIF orientation applied
+type Info struct {
+	Format      string
+	ContentType string
+	Width       int
+	Height      int
+	orientation int
+}
+
+// Inspect reads the image header without decoding the pixels
+func Inspect(data []byte) (Info, error) {
+	cfg, format, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return Info{}, ErrUnsupported
+	}
+	contentType, ok := contentTypes[format]
+	if !ok {
+		return Info{}, ErrUnsupported
+	}
+	if cfg.Width <= 0 || cfg.Height <= 0 {
+		return Info{}, ErrUnsupported
+	}
+	if int64(cfg.Width)*int64(cfg.Height) > MaxPixels {
+		return Info{}, ErrTooLarge
+	}
+
+	info := Info{Format: format, ContentType: contentType, Width: cfg.Width, Height: cfg.Height, orientation: 1}
+	if format == "jpeg" {
+		info.orientation = jpegOrientation(data)
+	}
+	// 5~8은 90도 회전이므로 화면에 보이는 가로/세로가 바뀜
+	if info.orientation >= 5 {
+		info.Width, info.Height = info.Height, info.Width
+	}
+	return info, nil
+}
+
+// Thumbnail renders the image scaled to fit in a size x size box as a JPEG. Transparent
+// areas are filled with white and smaller images are not enlarged.
+func Thumbnail(data []byte, size int) ([]byte, error) {
+	info, err := Inspect(data)
+	if err != nil {
+		return nil, err
+	}
+	src, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, ErrUnsupported
+	}
+
+	b := src.Bounds()
+	w, h := b.Dx(), b.Dy()
+	if w > size || h > size {
+		if w >= h {
+			w, h = size, max(1, h*size/b.Dx())
+		} else {
+			w, h = max(1, w*size/b.Dy()), size
+		}
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, w, h))
+	draw.Draw(dst, dst.Bounds(), image.NewUniform(color.White), image.Point{}, draw.Src)
+	draw.CatmullRom.Scale(dst, dst.Bounds(), src, b, draw.Over, nil)
+
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, orient(dst, info.orientation), &jpeg.Options{Quality: 80}); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// orient applies an EXIF orientation (1-8) so the thumbnail matches how browsers show the original
+func orient(src *image.RGBA, orientation int) image.Image {
+	if orientation < 2 || orientation > 8 {
+		return src
+	}
+
+	w, h := src.Bounds().Dx(), src.Bounds().Dy()
+	dw, dh := w, h
+	if orientation >= 5 {
+		dw, dh = h, w
+	}
+	dst := image.NewRGBA(image.Rect(0, 0, dw, dh))
+
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			var dx, dy int
+			switch orientation {
+			case 2:
+				dx, dy = w-1-x, y
+			case 3:
+				dx, dy = w-1-x, h-1-y
+			case 4:
+				dx, dy = x, h-1-y
+			case 5:
+				dx, dy = y, x
+			case 6:
+				dx, dy = h-1-y, x
+			case 7:
+				dx, dy = h-1-y, w-1-x
+			case 8:
+				dx, dy = y, w-1-x
+			}
+			dst.SetRGBA(dx, dy, src.RGBAAt(x, y))
+		}
+	}
+	return dst
+}
@@ -0,0 +1,81 @@
+// Package loginguard holds the brute-force rules for the login form: how long a failed
+// attempt is delayed, when an account is locked and for how long, and how many failures a
+// single IP may produce before it is refused outright.
+package loginguard
+
+import (
+	"context"
+	"time"
+)
+
+const (
+	// MaxFailures consecutive wrong passwords lock the account
+	MaxFailures = 5
+	// BaseLockout is the first lock; every further failure after a lock expires doubles it
+	BaseLockout = 15 * time.Minute
+	MaxLockout  = 24 * time.Hour
+
+	// IPWindow and MaxIPFailures limit failures from one address across all usernames
+	IPWindow      = 15 * time.Minute
+	MaxIPFailures = 20
+
+	// failures below freeDelayFailures are answered immediately
+	freeDelayFailures = 3
+	maxDelay          = 8 * time.Second
+)
+
+// Reasons stored in login_attempts.reason
+const (
+	ReasonSuccess     = ""
+	ReasonBadPassword = "bad_password"
+	ReasonUnknownUser = "unknown_user"
+	ReasonLocked      = "locked"
+	ReasonIPBlocked   = "ip_blocked"
+	// ReasonBadOTP is a wrong authenticator or recovery code after a correct password
+	ReasonBadOTP = "bad_otp"
+)
+
+// Lockout returns how long to lock an account after its failures-th consecutive failure, or
+// 0 if it stays unlocked
+func Lockout(failures int32) time.Duration {
+	if failures < MaxFailures {
+		return 0
+	}
+	d := BaseLockout
+	for i := int32(MaxFailures); i < failures && d < MaxLockout; i++ {
+		d *= 2
+	}
+	if d > MaxLockout {
+		d = MaxLockout
+	}
+	return d
+}
+
+// Delay returns how long to hold a failed response, doubling from one second once the
+// failures pass the free attempts
+func Delay(failures int64) time.Duration {
+	if failures < freeDelayFailures {
+		return 0
+	}
+	d := time.Second
+	for i := int64(freeDelayFailures); i < failures && d < maxDelay; i++ {
+		d *= 2
+	}
+	if d > maxDelay {
+		d = maxDelay
+	}
+	return d
+}
+
+// Wait sleeps for d or until the request is cancelled
+func Wait(ctx context.Context, d time.Duration) {
+	if d <= 0 {
+		return
+	}
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-t.C:
+	case <-ctx.Done():
+	}
+}
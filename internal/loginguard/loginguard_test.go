@@ -0,0 +1,60 @@
+package loginguard
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestLockout(t *testing.T) {
+	tests := []struct {
+		failures int32
+		want     time.Duration
+	}{
+		{0, 0},
+		{MaxFailures - 1, 0},
+		{MaxFailures, BaseLockout},
+		{MaxFailures + 1, 2 * BaseLockout},
+		{MaxFailures + 2, 4 * BaseLockout},
+		{MaxFailures + 6, 64 * BaseLockout},
+		{MaxFailures + 7, MaxLockout},
+		{1000, MaxLockout},
+	}
+	for _, tt := range tests {
+		if got := Lockout(tt.failures); got != tt.want {
+			t.Errorf("Lockout(%d) = %v, want %v", tt.failures, got, tt.want)
+		}
+	}
+}
+
+func TestDelay(t *testing.T) {
+	tests := []struct {
+		failures int64
+		want     time.Duration
+	}{
+		{0, 0},
+		{freeDelayFailures - 1, 0},
+		{freeDelayFailures, time.Second},
+		{freeDelayFailures + 1, 2 * time.Second},
+		{freeDelayFailures + 2, 4 * time.Second},
+		{freeDelayFailures + 3, maxDelay},
+		{freeDelayFailures + 4, maxDelay},
+		{1 << 40, maxDelay},
+	}
+	for _, tt := range tests {
+		if got := Delay(tt.failures); got != tt.want {
+			t.Errorf("Delay(%d) = %v, want %v", tt.failures, got, tt.want)
+		}
+	}
+}
+
+func TestWaitStopsOnCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	start := time.Now()
+	Wait(ctx, time.Hour)
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Wait on a cancelled context took %v", elapsed)
+	}
+}
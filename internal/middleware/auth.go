@@ -1,65 +1,231 @@
 package middleware
 
 import (
+	"errors"
 	"net/http"
+	"strings"
 
 	"github.com/gin-contrib/sessions"
 	"github.com/gin-gonic/gin"
 
+	"github.com/choiexe1/hongik-academy/internal/apitoken"
 	"github.com/choiexe1/hongik-academy/internal/db/sqlc"
+	"github.com/choiexe1/hongik-academy/internal/permission"
+	"github.com/choiexe1/hongik-academy/internal/usersession"
 )
 
 func AuthRequired(queries *sqlc.Queries) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		session := sessions.Default(c)
-		userID := session.Get("user_id")
-		sessionToken := session.Get("session_token")
+		id, ok := session.Get("user_id").(int32)
+		if !ok {
+			c.Redirect(http.StatusFound, "/login")
+			c.Abort()
+			return
+		}
+
+		// 로그인 세션 검증 (만료되었거나 내 세션 목록에서 종료된 경우)
+		userSession, err := activeSession(c, queries, id, session.Get("session_token"))
+		if err != nil {
+			session.Clear()
+			session.Save()
+			c.Redirect(http.StatusFound, "/login?reason=session_expired")
+			c.Abort()
+			return
+		}
+		c.Set(SessionIDKey, userSession.ID)
 
-		if userID == nil {
+		// 역할은 요청마다 DB에서 읽어 권한 변경이 바로 반영되게 함
+		auth, err := queries.GetUserAuthorization(c.Request.Context(), id)
+		if err != nil {
+			session.Clear()
+			session.Save()
 			c.Redirect(http.StatusFound, "/login")
 			c.Abort()
 			return
 		}
+		if session.Get("role") != auth.Role {
+			session.Set("role", auth.Role)
+			session.Save()
+		}
+		setAuthorization(c, id, auth)
+
+		// 비밀번호 변경 요구 → 2단계 인증 등록 순서로, 마칠 때까지 해당 화면만 사용 가능
+		path := c.Request.URL.Path
+		if auth.MustChangePassword && path != PasswordChangePath {
+			c.Redirect(http.StatusFound, PasswordChangePath)
+			c.Abort()
+			return
+		}
+		if auth.RequireTwoFactor && !auth.TwoFactorEnabled && path != PasswordChangePath && !strings.HasPrefix(path, TwoFactorSetupPath) {
+			c.Redirect(http.StatusFound, TwoFactorSetupPath)
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// PasswordChangePath is the "change my password" page, the only page open to a user who
+// must replace a password an administrator set
+const PasswordChangePath = "/account/password"
+
+// TwoFactorSetupPath is the 2FA settings page, the only page open to a user whose role
+// requires 2FA before they enroll
+const TwoFactorSetupPath = "/account/2fa"
+
+// MustChangePasswordKey is the context key telling whether the user has to replace their password
+const MustChangePasswordKey = "must_change_password"
+
+// RequireTwoFactorKey is the context key telling whether the user's role requires 2FA
+const RequireTwoFactorKey = "require_two_factor"
+
+// SessionIDKey is the context key holding the id of the user_sessions row behind the request,
+// so the "my sessions" page can mark and protect the current session
+const SessionIDKey = "session_id"
+
+// errNoSessionToken rejects cookies issued before sessions were stored in user_sessions
+var errNoSessionToken = errors.New("session has no token")
+
+// activeSession looks up the login session for the token stored in the cookie and records
+// that it was just used
+func activeSession(c *gin.Context, queries *sqlc.Queries, userID int32, token interface{}) (sqlc.UserSession, error) {
+	tokenString, _ := token.(string)
+	if tokenString == "" {
+		return sqlc.UserSession{}, errNoSessionToken
+	}
+
+	userSession, err := queries.GetActiveUserSession(c.Request.Context(), sqlc.GetActiveUserSessionParams{
+		UserID:    userID,
+		TokenHash: usersession.Hash(tokenString),
+	})
+	if err != nil {
+		return sqlc.UserSession{}, err
+	}
+	queries.TouchUserSession(c.Request.Context(), userSession.ID)
+	return userSession, nil
+}
+
+// RequirePermission must run after AuthRequired. It renders the error page unless the
+// user's role holds every given permission.
+func RequirePermission(perms ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !permission.FromContext(c).Has(perms...) {
+			c.HTML(http.StatusForbidden, "error.html", gin.H{
+				"error": "이 기능을 사용할 권한이 없습니다. 필요한 경우 관리자에게 권한을 요청해주세요.",
+			})
+			c.Abort()
+			return
+		}
+
+		c.Next()
+	}
+}
 
-		// 세션 토큰 검증
-		if sessionToken != nil {
-			id, ok := userID.(int32)
-			if !ok {
-				session.Clear()
-				session.Save()
-				c.Redirect(http.StatusFound, "/login")
-				c.Abort()
+// APIAuthRequired authenticates /api requests with either an "Authorization: Bearer" API
+// token or the login session, and responds with a JSON error instead of redirecting.
+// The authenticated user is stored in the context as "user_id" (int32) and "role" (string),
+// with the role's permissions available through permission.FromContext.
+func APIAuthRequired(queries *sqlc.Queries) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if header := c.GetHeader("Authorization"); header != "" {
+			token, ok := strings.CutPrefix(header, "Bearer ")
+			if !ok || token == "" {
+				abortJSON(c, http.StatusUnauthorized, "invalid_token", "Authorization 헤더는 Bearer 토큰 형식이어야 합니다.")
 				return
 			}
 
-			dbToken, err := queries.GetSessionToken(c.Request.Context(), id)
-			if err != nil || !dbToken.Valid || dbToken.String != sessionToken.(string) {
-				// 토큰이 일치하지 않음 = 다른 기기에서 로그인함
-				session.Clear()
-				session.Save()
-				c.Redirect(http.StatusFound, "/login?reason=session_expired")
-				c.Abort()
+			apiToken, err := queries.GetActiveApiTokenByHash(c.Request.Context(), apitoken.Hash(token))
+			if err != nil {
+				abortJSON(c, http.StatusUnauthorized, "invalid_token", "유효하지 않거나 만료된 API 토큰입니다.")
+				return
+			}
+			auth, err := queries.GetUserAuthorization(c.Request.Context(), apiToken.UserID)
+			if err != nil {
+				abortJSON(c, http.StatusUnauthorized, "invalid_token", "유효하지 않거나 만료된 API 토큰입니다.")
+				return
+			}
+			if !accountReady(c, auth) {
 				return
 			}
+			queries.TouchApiToken(c.Request.Context(), apiToken.ID)
+
+			setAuthorization(c, apiToken.UserID, auth)
+			c.Next()
+			return
+		}
+
+		session := sessions.Default(c)
+		userID, ok := session.Get("user_id").(int32)
+		if !ok {
+			abortJSON(c, http.StatusUnauthorized, "unauthorized", "로그인이 필요합니다.")
+			return
+		}
+
+		userSession, err := activeSession(c, queries, userID, session.Get("session_token"))
+		if err != nil {
+			abortJSON(c, http.StatusUnauthorized, "session_expired", "세션이 만료되었거나 종료되었습니다. 다시 로그인해주세요.")
+			return
 		}
+		c.Set(SessionIDKey, userSession.ID)
+
+		auth, err := queries.GetUserAuthorization(c.Request.Context(), userID)
+		if err != nil {
+			abortJSON(c, http.StatusUnauthorized, "unauthorized", "로그인이 필요합니다.")
+			return
+		}
+		if !accountReady(c, auth) {
+			return
+		}
+		setAuthorization(c, userID, auth)
 
 		c.Next()
 	}
 }
 
-func AdminRequired() gin.HandlerFunc {
-	return func(c *gin.Context) {
-		session := sessions.Default(c)
-		role := session.Get("role")
+// accountReady aborts API requests from accounts that must first change their password or
+// set up 2FA on the web pages, whether they come with the session or an API token
+func accountReady(c *gin.Context, auth sqlc.GetUserAuthorizationRow) bool {
+	if auth.MustChangePassword {
+		abortJSON(c, http.StatusForbidden, "password_change_required", "비밀번호를 먼저 변경해주세요.")
+		return false
+	}
+	if auth.RequireTwoFactor && !auth.TwoFactorEnabled {
+		abortJSON(c, http.StatusForbidden, "two_factor_required", "2단계 인증을 먼저 설정해주세요.")
+		return false
+	}
+	return true
+}
 
-		if role != "super_admin" && role != "admin" {
-			c.HTML(http.StatusForbidden, "error.html", gin.H{
-				"error": "관리자 권한이 필요합니다.",
-			})
-			c.Abort()
+// APIRequirePermission is RequirePermission for /api routes; it must run after APIAuthRequired
+func APIRequirePermission(perms ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !permission.FromContext(c).Has(perms...) {
+			abortJSON(c, http.StatusForbidden, "forbidden", "이 기능을 사용할 권한이 없습니다.")
 			return
 		}
 
 		c.Next()
 	}
 }
+
+// setAuthorization stores the user's current role and permissions for handlers and templates
+func setAuthorization(c *gin.Context, userID int32, auth sqlc.GetUserAuthorizationRow) {
+	c.Set("user_id", userID)
+	c.Set("role", auth.Role)
+	c.Set("role_name", auth.RoleName)
+	c.Set(RequireTwoFactorKey, auth.RequireTwoFactor)
+	c.Set(MustChangePasswordKey, auth.MustChangePassword)
+	permission.Store(c, permission.New(auth.Permissions))
+}
+
+// abortJSON aborts the request with the same error envelope the API handlers use
+func abortJSON(c *gin.Context, status int, code, message string) {
+	c.AbortWithStatusJSON(status, gin.H{
+		"error": gin.H{
+			"code":    code,
+			"message": message,
+		},
+	})
+}
@@ -0,0 +1,129 @@
+package middleware
+
+import (
+	"bytes"
+	"html/template"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/gin-contrib/sessions"
+	"github.com/gin-contrib/sessions/cookie"
+	"github.com/gin-gonic/gin"
+)
+
+// multipartBody builds a form with the given fields in order followed by a file part
+func multipartBody(t *testing.T, fields [][2]string) (*bytes.Buffer, string) {
+	t.Helper()
+	var buf bytes.Buffer
+	w := multipart.NewWriter(&buf)
+	for _, f := range fields {
+		if err := w.WriteField(f[0], f[1]); err != nil {
+			t.Fatal(err)
+		}
+	}
+	part, err := w.CreateFormFile("images", "a.jpg")
+	if err != nil {
+		t.Fatal(err)
+	}
+	part.Write(bytes.Repeat([]byte("x"), 1024))
+	w.Close()
+	return &buf, w.FormDataContentType()
+}
+
+func TestFormToken(t *testing.T) {
+	tests := []struct {
+		name   string
+		fields [][2]string
+		want   string
+	}{
+		{"token first", [][2]string{{CSRFFieldName, "abc"}, {"title", "t"}}, "abc"},
+		{"token after another field", [][2]string{{"title", "t"}, {CSRFFieldName, "abc"}}, ""},
+		{"no token", [][2]string{{"title", "t"}}, ""},
+	}
+	for _, tt := range tests {
+		body, contentType := multipartBody(t, tt.fields)
+		original := body.String()
+		req := httptest.NewRequest(http.MethodPost, "/upload", body)
+		req.Header.Set("Content-Type", contentType)
+
+		if got := formToken(req); got != tt.want {
+			t.Errorf("%s: formToken = %q, want %q", tt.name, got, tt.want)
+		}
+		// 핸들러가 본문 전체를 다시 읽을 수 있어야 함
+		rest, err := io.ReadAll(req.Body)
+		if err != nil || string(rest) != original {
+			t.Errorf("%s: body was not restored after peeking (%d of %d bytes, %v)", tt.name, len(rest), len(original), err)
+		}
+	}
+}
+
+func TestFormTokenURLEncoded(t *testing.T) {
+	form := url.Values{CSRFFieldName: {"abc"}, "title": {"t"}}
+	req := httptest.NewRequest(http.MethodPost, "/students", strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	if got := formToken(req); got != "abc" {
+		t.Errorf("formToken = %q, want %q", got, "abc")
+	}
+}
+
+func TestCSRF(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.SetHTMLTemplate(template.Must(template.New("error.html").Parse("{{.error}}")))
+	r.Use(sessions.Sessions("session", cookie.NewStore([]byte("test-session-key"))))
+	r.Use(CSRF())
+	r.GET("/form", func(c *gin.Context) { c.String(http.StatusOK, c.GetString(CSRFTokenKey)) })
+	r.POST("/form", func(c *gin.Context) { c.String(http.StatusOK, "saved") })
+	r.POST("/api/v1/students", func(c *gin.Context) { c.String(http.StatusOK, "saved") })
+
+	// 폼을 열어 세션 쿠키와 토큰을 받음
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/form", nil))
+	token := w.Body.String()
+	cookies := w.Result().Cookies()
+	if token == "" || len(cookies) == 0 {
+		t.Fatalf("GET did not issue a token and session cookie")
+	}
+
+	tests := []struct {
+		name       string
+		path       string
+		form       string
+		header     map[string]string
+		withCookie bool
+		want       int
+	}{
+		{"form token", "/form", CSRFFieldName + "=" + token, nil, true, http.StatusOK},
+		{"header token", "/form", "", map[string]string{CSRFHeaderName: token}, true, http.StatusOK},
+		{"missing token", "/form", "title=t", nil, true, http.StatusForbidden},
+		{"wrong token", "/form", CSRFFieldName + "=" + strings.Repeat("0", len(token)), nil, true, http.StatusForbidden},
+		{"token from another session", "/form", CSRFFieldName + "=" + token, nil, false, http.StatusForbidden},
+		{"api with session but no header", "/api/v1/students", CSRFFieldName + "=" + token, nil, true, http.StatusForbidden},
+		{"api with session and header", "/api/v1/students", "", map[string]string{CSRFHeaderName: token}, true, http.StatusOK},
+		{"api with bearer token", "/api/v1/students", "", map[string]string{"Authorization": "Bearer abc"}, false, http.StatusOK},
+		{"bearer token outside the api", "/form", "", map[string]string{"Authorization": "Bearer abc"}, false, http.StatusForbidden},
+	}
+	for _, tt := range tests {
+		req := httptest.NewRequest(http.MethodPost, tt.path, strings.NewReader(tt.form))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		for k, v := range tt.header {
+			req.Header.Set(k, v)
+		}
+		if tt.withCookie {
+			for _, c := range cookies {
+				req.AddCookie(c)
+			}
+		}
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		if w.Code != tt.want {
+			t.Errorf("%s: status %d, want %d", tt.name, w.Code, tt.want)
+		}
+	}
+}
@@ -0,0 +1,90 @@
+package middleware
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5"
+
+	"github.com/choiexe1/hongik-academy/internal/db/sqlc"
+	"github.com/choiexe1/hongik-academy/internal/permission"
+	"github.com/choiexe1/hongik-academy/internal/policy"
+)
+
+// EvaluationAccess guards the /students/:id/evaluations/:eval_id routes. It checks that the
+// evaluation exists and belongs to student :id, and with edit set that the policy lets the
+// logged-in user change it. It must run after AuthRequired. The evaluation is stored in the context as "evaluation"
+// (sqlc.GetEvaluationByIDRow).
+func EvaluationAccess(queries *sqlc.Queries, edit bool) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		evaluation, status, err := checkEvaluationAccess(c, queries, edit)
+		if err != nil {
+			message := "평가표를 찾을 수 없습니다."
+			switch status {
+			case http.StatusForbidden:
+				message = "본인이 작성한 평가표만 수정하거나 삭제할 수 있습니다."
+			case http.StatusInternalServerError:
+				message = "평가표를 불러오는데 실패했습니다."
+			}
+			c.HTML(status, "error.html", gin.H{"error": message})
+			c.Abort()
+			return
+		}
+
+		c.Set("evaluation", evaluation)
+		c.Next()
+	}
+}
+
+// APIEvaluationAccess is EvaluationAccess for /api routes; it must run after APIAuthRequired
+func APIEvaluationAccess(queries *sqlc.Queries, edit bool) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		evaluation, status, err := checkEvaluationAccess(c, queries, edit)
+		if err != nil {
+			switch status {
+			case http.StatusForbidden:
+				abortJSON(c, status, "forbidden", "본인이 작성한 평가표만 수정하거나 삭제할 수 있습니다.")
+			case http.StatusInternalServerError:
+				abortJSON(c, status, "internal_error", "평가표를 불러오는데 실패했습니다.")
+			default:
+				abortJSON(c, status, "not_found", "평가표를 찾을 수 없습니다.")
+			}
+			return
+		}
+
+		c.Set("evaluation", evaluation)
+		c.Next()
+	}
+}
+
+var (
+	errEvaluationNotFound  = errors.New("evaluation not found")
+	errEvaluationForbidden = errors.New("evaluation edit not allowed")
+)
+
+func checkEvaluationAccess(c *gin.Context, queries *sqlc.Queries, edit bool) (sqlc.GetEvaluationByIDRow, int, error) {
+	studentID, err1 := strconv.ParseInt(c.Param("id"), 10, 32)
+	evalID, err2 := strconv.ParseInt(c.Param("eval_id"), 10, 32)
+	if err1 != nil || err2 != nil {
+		return sqlc.GetEvaluationByIDRow{}, http.StatusNotFound, errEvaluationNotFound
+	}
+
+	evaluation, err := queries.GetEvaluationByID(c.Request.Context(), int32(evalID))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return sqlc.GetEvaluationByIDRow{}, http.StatusNotFound, errEvaluationNotFound
+	}
+	if err != nil {
+		return sqlc.GetEvaluationByIDRow{}, http.StatusInternalServerError, err
+	}
+
+	// 다른 원생의 경로로 평가표에 접근하는 것을 막음
+	if evaluation.StudentID != int32(studentID) {
+		return sqlc.GetEvaluationByIDRow{}, http.StatusNotFound, errEvaluationNotFound
+	}
+	if edit && !policy.CanEditEvaluation(c.GetInt32("user_id"), permission.FromContext(c), evaluation.AuthorID) {
+		return sqlc.GetEvaluationByIDRow{}, http.StatusForbidden, errEvaluationForbidden
+	}
+	return evaluation, http.StatusOK, nil
+}
@@ -0,0 +1,60 @@
+package middleware
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5"
+
+	"github.com/choiexe1/hongik-academy/internal/db/sqlc"
+	"github.com/choiexe1/hongik-academy/internal/sharelink"
+)
+
+// ParentLinkRequired guards the parent portal. It never looks at the admin session: access
+// comes only from the signed :token in the URL, and the student it was issued for is stored
+// in the context as "parent_student_id" (int32) with its expiry as "parent_link_expires_at".
+// Links whose version is older than the student's current one were revoked. A nil signer
+// (SHARE_LINK_KEY not configured) rejects every link.
+func ParentLinkRequired(signer *sharelink.Signer, queries *sqlc.Queries) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		// 공유 링크가 검색엔진, 캐시, 외부 사이트 Referer로 새지 않도록 함
+		c.Header("Cache-Control", "no-store")
+		c.Header("Referrer-Policy", "no-referrer")
+		c.Header("X-Robots-Tag", "noindex, nofollow")
+
+		if signer == nil {
+			c.HTML(http.StatusNotFound, "parent_error.html", gin.H{"error": "올바르지 않은 링크입니다. 학원에 새 링크를 요청해주세요."})
+			c.Abort()
+			return
+		}
+		link, err := signer.Verify(c.Param("token"), time.Now())
+		if err != nil {
+			message := "올바르지 않은 링크입니다. 학원에 새 링크를 요청해주세요."
+			if errors.Is(err, sharelink.ErrExpired) {
+				message = "링크의 유효기간이 지났습니다. 학원에 새 링크를 요청해주세요."
+			}
+			c.HTML(http.StatusNotFound, "parent_error.html", gin.H{"error": message})
+			c.Abort()
+			return
+		}
+
+		// 한 번도 취소하지 않은 원생은 행이 없고 version 0
+		current, err := queries.GetStudentShareLink(c.Request.Context(), link.StudentID)
+		if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+			c.HTML(http.StatusInternalServerError, "parent_error.html", gin.H{"error": "링크를 확인하는데 실패했습니다. 잠시 후 다시 시도해주세요."})
+			c.Abort()
+			return
+		}
+		if link.Version != current.Version {
+			c.HTML(http.StatusNotFound, "parent_error.html", gin.H{"error": "학원에서 취소한 링크입니다. 학원에 새 링크를 요청해주세요."})
+			c.Abort()
+			return
+		}
+
+		c.Set("parent_student_id", link.StudentID)
+		c.Set("parent_link_expires_at", link.ExpiresAt)
+		c.Next()
+	}
+}
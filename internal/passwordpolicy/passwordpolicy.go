@@ -0,0 +1,97 @@
+// Package passwordpolicy holds the minimum rules a new password must meet. Every path that
+// sets a password (the admin user form, the users API and the "change my password" page)
+// checks it here so the rules cannot drift apart.
+package passwordpolicy
+
+import (
+	"crypto/rand"
+	"math/big"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+const (
+	MinLength = 8
+	// MaxBytes is bcrypt's input limit; longer passwords would be silently truncated
+	MaxBytes = 72
+)
+
+// Summary describes the rules for form hints
+var Summary = strconv.Itoa(MinLength) + "자 이상, 영문과 숫자를 모두 포함하고 아이디가 들어가지 않아야 합니다."
+
+// common passwords are refused even when they meet the other rules; admin123 is the
+// password of the seeded account
+var common = map[string]bool{
+	"admin123":   true,
+	"admin1234":  true,
+	"password1":  true,
+	"password12": true,
+	"qwerty123":  true,
+	"abcd1234":   true,
+	"abc12345":   true,
+	"a1234567":   true,
+	"1q2w3e4r":   true,
+	"1qaz2wsx":   true,
+	"hongik123":  true,
+}
+
+// Check returns why password may not be used by username, or "" when it meets the policy
+func Check(password, username string) string {
+	if len([]rune(password)) < MinLength {
+		return "비밀번호는 " + strconv.Itoa(MinLength) + "자 이상이어야 합니다."
+	}
+	if len(password) > MaxBytes {
+		return "비밀번호가 너무 깁니다."
+	}
+
+	var letter, digit bool
+	for _, r := range password {
+		switch {
+		case unicode.IsLetter(r):
+			letter = true
+		case unicode.IsDigit(r):
+			digit = true
+		}
+	}
+	if !letter || !digit {
+		return "비밀번호에는 영문과 숫자가 모두 포함되어야 합니다."
+	}
+
+	lower := strings.ToLower(password)
+	if username != "" && strings.Contains(lower, strings.ToLower(username)) {
+		return "비밀번호에 아이디를 포함할 수 없습니다."
+	}
+	if common[lower] {
+		return "너무 흔한 비밀번호입니다. 다른 비밀번호를 사용해주세요."
+	}
+	return ""
+}
+
+// temporaryLetters and temporaryDigits leave out characters that are easy to misread when
+// a password is read aloud or copied from the screen (0/O, 1/l/I)
+const (
+	temporaryLetters = "abcdefghijkmnpqrstuvwxyzABCDEFGHJKLMNPQRSTUVWXYZ"
+	temporaryDigits  = "23456789"
+	temporaryLength  = 12
+)
+
+// Temporary returns a random one-time password for an administrator reset of username's
+// account. It always passes Check, so the user can log in with it once before choosing
+// their own.
+func Temporary(username string) (string, error) {
+	alphabet := temporaryLetters + temporaryDigits
+	for {
+		b := make([]byte, temporaryLength)
+		for i := range b {
+			n, err := rand.Int(rand.Reader, big.NewInt(int64(len(alphabet))))
+			if err != nil {
+				return "", err
+			}
+			b[i] = alphabet[n.Int64()]
+		}
+		if password := string(b); Check(password, username) == "" {
+			return password, nil
+		}
+	}
+}
@@ -0,0 +1,53 @@
+package passwordpolicy
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCheck(t *testing.T) {
+	tests := []struct {
+		name     string
+		password string
+		username string
+		ok       bool
+	}{
+		{"valid", "drawing2024", "teacher", true},
+		{"valid with Hangul letters", "그림그리기1234", "teacher", true},
+		{"too short", "abc1234", "teacher", false},
+		{"short in runes though long in bytes", "가나다라1", "teacher", false},
+		{"letters only", "drawingclass", "teacher", false},
+		{"digits only", "1234567890", "teacher", false},
+		{"contains the username", "myteacher99", "teacher", false},
+		{"contains the username in another case", "MyTeacher99", "teacher", false},
+		{"common password", "admin123", "teacher", false},
+		{"common password in another case", "Admin123", "teacher", false},
+		{"longer than bcrypt accepts", strings.Repeat("a1", 37), "teacher", false},
+		{"exactly bcrypt's limit", strings.Repeat("a1", 36), "teacher", true},
+		{"no username to compare", "drawing2024", "", true},
+	}
+	for _, tt := range tests {
+		got := Check(tt.password, tt.username)
+		if (got == "") != tt.ok {
+			t.Errorf("%s: Check(%q, %q) = %q, want ok=%v", tt.name, tt.password, tt.username, got, tt.ok)
+		}
+	}
+}
+
+func TestTemporary(t *testing.T) {
+	for i := 0; i < 50; i++ {
+		password, err := Temporary("teacher")
+		if err != nil {
+			t.Fatalf("Temporary: %v", err)
+		}
+		if len(password) != temporaryLength {
+			t.Errorf("Temporary() = %q, want %d characters", password, temporaryLength)
+		}
+		if msg := Check(password, "teacher"); msg != "" {
+			t.Errorf("Temporary() = %q fails the policy: %s", password, msg)
+		}
+		if strings.ContainsAny(password, "0O1lI") {
+			t.Errorf("Temporary() = %q contains an easily misread character", password)
+		}
+	}
+}
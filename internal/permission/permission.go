@@ -0,0 +1,75 @@
+// Package permission names the permissions a role can hold. The keys match the permissions
+// table; roles and their permissions are stored in the database and loaded per request by
+// the auth middleware.
+package permission
+
+import "github.com/gin-gonic/gin"
+
+const (
+	StudentsRead   = "students.read"
+	StudentsWrite  = "students.write"
+	StudentsDelete = "students.delete"
+
+	EvaluationsRead    = "evaluations.read"
+	EvaluationsWrite   = "evaluations.write"
+	EvaluationsDelete  = "evaluations.delete"
+	EvaluationsEditAll = "evaluations.edit_all"
+	TemplatesManage    = "templates.manage"
+	RubricManage       = "rubric.manage"
+
+	ClassesRead  = "classes.read"
+	ClassesWrite = "classes.write"
+
+	AttendanceRead  = "attendance.read"
+	AttendanceWrite = "attendance.write"
+
+	BillingRead  = "billing.read"
+	BillingWrite = "billing.write"
+
+	UsersRead   = "users.read"
+	UsersManage = "users.manage"
+	RolesManage = "roles.manage"
+	AuditRead   = "audit.read"
+	TrashManage = "trash.manage"
+)
+
+// SuperAdminRole is the built-in role that holds every permission. Only a super admin may
+// grant it, and its permissions cannot be edited.
+const SuperAdminRole = "super_admin"
+
+// contextKey is where the auth middleware stores the user's Set
+const contextKey = "permissions"
+
+// Set is the permissions of one role. The zero value holds nothing, so a missing Set
+// denies everything.
+type Set map[string]bool
+
+func New(keys []string) Set {
+	s := make(Set, len(keys))
+	for _, key := range keys {
+		s[key] = true
+	}
+	return s
+}
+
+// Has reports whether the set holds every given permission
+func (s Set) Has(keys ...string) bool {
+	for _, key := range keys {
+		if !s[key] {
+			return false
+		}
+	}
+	return true
+}
+
+// Store saves the set for the rest of the request
+func Store(c *gin.Context, s Set) {
+	c.Set(contextKey, s)
+}
+
+// FromContext returns the set stored by the auth middleware, or an empty set
+func FromContext(c *gin.Context) Set {
+	s, _ := c.Get(contextKey)
+	set, _ := s.(Set)
+	return set
+}
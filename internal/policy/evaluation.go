@@ -0,0 +1,12 @@
+// Package policy holds the rules for who may change which records, so handlers and
+// middleware ask one place instead of each checking roles on their own.
+package policy
+
+import "github.com/choiexe1/hongik-academy/internal/permission"
+
+// CanEditEvaluation reports whether the user may edit or delete an evaluation written by
+// authorID. The author may always change their own evaluation; roles holding
+// evaluations.edit_all may change all of them.
+func CanEditEvaluation(userID int32, perms permission.Set, authorID int32) bool {
+	return userID == authorID || perms.Has(permission.EvaluationsEditAll)
+}
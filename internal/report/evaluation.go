@@ -0,0 +1,142 @@
+// Package report renders the PDF documents handed to parents. Korean text needs a TrueType
+// font with Hangul glyphs, which is read from the path given in REPORT_FONT_PATH and
+// embedded in every document.
+package report
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/jung-kurt/gofpdf"
+
+	"github.com/choiexe1/hongik-academy/internal/db/sqlc"
+)
+
+// AcademyName is printed in the header of every report
+const AcademyName = "홍익미술학원"
+
+const fontFamily = "report"
+
+// ErrFontUnavailable is wrapped by every error caused by a missing or unreadable font
+var ErrFontUnavailable = errors.New("report: font unavailable")
+
+// EvaluationReport is the content of a student's evaluation report. StartDate and EndDate
+// are the filter the evaluations were selected with; either may be zero for an open range.
+type EvaluationReport struct {
+	Student     sqlc.Student
+	StartDate   time.Time
+	EndDate     time.Time
+	Evaluations []sqlc.ExportEvaluationsByStudentRow
+	GeneratedAt time.Time
+}
+
+// CheckFont loads the font at fontPath the way a report does, so a missing or unreadable
+// font can be reported at startup instead of on the first report request
+func CheckFont(fontPath string) error {
+	_, err := newDocument(fontPath)
+	return err
+}
+
+// newDocument starts an A4 document with the report font loaded
+func newDocument(fontPath string) (*gofpdf.Fpdf, error) {
+	font, err := os.ReadFile(fontPath)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrFontUnavailable, err)
+	}
+
+	pdf := gofpdf.New("P", "mm", "A4", "")
+	pdf.AddUTF8FontFromBytes(fontFamily, "", font)
+	if pdf.Err() {
+		return nil, fmt.Errorf("%w: %v", ErrFontUnavailable, pdf.Error())
+	}
+	return pdf, nil
+}
+
+// WriteEvaluationReport renders the report as an A4 PDF, oldest evaluation first
+func WriteEvaluationReport(w io.Writer, fontPath string, r EvaluationReport) error {
+	pdf, err := newDocument(fontPath)
+	if err != nil {
+		return err
+	}
+	pdf.SetMargins(20, 20, 20)
+	pdf.SetAutoPageBreak(true, 20)
+	pdf.AliasNbPages("{nb}")
+	pdf.SetFooterFunc(func() {
+		pdf.SetY(-15)
+		pdf.SetFont(fontFamily, "", 8)
+		pdf.SetTextColor(148, 163, 184)
+		pdf.CellFormat(0, 5, fmt.Sprintf("%s · %s 발행 · %d/{nb}", AcademyName, r.GeneratedAt.Format("2006-01-02"), pdf.PageNo()), "", 0, "C", false, 0, "")
+	})
+	pdf.AddPage()
+
+	// 머리글
+	pdf.SetFont(fontFamily, "", 20)
+	pdf.SetTextColor(67, 56, 202)
+	pdf.CellFormat(0, 10, AcademyName, "", 1, "L", false, 0, "")
+	pdf.SetFont(fontFamily, "", 13)
+	pdf.SetTextColor(51, 65, 85)
+	pdf.CellFormat(0, 8, "원생 평가 리포트", "", 1, "L", false, 0, "")
+	pdf.SetDrawColor(203, 213, 225)
+	pdf.Line(20, pdf.GetY()+2, 190, pdf.GetY()+2)
+	pdf.Ln(6)
+
+	// 원생 정보
+	gender := "여"
+	if r.Student.Gender == "M" {
+		gender = "남"
+	}
+	pdf.SetFont(fontFamily, "", 10)
+	infoRow := func(label, value string) {
+		pdf.SetTextColor(100, 116, 139)
+		pdf.CellFormat(25, 6, label, "", 0, "L", false, 0, "")
+		pdf.SetTextColor(30, 41, 59)
+		pdf.CellFormat(0, 6, value, "", 1, "L", false, 0, "")
+	}
+	infoRow("이름", r.Student.Name)
+	infoRow("성별", gender)
+	if r.Student.EnrolledOn.Valid {
+		infoRow("입학일", r.Student.EnrolledOn.Time.Format("2006-01-02"))
+	}
+	infoRow("기간", periodLabel(r.StartDate, r.EndDate))
+	infoRow("평가 수", fmt.Sprintf("%d건", len(r.Evaluations)))
+	pdf.Ln(4)
+
+	if len(r.Evaluations) == 0 {
+		pdf.SetTextColor(100, 116, 139)
+		pdf.CellFormat(0, 10, "선택한 기간에 작성된 평가가 없습니다.", "", 1, "C", false, 0, "")
+	}
+
+	// 평가 목록은 최신순으로 조회되므로 거꾸로 출력
+	for i := len(r.Evaluations) - 1; i >= 0; i-- {
+		e := r.Evaluations[i]
+
+		pdf.SetFillColor(241, 245, 249)
+		pdf.SetFont(fontFamily, "", 10)
+		pdf.SetTextColor(30, 41, 59)
+		pdf.CellFormat(0, 8, fmt.Sprintf("  %s   작성자 %s", e.CreatedAt.Time.Format("2006-01-02"), e.AuthorName), "", 1, "L", true, 0, "")
+		pdf.Ln(2)
+
+		pdf.SetFont(fontFamily, "", 10)
+		pdf.SetTextColor(51, 65, 85)
+		pdf.MultiCell(0, 6, e.Content, "", "L", false)
+		pdf.Ln(5)
+	}
+
+	return pdf.Output(w)
+}
+
+func periodLabel(start, end time.Time) string {
+	switch {
+	case start.IsZero() && end.IsZero():
+		return "전체"
+	case start.IsZero():
+		return "~ " + end.Format("2006-01-02")
+	case end.IsZero():
+		return start.Format("2006-01-02") + " ~"
+	default:
+		return start.Format("2006-01-02") + " ~ " + end.Format("2006-01-02")
+	}
+}
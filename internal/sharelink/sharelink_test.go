@@ -0,0 +1,75 @@
+package sharelink
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+const testKey = "0123456789abcdef0123456789abcdef"
+
+func TestSignVerify(t *testing.T) {
+	s := NewSigner(testKey)
+	expiresAt := time.Unix(1_800_000_000, 0)
+	token := s.Sign(42, 3, expiresAt)
+
+	link, err := s.Verify(token, expiresAt.Add(-time.Second))
+	if err != nil {
+		t.Fatalf("Verify: %v", err)
+	}
+	want := Link{StudentID: 42, Version: 3, ExpiresAt: expiresAt}
+	if link != want {
+		t.Errorf("Verify = %+v, want %+v", link, want)
+	}
+}
+
+func TestVerifyExpiry(t *testing.T) {
+	s := NewSigner(testKey)
+	expiresAt := time.Unix(1_800_000_000, 0)
+	token := s.Sign(42, 0, expiresAt)
+
+	tests := []struct {
+		name string
+		now  time.Time
+		want error
+	}{
+		{"before expiry", expiresAt.Add(-time.Second), nil},
+		{"at expiry", expiresAt, ErrExpired},
+		{"after expiry", expiresAt.Add(time.Hour), ErrExpired},
+	}
+	for _, tt := range tests {
+		if _, err := s.Verify(token, tt.now); !errors.Is(err, tt.want) {
+			t.Errorf("%s: Verify error = %v, want %v", tt.name, err, tt.want)
+		}
+	}
+}
+
+func TestVerifyRejectsTampering(t *testing.T) {
+	s := NewSigner(testKey)
+	expiresAt := time.Unix(1_800_000_000, 0)
+	now := expiresAt.Add(-time.Hour)
+	token := s.Sign(42, 3, expiresAt)
+	sig := token[strings.LastIndexByte(token, '.')+1:]
+
+	tests := []struct {
+		name  string
+		token string
+	}{
+		{"empty", ""},
+		{"no signature", "42.3.1800000000"},
+		{"other student", "43.3.1800000000." + sig},
+		{"older version", "42.2.1800000000." + sig},
+		{"later expiry", "42.3.1900000000." + sig},
+		{"missing version", "42.1800000000." + sig},
+		{"extra field", "42.3.0.1800000000." + sig},
+		{"bad signature", token[:len(token)-1] + "x"},
+		{"other key", NewSigner(testKey + "x").Sign(42, 3, expiresAt)},
+		{"signed with the raw secret", "42.3.1800000000." + (&Signer{key: []byte(testKey)}).signature("42.3.1800000000")},
+	}
+	for _, tt := range tests {
+		if _, err := s.Verify(tt.token, now); !errors.Is(err, ErrInvalid) {
+			t.Errorf("%s: Verify(%q) error = %v, want ErrInvalid", tt.name, tt.token, err)
+		}
+	}
+}
@@ -0,0 +1,91 @@
+// Package spreadsheet reads and writes the CSV and XLSX files exchanged with office staff.
+// Rows are plain string slices; interpreting the columns is left to the caller.
+package spreadsheet
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// ErrUnsupportedFormat is returned for files that are neither .csv nor .xlsx
+var ErrUnsupportedFormat = errors.New("spreadsheet: unsupported file format")
+
+// utf8BOM is written by Excel when saving "CSV UTF-8"
+var utf8BOM = []byte{0xEF, 0xBB, 0xBF}
+
+// ReadRows returns every row of a CSV file or of the first sheet of an XLSX file.
+// The format is chosen by the file name extension. The quote Write puts in front of cells
+// that look like formulas is removed.
+func ReadRows(filename string, r io.Reader) ([][]string, error) {
+	var rows [][]string
+	var err error
+	switch strings.ToLower(filepath.Ext(filename)) {
+	case ".csv":
+		rows, err = readCSV(r)
+	case ".xlsx":
+		// 내보낸 CSV를 Excel에서 XLSX로 다시 저장해도 따옴표가 남으므로 함께 처리
+		rows, err = readXLSX(r)
+	default:
+		return nil, ErrUnsupportedFormat
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	for _, row := range rows {
+		for i, v := range row {
+			row[i] = unescapeFormula(v)
+		}
+	}
+	return rows, nil
+}
+
+func readCSV(r io.Reader) ([][]string, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	data = bytes.TrimPrefix(data, utf8BOM)
+
+	reader := csv.NewReader(bytes.NewReader(data))
+	// 행마다 열 개수가 달라도 읽음
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	var rows [][]string
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			return rows, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		// csv.Reader는 빈 줄을 건너뛰므로 XLSX와 같이 행 번호가 유지되도록 빈 행을 채움
+		line, _ := reader.FieldPos(0)
+		for len(rows) < line-1 {
+			rows = append(rows, nil)
+		}
+		rows = append(rows, record)
+	}
+}
+
+func readXLSX(r io.Reader) ([][]string, error) {
+	f, err := excelize.OpenReader(r)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	sheets := f.GetSheetList()
+	if len(sheets) == 0 {
+		return nil, nil
+	}
+	return f.GetRows(sheets[0])
+}
@@ -0,0 +1,73 @@
+package spreadsheet
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+)
+
+func TestEscapeFormula(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{"", ""},
+		{"김민지", "김민지"},
+		{"010-1234-5678", "010-1234-5678"},
+		{"=HYPERLINK(\"x\")", "'=HYPERLINK(\"x\")"},
+		{"+82 10", "'+82 10"},
+		{"-1", "'-1"},
+		{"@SUM(A1)", "'@SUM(A1)"},
+		{"\t=1+1", "'\t=1+1"},
+		{"\r=1+1", "'\r=1+1"},
+		{"'quoted", "'quoted"},
+		{"a=b", "a=b"},
+	}
+	for _, tt := range tests {
+		if got := escapeFormula(tt.in); got != tt.want {
+			t.Errorf("escapeFormula(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestUnescapeFormula(t *testing.T) {
+	tests := []struct {
+		in, want string
+	}{
+		{"", ""},
+		{"'", "'"},
+		{"'=1+1", "=1+1"},
+		{"'\t=1+1", "\t=1+1"},
+		{"'-1", "-1"},
+		// 사용자가 직접 쓴 따옴표는 수식 접두어가 뒤따르지 않으면 그대로 둠
+		{"'quoted", "'quoted"},
+		{"''=1", "''=1"},
+		{"김민지", "김민지"},
+	}
+	for _, tt := range tests {
+		if got := unescapeFormula(tt.in); got != tt.want {
+			t.Errorf("unescapeFormula(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestCSVRoundTrip(t *testing.T) {
+	header := []string{"이름", "메모"}
+	rows := [][]string{
+		{"김민지", "=1+1"},
+		{"-3", "\t@cmd"},
+		{"'quoted", ""},
+	}
+
+	var buf bytes.Buffer
+	if err := WriteCSV(&buf, header, rows); err != nil {
+		t.Fatalf("WriteCSV: %v", err)
+	}
+	got, err := ReadRows("students.csv", &buf)
+	if err != nil {
+		t.Fatalf("ReadRows: %v", err)
+	}
+	want := append([][]string{header}, rows...)
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("round trip = %q, want %q", got, want)
+	}
+}
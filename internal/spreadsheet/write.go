@@ -0,0 +1,136 @@
+package spreadsheet
+
+import (
+	"encoding/csv"
+	"io"
+	"strings"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// Format is an export file format
+type Format string
+
+const (
+	CSV  Format = "csv"
+	XLSX Format = "xlsx"
+)
+
+// ParseFormat returns the format named by a query param; anything but "xlsx" is CSV
+func ParseFormat(s string) Format {
+	if s == string(XLSX) {
+		return XLSX
+	}
+	return CSV
+}
+
+// ContentType returns the MIME type sent with the file
+func (f Format) ContentType() string {
+	if f == XLSX {
+		return "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+	}
+	return "text/csv; charset=utf-8"
+}
+
+// Write writes header and rows in the given format; sheet names the XLSX worksheet
+func Write(w io.Writer, f Format, sheet string, header []string, rows [][]string) error {
+	if f == XLSX {
+		return WriteXLSX(w, sheet, header, rows)
+	}
+	return WriteCSV(w, header, rows)
+}
+
+// WriteCSV writes a UTF-8 CSV file with a byte order mark, without which Excel opens
+// Korean text as mojibake
+func WriteCSV(w io.Writer, header []string, rows [][]string) error {
+	if _, err := w.Write(utf8BOM); err != nil {
+		return err
+	}
+
+	writer := csv.NewWriter(w)
+	if err := writer.Write(header); err != nil {
+		return err
+	}
+	for _, row := range rows {
+		escaped := make([]string, len(row))
+		for i, v := range row {
+			escaped[i] = escapeFormula(v)
+		}
+		if err := writer.Write(escaped); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+// formulaPrefixes are the first characters that make Excel or LibreOffice read a CSV cell as
+// a formula; tab and carriage return count because the cell is trimmed first
+const formulaPrefixes = "=+-@\t\r"
+
+// escapeFormula keeps Excel from running a cell that starts like a formula by prefixing it
+// with a quote, which ReadRows strips again. XLSX cells are written as strings and need no
+// escaping.
+func escapeFormula(v string) string {
+	if v != "" && strings.ContainsRune(formulaPrefixes, rune(v[0])) {
+		return "'" + v
+	}
+	return v
+}
+
+// unescapeFormula removes the quote escapeFormula added, so an exported file can be
+// imported again unchanged
+func unescapeFormula(v string) string {
+	if len(v) > 1 && v[0] == '\'' && strings.ContainsRune(formulaPrefixes, rune(v[1])) {
+		return v[1:]
+	}
+	return v
+}
+
+// WriteXLSX writes a workbook with a single sheet and a bold header row. Every cell is
+// written as text so phone numbers keep their leading zero.
+func WriteXLSX(w io.Writer, sheet string, header []string, rows [][]string) error {
+	f := excelize.NewFile()
+	defer f.Close()
+
+	if err := f.SetSheetName("Sheet1", sheet); err != nil {
+		return err
+	}
+
+	sw, err := f.NewStreamWriter(sheet)
+	if err != nil {
+		return err
+	}
+
+	boldStyle, err := f.NewStyle(&excelize.Style{Font: &excelize.Font{Bold: true}})
+	if err != nil {
+		return err
+	}
+
+	headerCells := make([]interface{}, len(header))
+	for i, h := range header {
+		headerCells[i] = excelize.Cell{StyleID: boldStyle, Value: h}
+	}
+	if err := sw.SetRow("A1", headerCells); err != nil {
+		return err
+	}
+
+	for i, row := range rows {
+		cells := make([]interface{}, len(row))
+		for j, v := range row {
+			cells[j] = v
+		}
+		cell, err := excelize.CoordinatesToCellName(1, i+2)
+		if err != nil {
+			return err
+		}
+		if err := sw.SetRow(cell, cells); err != nil {
+			return err
+		}
+	}
+
+	if err := sw.Flush(); err != nil {
+		return err
+	}
+	return f.Write(w)
+}
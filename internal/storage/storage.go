@@ -0,0 +1,42 @@
+// Package storage keeps uploaded files out of the database. Files are addressed by a
+// slash-separated key such as "evaluations/12/3f2a.jpg"; the database stores only the key,
+// so the backend can be swapped (local disk today, an S3-compatible bucket later) without
+// touching the rows.
+package storage
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+)
+
+var (
+	// ErrNotFound is returned by Open for keys that do not exist
+	ErrNotFound = errors.New("storage: not found")
+	// ErrInvalidKey is returned for empty, absolute or parent-relative keys
+	ErrInvalidKey = errors.New("storage: invalid key")
+)
+
+// Storage is a flat key/value store for file contents
+type Storage interface {
+	// Put stores the contents of r under key, replacing any existing file
+	Put(ctx context.Context, key string, r io.Reader, contentType string) error
+	// Open returns the contents stored under key; the caller must close it
+	Open(ctx context.Context, key string) (io.ReadCloser, error)
+	// Delete removes key; deleting a missing key is not an error
+	Delete(ctx context.Context, key string) error
+}
+
+// validateKey rejects keys that could escape the storage root
+func validateKey(key string) error {
+	if key == "" || strings.HasPrefix(key, "/") || strings.Contains(key, `\`) {
+		return ErrInvalidKey
+	}
+	for _, part := range strings.Split(key, "/") {
+		if part == "" || part == "." || part == ".." {
+			return ErrInvalidKey
+		}
+	}
+	return nil
+}
@@ -0,0 +1,74 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestValidateKey(t *testing.T) {
+	tests := []struct {
+		key string
+		ok  bool
+	}{
+		{"evaluations/12/3f2a.jpg", true},
+		{"evaluations/12/thumb_3f2a.jpg", true},
+		{"a", true},
+		{"", false},
+		{"/etc/passwd", false},
+		{"../secret", false},
+		{"evaluations/../../secret", false},
+		{"evaluations/./12.jpg", false},
+		{"evaluations//12.jpg", false},
+		{"evaluations/12/", false},
+		{`evaluations\..\secret`, false},
+		{"..", false},
+	}
+	for _, tt := range tests {
+		err := validateKey(tt.key)
+		if tt.ok && err != nil {
+			t.Errorf("validateKey(%q) = %v, want nil", tt.key, err)
+		}
+		if !tt.ok && !errors.Is(err, ErrInvalidKey) {
+			t.Errorf("validateKey(%q) = %v, want ErrInvalidKey", tt.key, err)
+		}
+	}
+}
+
+func TestLocalStorage(t *testing.T) {
+	ctx := context.Background()
+	s, err := NewLocalStorage(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewLocalStorage: %v", err)
+	}
+
+	const key = "evaluations/1/a.jpg"
+	if err := s.Put(ctx, key, strings.NewReader("image"), "image/jpeg"); err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+	r, err := s.Open(ctx, key)
+	if err != nil {
+		t.Fatalf("Open: %v", err)
+	}
+	data, err := io.ReadAll(r)
+	r.Close()
+	if err != nil || string(data) != "image" {
+		t.Fatalf("Open read %q, %v; want %q", data, err, "image")
+	}
+
+	if err := s.Delete(ctx, key); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if err := s.Delete(ctx, key); err != nil {
+		t.Errorf("Delete of a missing key = %v, want nil", err)
+	}
+	if _, err := s.Open(ctx, key); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Open after Delete = %v, want ErrNotFound", err)
+	}
+
+	if err := s.Put(ctx, "../escape.jpg", strings.NewReader("x"), "image/jpeg"); !errors.Is(err, ErrInvalidKey) {
+		t.Errorf("Put outside the root = %v, want ErrInvalidKey", err)
+	}
+}
@@ -0,0 +1,138 @@
+// Package textdiff computes a word-level diff between two versions of a text. Words and the
+// whitespace between them are compared as separate tokens, so Korean text (words separated
+// by spaces) and line breaks both diff naturally.
+package textdiff
+
+import (
+	"strings"
+	"unicode"
+)
+
+// OpType says how a span of text changed between the old and the new version
+type OpType string
+
+const (
+	Equal  OpType = "equal"
+	Insert OpType = "insert"
+	Delete OpType = "delete"
+)
+
+// Op is one span of the diff. Adjacent tokens of the same type are merged into one Op.
+type Op struct {
+	Type OpType
+	Text string
+}
+
+// maxCells bounds the LCS table. Beyond it the changed middle part is reported as one
+// deletion and one insertion instead of a detailed diff.
+const maxCells = 4 << 20
+
+// Diff returns the operations that turn oldText into newText
+func Diff(oldText, newText string) []Op {
+	a, b := tokenize(oldText), tokenize(newText)
+
+	// 공통 앞/뒷부분을 먼저 잘라내면 대부분의 수정은 작은 표로 계산됨
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(a)-prefix && suffix < len(b)-prefix && a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+
+	var ops []Op
+	ops = appendOp(ops, Equal, a[:prefix]...)
+	ops = append(ops, diffMiddle(a[prefix:len(a)-suffix], b[prefix:len(b)-suffix])...)
+	ops = appendOp(ops, Equal, a[len(a)-suffix:]...)
+	return merge(ops)
+}
+
+// Changed reports whether ops contains any insertion or deletion
+func Changed(ops []Op) bool {
+	for _, op := range ops {
+		if op.Type != Equal {
+			return true
+		}
+	}
+	return false
+}
+
+func diffMiddle(a, b []string) []Op {
+	if len(a) == 0 || len(b) == 0 || len(a)*len(b) > maxCells {
+		var ops []Op
+		ops = appendOp(ops, Delete, a...)
+		return appendOp(ops, Insert, b...)
+	}
+
+	// lcs[i][j]는 a[i:]와 b[j:]의 최장 공통 부분열 길이
+	lcs := make([][]int32, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int32, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	var ops []Op
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch {
+		case a[i] == b[j]:
+			ops = appendOp(ops, Equal, a[i])
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			ops = appendOp(ops, Delete, a[i])
+			i++
+		default:
+			ops = appendOp(ops, Insert, b[j])
+			j++
+		}
+	}
+	ops = appendOp(ops, Delete, a[i:]...)
+	return appendOp(ops, Insert, b[j:]...)
+}
+
+// tokenize splits text into alternating runs of whitespace and non-whitespace
+func tokenize(text string) []string {
+	var tokens []string
+	start, space := 0, false
+	for i, r := range text {
+		if i > start && unicode.IsSpace(r) != space {
+			tokens = append(tokens, text[start:i])
+			start = i
+		}
+		space = unicode.IsSpace(r)
+	}
+	if start < len(text) {
+		tokens = append(tokens, text[start:])
+	}
+	return tokens
+}
+
+func appendOp(ops []Op, typ OpType, tokens ...string) []Op {
+	if len(tokens) == 0 {
+		return ops
+	}
+	return append(ops, Op{Type: typ, Text: strings.Join(tokens, "")})
+}
+
+// merge joins adjacent operations of the same type
+func merge(ops []Op) []Op {
+	var merged []Op
+	for _, op := range ops {
+		if n := len(merged); n > 0 && merged[n-1].Type == op.Type {
+			merged[n-1].Text += op.Text
+			continue
+		}
+		merged = append(merged, op)
+	}
+	return merged
+}
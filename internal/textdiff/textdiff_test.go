@@ -0,0 +1,96 @@
+package textdiff
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestDiff(t *testing.T) {
+	tests := []struct {
+		name     string
+		old, new string
+		want     []Op
+	}{
+		{"identical", "수업 태도가 좋음", "수업 태도가 좋음", []Op{{Equal, "수업 태도가 좋음"}}},
+		{"both empty", "", "", nil},
+		{"added from empty", "", "새 평가", []Op{{Insert, "새 평가"}}},
+		{"cleared", "기존 평가", "", []Op{{Delete, "기존 평가"}}},
+		{"one word replaced", "수업 태도가 좋음", "수업 태도가 훌륭함", []Op{
+			{Equal, "수업 태도가 "}, {Delete, "좋음"}, {Insert, "훌륭함"},
+		}},
+		{"word inserted in the middle", "색감 표현이 좋음", "색감 표현이 매우 좋음", []Op{
+			{Equal, "색감 표현이 "}, {Insert, "매우 "}, {Equal, "좋음"},
+		}},
+		{"word deleted at the start", "오늘 데생 연습", "데생 연습", []Op{
+			{Delete, "오늘 "}, {Equal, "데생 연습"},
+		}},
+		{"line break changed", "첫 줄\n둘째 줄", "첫 줄 둘째 줄", []Op{
+			{Equal, "첫 줄"}, {Delete, "\n"}, {Insert, " "}, {Equal, "둘째 줄"},
+		}},
+		{"changes apart keep the equal part between", "a b c d e", "x b c d y", []Op{
+			{Delete, "a"}, {Insert, "x"}, {Equal, " b c d "}, {Delete, "e"}, {Insert, "y"},
+		}},
+	}
+	for _, tt := range tests {
+		got := Diff(tt.old, tt.new)
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: Diff(%q, %q) = %v, want %v", tt.name, tt.old, tt.new, got, tt.want)
+		}
+	}
+}
+
+// 어떤 입력이든 Equal+Delete는 이전 글, Equal+Insert는 새 글을 그대로 복원해야 함
+func TestDiffReconstructs(t *testing.T) {
+	pairs := [][2]string{
+		{"the quick brown fox", "the slow brown dog jumps"},
+		{"가 나 다 라 마", "마 라 다 나 가"},
+		{"  leading and trailing  ", "leading\tand trailing"},
+		{"same same same", "same different same same"},
+	}
+	for _, p := range pairs {
+		ops := Diff(p[0], p[1])
+		var oldText, newText strings.Builder
+		for i, op := range ops {
+			if i > 0 && ops[i-1].Type == op.Type {
+				t.Errorf("Diff(%q, %q): adjacent %s ops were not merged", p[0], p[1], op.Type)
+			}
+			if op.Type != Insert {
+				oldText.WriteString(op.Text)
+			}
+			if op.Type != Delete {
+				newText.WriteString(op.Text)
+			}
+		}
+		if oldText.String() != p[0] || newText.String() != p[1] {
+			t.Errorf("Diff(%q, %q) rebuilds %q and %q", p[0], p[1], oldText.String(), newText.String())
+		}
+	}
+}
+
+func TestDiffLargeInputFallsBack(t *testing.T) {
+	// 표가 maxCells를 넘으면 가운데 부분을 통째로 삭제+추가로 보고, 공통 뒷부분은 그대로 유지
+	a := strings.Repeat("a ", 3000) + "end"
+	b := strings.Repeat("b ", 3000) + "end"
+	ops := Diff(a, b)
+	want := []Op{
+		{Delete, strings.Repeat("a ", 2999) + "a"},
+		{Insert, strings.Repeat("b ", 2999) + "b"},
+		{Equal, " end"},
+	}
+	if !reflect.DeepEqual(ops, want) {
+		t.Errorf("Diff of large inputs = %d ops, want a single delete and insert before the common end", len(ops))
+	}
+}
+
+func TestChanged(t *testing.T) {
+	if Changed(Diff("같은 글", "같은 글")) {
+		t.Error("Changed reports a change for identical texts")
+	}
+	if Changed(nil) {
+		t.Error("Changed(nil) = true")
+	}
+	if !Changed(Diff("이전 글", "새 글")) {
+		t.Error("Changed misses an edit")
+	}
+}
@@ -0,0 +1,160 @@
+// Package twofactor implements the TOTP second login step: enrollment keys and their QR
+// codes, code verification with replay protection, and one-time recovery codes. Like
+// session tokens, recovery codes are stored only as SHA-256 hashes.
+package twofactor
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base32"
+	"encoding/base64"
+	"encoding/hex"
+	"image/png"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/pquerna/otp"
+	"github.com/pquerna/otp/totp"
+)
+
+const (
+	// Issuer is the account label shown in authenticator apps
+	Issuer = "홍익미술학원"
+	// Period is the TOTP time step in seconds
+	Period = 30
+	// RecoveryCodeCount codes are issued on enrollment and on every regeneration
+	RecoveryCodeCount = 10
+	// PendingLifetime is how long the password step stays valid while waiting for the code
+	PendingLifetime = 5 * time.Minute
+
+	// skew accepts the previous and next step to allow for clock drift
+	skew = 1
+	// recoveryAlphabet leaves out 0/O and 1/I/L, which are easily confused on paper
+	recoveryAlphabet = "23456789ABCDEFGHJKMNPQRSTUVWXYZ"
+)
+
+// Key is a freshly generated enrollment secret
+type Key struct {
+	Secret string
+	URL    string
+}
+
+// NewKey generates a TOTP secret for username
+func NewKey(username string) (*Key, error) {
+	key, err := totp.Generate(totp.GenerateOpts{
+		Issuer:      Issuer,
+		AccountName: username,
+		Period:      Period,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &Key{Secret: key.Secret(), URL: key.URL()}, nil
+}
+
+// KeyURL rebuilds the otpauth:// URL of an existing secret
+func KeyURL(username, secret string) (string, error) {
+	raw, err := base32.StdEncoding.WithPadding(base32.NoPadding).DecodeString(secret)
+	if err != nil {
+		return "", err
+	}
+	key, err := totp.Generate(totp.GenerateOpts{
+		Issuer:      Issuer,
+		AccountName: username,
+		Period:      Period,
+		Secret:      raw,
+	})
+	if err != nil {
+		return "", err
+	}
+	return key.URL(), nil
+}
+
+// QRCode renders an otpauth:// URL as a PNG data URI for an <img> tag
+func QRCode(url string) (string, error) {
+	key, err := otp.NewKeyFromURL(url)
+	if err != nil {
+		return "", err
+	}
+	img, err := key.Image(200, 200)
+	if err != nil {
+		return "", err
+	}
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return "", err
+	}
+	return "data:image/png;base64," + base64.StdEncoding.EncodeToString(buf.Bytes()), nil
+}
+
+// Verify checks code against secret and returns the time step it matched. Steps at or
+// before lastStep are rejected so an intercepted code cannot be replayed.
+func Verify(secret, code string, lastStep int64) (int64, bool) {
+	code = strings.ReplaceAll(strings.TrimSpace(code), " ", "")
+	if !IsTOTPCode(code) {
+		return 0, false
+	}
+
+	now := time.Now()
+	current := now.Unix() / Period
+	for offset := int64(-skew); offset <= skew; offset++ {
+		step := current + offset
+		if step <= lastStep {
+			continue
+		}
+		expected, err := totp.GenerateCodeCustom(secret, time.Unix(step*Period, 0), totp.ValidateOpts{
+			Period:    Period,
+			Digits:    otp.DigitsSix,
+			Algorithm: otp.AlgorithmSHA1,
+		})
+		if err == nil && expected == code {
+			return step, true
+		}
+	}
+	return 0, false
+}
+
+// GenerateRecoveryCodes returns RecoveryCodeCount new codes formatted as XXXXX-XXXXX
+func GenerateRecoveryCodes() ([]string, error) {
+	codes := make([]string, RecoveryCodeCount)
+	alphabetSize := big.NewInt(int64(len(recoveryAlphabet)))
+	for i := range codes {
+		raw := make([]byte, 10)
+		for j := range raw {
+			// rand.Int는 균등 분포라 바이트를 알파벳 길이로 나눈 나머지처럼 일부 문자에 치우치지 않음
+			n, err := rand.Int(rand.Reader, alphabetSize)
+			if err != nil {
+				return nil, err
+			}
+			raw[j] = recoveryAlphabet[n.Int64()]
+		}
+		codes[i] = string(raw[:5]) + "-" + string(raw[5:])
+	}
+	return codes, nil
+}
+
+// HashRecoveryCode returns the stored hash of a recovery code. Case, spaces and dashes
+// are ignored so a code typed as "abcde fghij" still matches.
+func HashRecoveryCode(code string) string {
+	normalized := strings.ToUpper(code)
+	normalized = strings.NewReplacer("-", "", " ", "").Replace(normalized)
+	sum := sha256.Sum256([]byte(normalized))
+	return hex.EncodeToString(sum[:])
+}
+
+// IsTOTPCode reports whether input has the shape of a 6 digit authenticator code; anything
+// else on the verification form is treated as a recovery code
+func IsTOTPCode(input string) bool {
+	input = strings.ReplaceAll(strings.TrimSpace(input), " ", "")
+	if len(input) != 6 {
+		return false
+	}
+	for _, r := range input {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
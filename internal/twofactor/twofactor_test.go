@@ -0,0 +1,126 @@
+package twofactor
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pquerna/otp"
+	"github.com/pquerna/otp/totp"
+)
+
+func codeAt(t *testing.T, secret string, at time.Time) string {
+	t.Helper()
+	code, err := totp.GenerateCodeCustom(secret, at, totp.ValidateOpts{
+		Period:    Period,
+		Digits:    otp.DigitsSix,
+		Algorithm: otp.AlgorithmSHA1,
+	})
+	if err != nil {
+		t.Fatalf("GenerateCodeCustom: %v", err)
+	}
+	return code
+}
+
+func TestVerify(t *testing.T) {
+	key, err := NewKey("teacher")
+	if err != nil {
+		t.Fatalf("NewKey: %v", err)
+	}
+	now := time.Now()
+
+	tests := []struct {
+		name string
+		code string
+		want bool
+	}{
+		{"current code", codeAt(t, key.Secret, now), true},
+		{"current code with spaces", " " + codeAt(t, key.Secret, now)[:3] + " " + codeAt(t, key.Secret, now)[3:], true},
+		{"previous step", codeAt(t, key.Secret, now.Add(-Period*time.Second)), true},
+		{"two steps old", codeAt(t, key.Secret, now.Add(-2*Period*time.Second)), false},
+		{"not digits", "abcdef", false},
+		{"too short", "12345", false},
+	}
+	for _, tt := range tests {
+		if _, ok := Verify(key.Secret, tt.code, 0); ok != tt.want {
+			t.Errorf("%s: Verify(%q) = %v, want %v", tt.name, tt.code, ok, tt.want)
+		}
+	}
+}
+
+func TestVerifyRejectsReplay(t *testing.T) {
+	key, err := NewKey("teacher")
+	if err != nil {
+		t.Fatalf("NewKey: %v", err)
+	}
+	code := codeAt(t, key.Secret, time.Now())
+
+	step, ok := Verify(key.Secret, code, 0)
+	if !ok {
+		t.Fatal("Verify rejected a current code")
+	}
+	if _, ok := Verify(key.Secret, code, step); ok {
+		t.Error("Verify accepted a code for an already used step")
+	}
+}
+
+func TestGenerateRecoveryCodes(t *testing.T) {
+	codes, err := GenerateRecoveryCodes()
+	if err != nil {
+		t.Fatalf("GenerateRecoveryCodes: %v", err)
+	}
+	if len(codes) != RecoveryCodeCount {
+		t.Fatalf("got %d codes, want %d", len(codes), RecoveryCodeCount)
+	}
+
+	seen := make(map[string]bool)
+	for _, code := range codes {
+		if len(code) != 11 || code[5] != '-' {
+			t.Errorf("code %q is not formatted as XXXXX-XXXXX", code)
+		}
+		for _, r := range strings.ReplaceAll(code, "-", "") {
+			if !strings.ContainsRune(recoveryAlphabet, r) {
+				t.Errorf("code %q contains %q outside the alphabet", code, r)
+			}
+		}
+		if seen[code] {
+			t.Errorf("code %q issued twice", code)
+		}
+		seen[code] = true
+		if IsTOTPCode(code) {
+			t.Errorf("code %q would be checked as an authenticator code", code)
+		}
+	}
+}
+
+func TestHashRecoveryCode(t *testing.T) {
+	want := HashRecoveryCode("ABCDE-FGHJK")
+	for _, input := range []string{"ABCDEFGHJK", "abcde-fghjk", "abcde fghjk", " ABCDE - FGHJK "} {
+		if got := HashRecoveryCode(input); got != want {
+			t.Errorf("HashRecoveryCode(%q) does not match the stored form", input)
+		}
+	}
+	if HashRecoveryCode("ABCDE-FGHJM") == want {
+		t.Error("different codes hashed the same")
+	}
+}
+
+func TestIsTOTPCode(t *testing.T) {
+	tests := []struct {
+		input string
+		want  bool
+	}{
+		{"123456", true},
+		{" 123 456 ", true},
+		{"12345", false},
+		{"1234567", false},
+		{"12345a", false},
+		{"ABCDE-FGHJK", false},
+		{"", false},
+	}
+	for _, tt := range tests {
+		if got := IsTOTPCode(tt.input); got != tt.want {
+			t.Errorf("IsTOTPCode(%q) = %v, want %v", tt.input, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,74 @@
+// Package usersession generates login session tokens and describes the devices they were
+// issued to. The plain token lives only in the session cookie; the database keeps its
+// SHA-256 hash, so a leaked user_sessions table cannot be used to log in.
+package usersession
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"time"
+)
+
+// Lifetime is how long a login stays valid. The session cookie uses the same max age.
+const Lifetime = 3 * 24 * time.Hour
+
+// Generate returns a new random session token and its hash
+func Generate() (token, hash string, err error) {
+	bytes := make([]byte, 32)
+	if _, err := rand.Read(bytes); err != nil {
+		return "", "", err
+	}
+	token = hex.EncodeToString(bytes)
+	return token, Hash(token), nil
+}
+
+// Hash returns the hex encoded SHA-256 hash of a token
+func Hash(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// browsers and platforms are checked in order; more specific names come first because
+// e.g. Edge and Chrome both send "Chrome" in their user agent
+var browsers = []struct{ token, name string }{
+	{"Edg/", "Edge"},
+	{"Whale/", "Whale"},
+	{"SamsungBrowser/", "Samsung Internet"},
+	{"KAKAOTALK", "카카오톡"},
+	{"Firefox/", "Firefox"},
+	{"Chrome/", "Chrome"},
+	{"Safari/", "Safari"},
+}
+
+var platforms = []struct{ token, name string }{
+	{"iPhone", "iPhone"},
+	{"iPad", "iPad"},
+	{"Android", "Android"},
+	{"Windows", "Windows"},
+	{"Mac OS X", "macOS"},
+	{"Linux", "Linux"},
+}
+
+// Describe turns a user agent into a short label such as "Chrome · Windows" for the
+// session list. Unknown agents are reported as "알 수 없는 기기".
+func Describe(userAgent string) string {
+	var parts []string
+	for _, b := range browsers {
+		if strings.Contains(userAgent, b.token) {
+			parts = append(parts, b.name)
+			break
+		}
+	}
+	for _, p := range platforms {
+		if strings.Contains(userAgent, p.token) {
+			parts = append(parts, p.name)
+			break
+		}
+	}
+	if len(parts) == 0 {
+		return "알 수 없는 기기"
+	}
+	return strings.Join(parts, " · ")
+}
@@ -0,0 +1,21 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE attendance (
+    id SERIAL PRIMARY KEY,
+    student_id INTEGER NOT NULL REFERENCES students(id) ON DELETE CASCADE,
+    attendance_date DATE NOT NULL,
+    status VARCHAR(10) NOT NULL CHECK (status IN ('present', 'late', 'absent', 'excused')),
+    memo TEXT,
+    recorded_by INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    UNIQUE (student_id, attendance_date)
+);
+
+CREATE INDEX idx_attendance_attendance_date ON attendance(attendance_date);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS attendance;
+-- +goose StatementEnd
@@ -0,0 +1,40 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE classes (
+    id SERIAL PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    instructor_id INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    capacity INTEGER CHECK (capacity IS NULL OR capacity > 0),
+    description TEXT,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+-- 요일은 ISO 기준 (1=월요일 ... 7=일요일)
+CREATE TABLE class_schedules (
+    id SERIAL PRIMARY KEY,
+    class_id INTEGER NOT NULL REFERENCES classes(id) ON DELETE CASCADE,
+    weekday SMALLINT NOT NULL CHECK (weekday BETWEEN 1 AND 7),
+    start_time TIME NOT NULL,
+    end_time TIME NOT NULL,
+    CHECK (start_time < end_time)
+);
+
+CREATE TABLE class_enrollments (
+    class_id INTEGER NOT NULL REFERENCES classes(id) ON DELETE CASCADE,
+    student_id INTEGER NOT NULL REFERENCES students(id) ON DELETE CASCADE,
+    enrolled_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    PRIMARY KEY (class_id, student_id)
+);
+
+CREATE INDEX idx_classes_instructor_id ON classes(instructor_id);
+CREATE INDEX idx_class_schedules_class_id ON class_schedules(class_id);
+CREATE INDEX idx_class_enrollments_student_id ON class_enrollments(student_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS class_enrollments;
+DROP TABLE IF EXISTS class_schedules;
+DROP TABLE IF EXISTS classes;
+-- +goose StatementEnd
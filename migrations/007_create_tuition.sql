@@ -0,0 +1,43 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE tuition_plans (
+    id SERIAL PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    monthly_fee BIGINT NOT NULL CHECK (monthly_fee >= 0),
+    description TEXT,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE TABLE tuition_invoices (
+    id SERIAL PRIMARY KEY,
+    student_id INTEGER NOT NULL REFERENCES students(id) ON DELETE CASCADE,
+    plan_id INTEGER REFERENCES tuition_plans(id) ON DELETE SET NULL,
+    billing_month DATE NOT NULL CHECK (EXTRACT(DAY FROM billing_month) = 1),
+    amount BIGINT NOT NULL CHECK (amount >= 0),
+    memo TEXT,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE TABLE tuition_payments (
+    id SERIAL PRIMARY KEY,
+    invoice_id INTEGER NOT NULL REFERENCES tuition_invoices(id) ON DELETE CASCADE,
+    amount BIGINT NOT NULL CHECK (amount > 0),
+    method VARCHAR(10) NOT NULL CHECK (method IN ('cash', 'card', 'transfer')),
+    paid_on DATE NOT NULL,
+    memo TEXT,
+    recorded_by INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX idx_tuition_invoices_student_id ON tuition_invoices(student_id);
+CREATE INDEX idx_tuition_invoices_billing_month ON tuition_invoices(billing_month);
+CREATE INDEX idx_tuition_payments_invoice_id ON tuition_payments(invoice_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS tuition_payments;
+DROP TABLE IF EXISTS tuition_invoices;
+DROP TABLE IF EXISTS tuition_plans;
+-- +goose StatementEnd
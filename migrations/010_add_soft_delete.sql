@@ -0,0 +1,26 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE students ADD COLUMN deleted_at TIMESTAMP WITH TIME ZONE;
+ALTER TABLE evaluations ADD COLUMN deleted_at TIMESTAMP WITH TIME ZONE;
+
+CREATE INDEX idx_students_deleted_at ON students(deleted_at) WHERE deleted_at IS NOT NULL;
+CREATE INDEX idx_evaluations_deleted_at ON evaluations(deleted_at) WHERE deleted_at IS NOT NULL;
+
+ALTER TABLE audit_log DROP CONSTRAINT audit_log_action_check;
+ALTER TABLE audit_log ADD CONSTRAINT audit_log_action_check
+    CHECK (action IN ('create', 'update', 'delete', 'restore', 'purge'));
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DELETE FROM audit_log WHERE action IN ('restore', 'purge');
+ALTER TABLE audit_log DROP CONSTRAINT audit_log_action_check;
+ALTER TABLE audit_log ADD CONSTRAINT audit_log_action_check
+    CHECK (action IN ('create', 'update', 'delete'));
+
+DROP INDEX IF EXISTS idx_evaluations_deleted_at;
+DROP INDEX IF EXISTS idx_students_deleted_at;
+
+ALTER TABLE evaluations DROP COLUMN IF EXISTS deleted_at;
+ALTER TABLE students DROP COLUMN IF EXISTS deleted_at;
+-- +goose StatementEnd
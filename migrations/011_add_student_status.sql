@@ -0,0 +1,38 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE students
+    ADD COLUMN status VARCHAR(20) NOT NULL DEFAULT 'enrolled'
+        CHECK (status IN ('enrolled', 'on_leave', 'graduated', 'withdrawn')),
+    ADD COLUMN enrolled_on DATE NOT NULL DEFAULT CURRENT_DATE,
+    ADD COLUMN left_on DATE,
+    ADD COLUMN status_reason TEXT;
+
+-- 기존 원생의 입학일은 등록일로 채움
+UPDATE students SET enrolled_on = created_at::date WHERE created_at IS NOT NULL;
+
+CREATE INDEX idx_students_status ON students(status);
+
+CREATE TABLE student_status_history (
+    id SERIAL PRIMARY KEY,
+    student_id INTEGER NOT NULL REFERENCES students(id) ON DELETE CASCADE,
+    from_status VARCHAR(20),
+    to_status VARCHAR(20) NOT NULL,
+    changed_on DATE NOT NULL,
+    reason TEXT,
+    changed_by INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX idx_student_status_history_student_id ON student_status_history(student_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS student_status_history;
+DROP INDEX IF EXISTS idx_students_status;
+ALTER TABLE students
+    DROP COLUMN IF EXISTS status_reason,
+    DROP COLUMN IF EXISTS left_on,
+    DROP COLUMN IF EXISTS enrolled_on,
+    DROP COLUMN IF EXISTS status;
+-- +goose StatementEnd
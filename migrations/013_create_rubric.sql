@@ -0,0 +1,36 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE rubric_criteria (
+    id SERIAL PRIMARY KEY,
+    name VARCHAR(50) NOT NULL UNIQUE,
+    description TEXT,
+    max_score INTEGER NOT NULL DEFAULT 5 CHECK (max_score BETWEEN 1 AND 100),
+    sort_order INTEGER NOT NULL DEFAULT 0,
+    active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+-- 점수가 기록된 기준은 삭제할 수 없고 사용 중지만 가능
+CREATE TABLE evaluation_scores (
+    evaluation_id INTEGER NOT NULL REFERENCES evaluations(id) ON DELETE CASCADE,
+    criterion_id INTEGER NOT NULL REFERENCES rubric_criteria(id) ON DELETE RESTRICT,
+    score INTEGER NOT NULL CHECK (score >= 0),
+    PRIMARY KEY (evaluation_id, criterion_id)
+);
+
+CREATE INDEX idx_evaluation_scores_criterion_id ON evaluation_scores(criterion_id);
+
+INSERT INTO rubric_criteria (name, max_score, sort_order) VALUES
+    ('소묘 형태', 5, 1),
+    ('명암', 5, 2),
+    ('구도', 5, 3),
+    ('색채', 5, 4),
+    ('완성도', 5, 5);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS evaluation_scores;
+DROP TABLE IF EXISTS rubric_criteria;
+-- +goose StatementEnd
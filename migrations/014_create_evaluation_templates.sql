@@ -0,0 +1,19 @@
+-- +goose Up
+-- +goose StatementBegin
+-- owner_id가 NULL이면 학원 공용 템플릿, 아니면 해당 강사의 개인 템플릿
+CREATE TABLE evaluation_templates (
+    id SERIAL PRIMARY KEY,
+    owner_id INTEGER REFERENCES users(id) ON DELETE CASCADE,
+    title VARCHAR(100) NOT NULL,
+    content TEXT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX idx_evaluation_templates_owner_id ON evaluation_templates(owner_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS evaluation_templates;
+-- +goose StatementEnd
@@ -0,0 +1,22 @@
+-- +goose Up
+-- +goose StatementBegin
+-- 평가표의 모든 버전을 보관 (가장 최근 행이 현재 내용)
+CREATE TABLE evaluation_revisions (
+    id SERIAL PRIMARY KEY,
+    evaluation_id INTEGER NOT NULL REFERENCES evaluations(id) ON DELETE CASCADE,
+    content TEXT NOT NULL,
+    edited_by INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX idx_evaluation_revisions_evaluation_id ON evaluation_revisions(evaluation_id);
+
+-- 기존 평가표는 현재 내용을 첫 버전으로 기록 (수정한 사람은 알 수 없음)
+INSERT INTO evaluation_revisions (evaluation_id, content, edited_by, created_at)
+SELECT id, content, NULL, COALESCE(updated_at, created_at) FROM evaluations;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS evaluation_revisions;
+-- +goose StatementEnd
@@ -0,0 +1,82 @@
+-- +goose Up
+-- +goose StatementBegin
+-- 권한 목록은 코드(internal/permission)와 함께 마이그레이션으로만 추가하며,
+-- 새 권한을 추가할 때는 super_admin 역할에도 함께 부여
+CREATE TABLE permissions (
+    key VARCHAR(50) PRIMARY KEY,
+    category VARCHAR(50) NOT NULL,
+    description VARCHAR(200) NOT NULL,
+    sort_order INTEGER NOT NULL DEFAULT 0
+);
+
+-- is_system 역할(super_admin, admin)은 삭제할 수 없음
+CREATE TABLE roles (
+    id SERIAL PRIMARY KEY,
+    name VARCHAR(20) NOT NULL UNIQUE,
+    display_name VARCHAR(50) NOT NULL,
+    description TEXT,
+    is_system BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE TABLE role_permissions (
+    role_id INTEGER NOT NULL REFERENCES roles(id) ON DELETE CASCADE,
+    permission VARCHAR(50) NOT NULL REFERENCES permissions(key) ON DELETE CASCADE,
+    PRIMARY KEY (role_id, permission)
+);
+
+INSERT INTO permissions (key, category, description, sort_order) VALUES
+    ('students.read', '원생', '원생 목록과 정보 조회', 10),
+    ('students.write', '원생', '원생 등록·수정·상태 변경·가져오기', 11),
+    ('students.delete', '원생', '원생 삭제', 12),
+    ('evaluations.read', '평가', '평가표·작품 이미지·수정 이력 조회', 20),
+    ('evaluations.write', '평가', '평가표 작성과 본인 평가표 수정', 21),
+    ('evaluations.delete', '평가', '평가표 삭제', 22),
+    ('evaluations.edit_all', '평가', '다른 강사가 작성한 평가표 수정·삭제', 23),
+    ('templates.manage', '평가', '학원 공용 평가 템플릿 관리', 24),
+    ('rubric.manage', '평가', '평가 기준(루브릭) 관리', 25),
+    ('classes.read', '반', '반 목록과 배정 현황 조회', 30),
+    ('classes.write', '반', '반 생성·수정·삭제와 원생 배정', 31),
+    ('attendance.read', '출석', '출석 기록 조회', 40),
+    ('attendance.write', '출석', '출석 체크와 기록 수정', 41),
+    ('billing.read', '수강료', '미납 현황과 원생별 수강료 장부 조회', 50),
+    ('billing.write', '수강료', '수강료 플랜·청구·수납 관리', 51),
+    ('users.read', '관리', '관리자 목록 조회와 본인 계정 관리', 60),
+    ('users.manage', '관리', '다른 관리자 등록·수정·삭제', 61),
+    ('roles.manage', '관리', '역할과 권한 관리', 62),
+    ('audit.read', '관리', '감사 로그 조회', 63),
+    ('trash.manage', '관리', '휴지통 복원과 영구 삭제', 64);
+
+INSERT INTO roles (name, display_name, description, is_system) VALUES
+    ('super_admin', '최고관리자', '모든 권한을 가진 학원 운영 계정', TRUE),
+    ('admin', '일반관리자', '역할 관리와 다른 관리자 관리를 제외한 업무 전반', TRUE),
+    ('director', '원장', '관리자 계정 관리를 제외한 학원 업무 전반', FALSE),
+    ('instructor', '강사', '원생 조회, 평가표 작성, 출석 체크', FALSE),
+    ('front_desk', '데스크', '원생 등록, 반 배정, 출석, 수강료 관리', FALSE),
+    ('read_only', '열람 전용', '조회만 가능', FALSE);
+
+INSERT INTO role_permissions (role_id, permission)
+SELECT r.id, p.key FROM roles r CROSS JOIN permissions p
+WHERE r.name = 'super_admin'
+   OR (r.name = 'admin' AND p.key NOT IN ('users.manage', 'roles.manage', 'templates.manage'))
+   OR (r.name = 'director' AND p.key NOT IN ('users.manage', 'roles.manage'))
+   OR (r.name = 'instructor' AND p.key IN ('students.read', 'evaluations.read', 'evaluations.write', 'evaluations.delete', 'classes.read', 'attendance.read', 'attendance.write'))
+   OR (r.name = 'front_desk' AND p.key IN ('students.read', 'students.write', 'evaluations.read', 'classes.read', 'classes.write', 'attendance.read', 'attendance.write', 'billing.read', 'billing.write'))
+   OR (r.name = 'read_only' AND p.key IN ('students.read', 'evaluations.read', 'classes.read', 'attendance.read', 'billing.read'));
+
+-- 알 수 없는 역할은 기존 동작과 같이 일반관리자로 취급
+UPDATE users SET role = 'admin' WHERE role NOT IN (SELECT name FROM roles);
+
+ALTER TABLE users
+    ADD CONSTRAINT users_role_fkey FOREIGN KEY (role) REFERENCES roles(name);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE users DROP CONSTRAINT IF EXISTS users_role_fkey;
+UPDATE users SET role = 'admin' WHERE role NOT IN ('super_admin', 'admin');
+DROP TABLE IF EXISTS role_permissions;
+DROP TABLE IF EXISTS roles;
+DROP TABLE IF EXISTS permissions;
+-- +goose StatementEnd
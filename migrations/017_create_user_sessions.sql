@@ -0,0 +1,29 @@
+-- +goose Up
+-- +goose StatementBegin
+-- 로그인 세션마다 한 행. 쿠키에는 토큰 원문, DB에는 SHA-256 해시만 저장
+CREATE TABLE user_sessions (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    token_hash CHAR(64) NOT NULL UNIQUE,
+    user_agent TEXT NOT NULL DEFAULT '',
+    ip_address VARCHAR(45) NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    last_seen_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL
+);
+
+CREATE INDEX idx_user_sessions_user_id ON user_sessions(user_id);
+
+-- NULL이면 동시 로그인 수 제한 없음
+ALTER TABLE roles ADD COLUMN max_sessions INTEGER CHECK (max_sessions > 0);
+
+-- 기존 단일 세션 토큰은 더 이상 사용하지 않음 (배포 후 모두 다시 로그인)
+ALTER TABLE users DROP COLUMN session_token;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE users ADD COLUMN session_token VARCHAR(255);
+ALTER TABLE roles DROP COLUMN IF EXISTS max_sessions;
+DROP TABLE IF EXISTS user_sessions;
+-- +goose StatementEnd
@@ -0,0 +1,34 @@
+-- +goose Up
+-- +goose StatementBegin
+-- 로그인 시도 기록 (성공·실패 모두). 존재하지 않는 사용자명도 그대로 남김
+CREATE TABLE login_attempts (
+    id SERIAL PRIMARY KEY,
+    username VARCHAR(50) NOT NULL,
+    user_id INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    ip_address VARCHAR(45) NOT NULL,
+    user_agent TEXT NOT NULL DEFAULT '',
+    success BOOLEAN NOT NULL,
+    reason VARCHAR(20) NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+CREATE INDEX idx_login_attempts_created_at ON login_attempts(created_at);
+CREATE INDEX idx_login_attempts_ip_address ON login_attempts(ip_address, created_at);
+CREATE INDEX idx_login_attempts_user_id ON login_attempts(user_id, created_at);
+
+-- 연속 실패 횟수와 잠금 해제 시각. 로그인 성공이나 관리자 잠금 해제 시 초기화
+ALTER TABLE users
+    ADD COLUMN failed_login_count INTEGER NOT NULL DEFAULT 0,
+    ADD COLUMN locked_until TIMESTAMP WITH TIME ZONE;
+
+UPDATE permissions SET description = '감사 로그와 로그인 기록 조회' WHERE key = 'audit.read';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+UPDATE permissions SET description = '감사 로그 조회' WHERE key = 'audit.read';
+ALTER TABLE users
+    DROP COLUMN IF EXISTS locked_until,
+    DROP COLUMN IF EXISTS failed_login_count;
+DROP TABLE IF EXISTS login_attempts;
+-- +goose StatementEnd
@@ -0,0 +1,18 @@
+-- +goose Up
+-- +goose StatementBegin
+-- 관리자가 정해준 비밀번호(신규 등록, 재설정)는 다음 로그인 때 본인이 바꾸도록 함
+ALTER TABLE users
+    ADD COLUMN must_change_password BOOLEAN NOT NULL DEFAULT FALSE,
+    ADD COLUMN password_changed_at TIMESTAMP WITH TIME ZONE;
+
+-- 001에서 만든 기본 계정이 아직 admin123을 쓰고 있으면 변경 요구
+UPDATE users SET must_change_password = TRUE
+WHERE password_hash = '$2a$10$R6622lqkuY9SYIZnBeCVTeTkiCfa.xP6FcKYzt0ChI0rSQ0ScUSzi';
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE users
+    DROP COLUMN IF EXISTS password_changed_at,
+    DROP COLUMN IF EXISTS must_change_password;
+-- +goose StatementEnd
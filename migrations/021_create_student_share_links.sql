@@ -0,0 +1,15 @@
+-- +goose Up
+-- +goose StatementBegin
+-- 학부모 공유 링크 취소: 링크에는 발급 당시 version이 서명되어 있고, 취소하면 version을 올려
+-- 그 전에 발급한 링크를 모두 무효화
+CREATE TABLE student_share_links (
+    student_id INTEGER PRIMARY KEY REFERENCES students(id) ON DELETE CASCADE,
+    version INTEGER NOT NULL DEFAULT 0,
+    revoked_at TIMESTAMP WITH TIME ZONE
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS student_share_links;
+-- +goose StatementEnd
@@ -0,0 +1,15 @@
+-- +goose Up
+-- +goose StatementBegin
+-- 역할 생성·수정·삭제도 감사 로그에 기록
+ALTER TABLE audit_log DROP CONSTRAINT audit_log_entity_type_check;
+ALTER TABLE audit_log ADD CONSTRAINT audit_log_entity_type_check
+    CHECK (entity_type IN ('student', 'evaluation', 'user', 'role'));
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DELETE FROM audit_log WHERE entity_type = 'role';
+ALTER TABLE audit_log DROP CONSTRAINT audit_log_entity_type_check;
+ALTER TABLE audit_log ADD CONSTRAINT audit_log_entity_type_check
+    CHECK (entity_type IN ('student', 'evaluation', 'user'));
+-- +goose StatementEnd
@@ -0,0 +1,17 @@
+-- +goose Up
+-- +goose StatementBegin
+-- 최고관리자의 비밀번호 초기화(reset_password)를 감사 로그에 기록할 수 있도록 action 길이를 늘림
+ALTER TABLE audit_log ALTER COLUMN action TYPE VARCHAR(20);
+ALTER TABLE audit_log DROP CONSTRAINT audit_log_action_check;
+ALTER TABLE audit_log ADD CONSTRAINT audit_log_action_check
+    CHECK (action IN ('create', 'update', 'delete', 'restore', 'purge', 'reset_password'));
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DELETE FROM audit_log WHERE action = 'reset_password';
+ALTER TABLE audit_log DROP CONSTRAINT audit_log_action_check;
+ALTER TABLE audit_log ADD CONSTRAINT audit_log_action_check
+    CHECK (action IN ('create', 'update', 'delete', 'restore', 'purge'));
+ALTER TABLE audit_log ALTER COLUMN action TYPE VARCHAR(10);
+-- +goose StatementEnd
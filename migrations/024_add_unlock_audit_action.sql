@@ -0,0 +1,15 @@
+-- +goose Up
+-- +goose StatementBegin
+-- 관리자의 로그인 잠금 해제를 감사 로그에 기록
+ALTER TABLE audit_log DROP CONSTRAINT audit_log_action_check;
+ALTER TABLE audit_log ADD CONSTRAINT audit_log_action_check
+    CHECK (action IN ('create', 'update', 'delete', 'restore', 'purge', 'reset_password', 'unlock'));
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DELETE FROM audit_log WHERE action = 'unlock';
+ALTER TABLE audit_log DROP CONSTRAINT audit_log_action_check;
+ALTER TABLE audit_log ADD CONSTRAINT audit_log_action_check
+    CHECK (action IN ('create', 'update', 'delete', 'restore', 'purge', 'reset_password'));
+-- +goose StatementEnd
@@ -0,0 +1,15 @@
+-- +goose Up
+-- +goose StatementBegin
+-- 학부모 공유 링크 취소를 감사 로그에 기록
+ALTER TABLE audit_log DROP CONSTRAINT audit_log_action_check;
+ALTER TABLE audit_log ADD CONSTRAINT audit_log_action_check
+    CHECK (action IN ('create', 'update', 'delete', 'restore', 'purge', 'reset_password', 'unlock', 'reset_2fa', 'revoke_share'));
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DELETE FROM audit_log WHERE action = 'revoke_share';
+ALTER TABLE audit_log DROP CONSTRAINT audit_log_action_check;
+ALTER TABLE audit_log ADD CONSTRAINT audit_log_action_check
+    CHECK (action IN ('create', 'update', 'delete', 'restore', 'purge', 'reset_password', 'unlock', 'reset_2fa'));
+-- +goose StatementEnd
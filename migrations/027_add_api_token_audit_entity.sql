@@ -0,0 +1,15 @@
+-- +goose Up
+-- +goose StatementBegin
+-- API 토큰 발급·폐기도 감사 로그에 기록
+ALTER TABLE audit_log DROP CONSTRAINT audit_log_entity_type_check;
+ALTER TABLE audit_log ADD CONSTRAINT audit_log_entity_type_check
+    CHECK (entity_type IN ('student', 'evaluation', 'user', 'role', 'api_token'));
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DELETE FROM audit_log WHERE entity_type = 'api_token';
+ALTER TABLE audit_log DROP CONSTRAINT audit_log_entity_type_check;
+ALTER TABLE audit_log ADD CONSTRAINT audit_log_entity_type_check
+    CHECK (entity_type IN ('student', 'evaluation', 'user', 'role'));
+-- +goose StatementEnd